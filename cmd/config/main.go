@@ -0,0 +1,32 @@
+package main
+
+import (
+	"auth-haven/internal/config"
+	"fmt"
+	"log"
+	"os"
+)
+
+// config inspects the server configuration. "config print" writes the
+// effective settings, after the config file and environment are applied, as
+// YAML with secrets masked, then reports any validation errors.
+func main() {
+	if len(os.Args) != 2 || os.Args[1] != "print" {
+		fmt.Fprintln(os.Stderr, "usage: config print")
+		os.Exit(2)
+	}
+
+	cfg, err := config.Read()
+	if err != nil {
+		log.Fatalf("failed to read config: %v", err)
+	}
+	out, err := cfg.YAML()
+	if err != nil {
+		log.Fatalf("failed to render config: %v", err)
+	}
+	os.Stdout.Write(out)
+
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("invalid config:\n%v", err)
+	}
+}
@@ -3,26 +3,106 @@ package main
 import (
 	"auth-haven/internal/config"
 	"auth-haven/internal/db"
+	"auth-haven/internal/health"
+	"auth-haven/internal/lifecycle"
+	"auth-haven/internal/logging"
+	"auth-haven/internal/metrics"
 	"auth-haven/internal/server"
-	"log"
+	"auth-haven/internal/tracing"
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+const (
+	// traceFlushTimeout bounds how long exiting waits for spans to be
+	// exported
+	traceFlushTimeout = 5 * time.Second
+	dbCloseTimeout    = 5 * time.Second
 )
 
 func main() {
 	// Load config
 	cfg, err := config.Load()
 	if err != nil {
-		log.Fatalf("failed to load config: %v", err)
+		fatal("failed to load config", err)
+	}
+
+	// Set up logging
+	logger, err := logging.New(os.Stderr, cfg.LogFormat, cfg.LogLevel)
+	if err != nil {
+		fatal("failed to set up logging", err)
+	}
+	slog.SetDefault(logger)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// Everything started from here on is stopped in reverse order, so the
+	// database closes and traces flush last
+	lc := lifecycle.New()
+
+	// Set up tracing
+	shutdownTracing, err := tracing.Setup(ctx, cfg.TraceExporter)
+	if err != nil {
+		fatal("failed to set up tracing", err)
 	}
+	lc.OnStop("tracing", traceFlushTimeout, shutdownTracing)
 
 	// Connect to DB
 	conn, err := db.Connect(cfg.DBUrl)
 	if err != nil {
-		log.Fatalf("failed to connect DB: %v", err)
+		abort(lc, "failed to connect DB", err)
+	}
+	lc.OnStop("database", dbCloseTimeout, func(context.Context) error {
+		return conn.Close()
+	})
+	metrics.RegisterDB(conn)
+	traced := db.Traced(conn)
+	probe := &health.Probe{DB: conn}
+
+	// Start gRPC and HTTP servers
+	if err := server.StartGRPC(lc, cfg, traced, probe); err != nil {
+		abort(lc, "failed to start gRPC server", err)
 	}
-	defer conn.Close()
+	if err := server.StartHTTP(lc, cfg, traced, probe); err != nil {
+		abort(lc, "failed to start HTTP server", err)
+	}
+
+	// Readiness flips first at shutdown and load balancers get DrainDelay to
+	// notice before connections are drained
+	lc.OnStop("readiness", cfg.DrainDelay+time.Second, func(ctx context.Context) error {
+		probe.Shutdown()
+		select {
+		case <-time.After(cfg.DrainDelay):
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
 
-	// Start gRPC server
-	if err := server.StartGRPC(cfg, conn); err != nil {
-		log.Fatalf("server failed: %v", err)
+	select {
+	case <-ctx.Done():
+		// A second signal kills the process outright
+		stop()
+		slog.Info("shutting down")
+		lc.Stop()
+	case err := <-lc.Failed():
+		abort(lc, "server failed", err)
 	}
 }
+
+// abort stops what has been started and exits with an error
+func abort(lc *lifecycle.Lifecycle, msg string, err error) {
+	slog.Error(msg, "error", err)
+	lc.Stop()
+	os.Exit(1)
+}
+
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
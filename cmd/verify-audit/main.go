@@ -0,0 +1,72 @@
+package main
+
+import (
+	"auth-haven/internal/config"
+	"auth-haven/internal/db"
+	"auth-haven/internal/domain/audit"
+	"context"
+	"flag"
+	"log"
+	"os"
+)
+
+// verify-audit walks the audit hash chains and reports the first broken link
+// of each. It exits non-zero if any chain fails to verify.
+//
+//	verify-audit [-tenant <tenant id>] [-checkpoint]
+func main() {
+	tenantID := flag.String("tenant", "", "verify only this tenant's chain")
+	checkpoint := flag.Bool("checkpoint", false, "sign the current chain heads after verifying")
+	flag.Parse()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("failed to load config: %v", err)
+	}
+	conn, err := db.Connect(cfg.DBUrl)
+	if err != nil {
+		log.Fatalf("failed to connect DB: %v", err)
+	}
+	defer conn.Close()
+
+	ctx := context.Background()
+	repo := audit.AuditRepoImpl(conn)
+	signer := audit.NewSigner(cfg.JWTSecret)
+
+	chains := []string{*tenantID}
+	if *tenantID == "" {
+		heads, err := repo.ListChainHeads(ctx)
+		if err != nil {
+			log.Fatalf("failed to list chains: %v", err)
+		}
+		chains = chains[:0]
+		for _, h := range heads {
+			chains = append(chains, h.Chain)
+		}
+	}
+
+	broken := false
+	for _, chain := range chains {
+		n, link, err := audit.Verify(ctx, repo, signer, chain)
+		if err != nil {
+			log.Fatalf("failed to verify chain %q: %v", chain, err)
+		}
+		if link != nil {
+			log.Printf("❌ %v (%d entries verified before it)", link, n)
+			broken = true
+			continue
+		}
+		log.Printf("✅ chain %q: %d entries verified", chain, n)
+	}
+	if broken {
+		os.Exit(1)
+	}
+
+	if *checkpoint {
+		n, err := audit.SignCheckpoints(ctx, repo, signer)
+		if err != nil {
+			log.Fatalf("failed to sign checkpoints: %v", err)
+		}
+		log.Printf("signed %d checkpoints", n)
+	}
+}
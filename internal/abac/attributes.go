@@ -0,0 +1,60 @@
+package abac
+
+import (
+	"auth-haven/internal/domain/user"
+	"context"
+	"net"
+	"time"
+
+	"google.golang.org/grpc/peer"
+)
+
+// Attributes are the variables a condition is evaluated against
+type Attributes struct {
+	Principal map[string]any
+	Resource  map[string]any
+	Env       map[string]any
+}
+
+// NewAttributes gathers the principal's attributes from its user record and
+// the environment from the request context
+func NewAttributes(ctx context.Context, u *user.User, resource map[string]any) Attributes {
+	if resource == nil {
+		resource = map[string]any{}
+	}
+	principal := map[string]any{
+		"id":          u.ID,
+		"tenant_id":   u.TenantID,
+		"email":       u.Email,
+		"full_name":   u.FullName,
+		"status":      u.Status,
+		"role_id":     u.RoleId,
+		"org_unit_id": u.OrgUnitID,
+		"external_id": "",
+		"created_at":  u.CreatedAt,
+	}
+	if u.ExternalID != nil {
+		principal["external_id"] = *u.ExternalID
+	}
+	return Attributes{
+		Principal: principal,
+		Resource:  resource,
+		Env: map[string]any{
+			"time": time.Now(),
+			"ip":   clientIP(ctx),
+		},
+	}
+}
+
+// clientIP returns the address of the RPC's peer, or "" outside an RPC
+func clientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
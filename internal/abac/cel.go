@@ -0,0 +1,124 @@
+package abac
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/ext"
+)
+
+const (
+	// maxConditionCost bounds the work a single condition may do
+	maxConditionCost = 10_000
+	// maxPrograms bounds the compiled program cache; it is emptied when full
+	maxPrograms = 10_000
+)
+
+// Engine compiles policy conditions and evaluates them. Conditions are CEL
+// expressions returning a bool over three variables:
+//
+//	principal  the user being authorized: id, tenant_id, email, full_name,
+//	           status, role_id, org_unit_id, external_id, created_at
+//	resource   attributes of the resource, supplied by the caller
+//	env        time (a timestamp) and ip (the client address)
+//
+// For example:
+//
+//	resource.org_unit_id == principal.org_unit_id &&
+//	  env.time.getHours("Europe/Berlin") >= 9 && env.time.getHours("Europe/Berlin") < 17
+//
+// inNetwork(env.ip, "10.0.0.0/8") tests an address against a CIDR range.
+// Each distinct condition is compiled once and the program reused.
+type Engine struct {
+	env *cel.Env
+
+	mu       sync.Mutex
+	programs map[string]cel.Program
+}
+
+func NewEngine() (*Engine, error) {
+	env, err := cel.NewEnv(
+		cel.Variable("principal", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("resource", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("env", cel.MapType(cel.StringType, cel.DynType)),
+		cel.CrossTypeNumericComparisons(true),
+		ext.Strings(),
+		cel.Function("inNetwork",
+			cel.Overload("inNetwork_string_string", []*cel.Type{cel.StringType, cel.StringType}, cel.BoolType,
+				cel.BinaryBinding(inNetwork))),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &Engine{env: env, programs: map[string]cel.Program{}}, nil
+}
+
+// Compile checks a condition and returns its program, reusing the program
+// compiled earlier for the same source
+func (e *Engine) Compile(condition string) (cel.Program, error) {
+	e.mu.Lock()
+	prg, ok := e.programs[condition]
+	e.mu.Unlock()
+	if ok {
+		return prg, nil
+	}
+
+	ast, iss := e.env.Compile(condition)
+	if iss.Err() != nil {
+		return nil, iss.Err()
+	}
+	// A bare attribute such as resource.public is dyn and checked when run
+	if t := ast.OutputType(); t != cel.BoolType && t != cel.DynType {
+		return nil, fmt.Errorf("condition must be a bool, not %s", ast.OutputType())
+	}
+	prg, err := e.env.Program(ast, cel.CostLimit(maxConditionCost))
+	if err != nil {
+		return nil, err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if len(e.programs) >= maxPrograms {
+		clear(e.programs)
+	}
+	e.programs[condition] = prg
+	return prg, nil
+}
+
+// Eval reports whether a condition holds for the given attributes
+func (e *Engine) Eval(condition string, attrs Attributes) (bool, error) {
+	prg, err := e.Compile(condition)
+	if err != nil {
+		return false, err
+	}
+	out, _, err := prg.Eval(map[string]any{
+		"principal": attrs.Principal,
+		"resource":  attrs.Resource,
+		"env":       attrs.Env,
+	})
+	if err != nil {
+		return false, err
+	}
+	b, ok := out.Value().(bool)
+	if !ok {
+		return false, errors.New("condition did not return a bool")
+	}
+	return b, nil
+}
+
+func inNetwork(ip, cidr ref.Val) ref.Val {
+	addr, err := netip.ParseAddr(fmt.Sprint(ip.Value()))
+	if err != nil {
+		return types.NewErr("inNetwork: invalid address %q", ip.Value())
+	}
+	prefix, err := netip.ParsePrefix(fmt.Sprint(cidr.Value()))
+	if err != nil {
+		return types.NewErr("inNetwork: invalid network %q", cidr.Value())
+	}
+	return types.Bool(prefix.Contains(addr.Unmap()))
+}
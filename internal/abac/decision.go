@@ -0,0 +1,136 @@
+package abac
+
+import (
+	"auth-haven/internal/auth"
+	"auth-haven/internal/domain/policy"
+	"auth-haven/internal/domain/role"
+	"auth-haven/internal/domain/user"
+	"context"
+	"fmt"
+)
+
+// Decision records whether a permission was granted and why
+type Decision struct {
+	Allowed bool
+	Reason  string
+	// Grants are the principal's roles that carry the permission
+	Grants []Grant
+	// Conditions are the policies attached to the permission itself
+	Conditions []Result
+}
+
+// Grant is one role carrying the permission. Owner roles are never
+// conditioned, so a tenant cannot lock its owners out.
+type Grant struct {
+	Role          *role.Role
+	Unconditional bool
+	Conditions    []Result
+	Satisfied     bool
+}
+
+// Result is the outcome of one policy's condition. A condition that fails to
+// evaluate, for instance because a resource attribute is missing, does not
+// hold.
+type Result struct {
+	Policy    *policy.Policy
+	Satisfied bool
+	Err       error
+}
+
+// Authorizer decides permissions from roles and the policies attached to them
+type Authorizer struct {
+	Policies policy.PolicyRepository
+	Engine   *Engine
+}
+
+// Decide reports whether principal, holding roles held, is granted perm on a
+// resource with the given attributes. The permission is granted when some
+// role carrying it has all of its conditions hold, and all the conditions
+// attached to the permission hold as well.
+func (a *Authorizer) Decide(ctx context.Context, principal *user.User, held []*role.Role, perm string, resource map[string]any) (*Decision, error) {
+	d := &Decision{}
+	var conditioned []int64
+	for _, r := range held {
+		if !auth.HasPermission(r.Permissions, perm) {
+			continue
+		}
+		g := Grant{Role: r, Unconditional: IsUnconditional(r)}
+		if !g.Unconditional {
+			conditioned = append(conditioned, r.ID)
+		}
+		d.Grants = append(d.Grants, g)
+	}
+	if len(d.Grants) == 0 {
+		d.Reason = fmt.Sprintf("no role grants %s", perm)
+		return d, nil
+	}
+
+	policies, err := a.Policies.ListApplicable(ctx, principal.TenantID, conditioned, perm)
+	if err != nil {
+		return nil, err
+	}
+	attrs := NewAttributes(ctx, principal, resource)
+	byRole := map[int64][]Result{}
+	for _, p := range policies {
+		res := Result{Policy: p}
+		res.Satisfied, res.Err = a.Engine.Eval(p.Condition, attrs)
+		if p.RoleID != nil {
+			byRole[*p.RoleID] = append(byRole[*p.RoleID], res)
+		} else {
+			d.Conditions = append(d.Conditions, res)
+		}
+	}
+
+	var granted *Grant
+	for i := range d.Grants {
+		g := &d.Grants[i]
+		g.Satisfied = true
+		if !g.Unconditional {
+			g.Conditions = byRole[g.Role.ID]
+			g.Satisfied = allSatisfied(g.Conditions)
+		}
+		if g.Satisfied && (granted == nil || g.Unconditional && !granted.Unconditional) {
+			granted = g
+		}
+	}
+	switch {
+	case granted == nil:
+		d.Reason = fmt.Sprintf("the conditions of every role granting %s failed", perm)
+	case granted.Unconditional:
+		d.Allowed = true
+		d.Reason = fmt.Sprintf("granted by owner role %q", granted.Role.Name)
+	case !allSatisfied(d.Conditions):
+		d.Reason = fmt.Sprintf("the conditions on %s failed", perm)
+	default:
+		d.Allowed = true
+		d.Reason = fmt.Sprintf("granted by role %q", granted.Role.Name)
+	}
+	return d, nil
+}
+
+// Unconditional reports whether the permission is granted regardless of any
+// policy: by an owner role, or by a role with no conditions on a permission
+// that has none either
+func (d *Decision) Unconditional() bool {
+	for _, g := range d.Grants {
+		if g.Unconditional || len(g.Conditions) == 0 && len(d.Conditions) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func allSatisfied(results []Result) bool {
+	for _, r := range results {
+		if !r.Satisfied {
+			return false
+		}
+	}
+	return true
+}
+
+// IsUnconditional reports whether a role is exempt from policies: owner roles,
+// those granting admin, always apply
+func IsUnconditional(r *role.Role) bool {
+	return auth.HasPermission(r.Permissions, auth.PermAdmin)
+}
@@ -0,0 +1,28 @@
+package abac
+
+import (
+	"auth-haven/internal/domain/role"
+	"auth-haven/internal/domain/user"
+	"context"
+)
+
+// WithPolicies wraps a role repository so that permission checks made
+// through it honour the tenant's policies. Listing a user's roles is
+// unchanged; the conditions apply when a permission is decided.
+func WithPolicies(roles role.RoleRepository, authorizer *Authorizer) role.RoleRepository {
+	return &policyRoles{RoleRepository: roles, authorizer: authorizer}
+}
+
+type policyRoles struct {
+	role.RoleRepository
+	authorizer *Authorizer
+}
+
+// Decide decides perm for principal from the roles it holds
+func (r *policyRoles) Decide(ctx context.Context, principal *user.User, perm string, resource map[string]any) (*Decision, error) {
+	held, err := r.ListByUser(ctx, principal.ID)
+	if err != nil {
+		return nil, err
+	}
+	return r.authorizer.Decide(ctx, principal, held, perm, resource)
+}
@@ -9,17 +9,38 @@ import (
 type Claims struct {
 	UserID   string `json:"user_id"`
 	TenantID string `json:"tenant_id"`
+	Scope    string `json:"scope,omitempty"`
+	Act      *Actor `json:"act,omitempty"`
+
+	// ImpersonationID is set on tokens issued to an operator acting as UserID;
+	// Act then names the operator
+	ImpersonationID string `json:"imp,omitempty"`
 	jwt.RegisteredClaims
 }
 
-func GenerateToken(secret, userID, tenantID string) (string, error) {
-	claims := Claims{
+// Actor identifies the party acting on behalf of the token subject (RFC 8693 "act").
+// Nested actors record the prior delegation chain.
+type Actor struct {
+	Subject string `json:"sub"`
+	Act     *Actor `json:"act,omitempty"`
+}
+
+func GenerateToken(secret, userID, tenantID string, ttl time.Duration) (string, error) {
+	claims := &Claims{
 		UserID:   userID,
 		TenantID: tenantID,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
+			Subject: userID,
 		},
 	}
+	return SignToken(secret, claims, ttl)
+}
+
+// SignToken stamps the issue and expiry times on claims and signs them
+func SignToken(secret string, claims *Claims, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims.IssuedAt = jwt.NewNumericDate(now)
+	claims.ExpiresAt = jwt.NewNumericDate(now.Add(ttl))
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString([]byte(secret))
 }
@@ -27,7 +48,7 @@ func GenerateToken(secret, userID, tenantID string) (string, error) {
 func ParseToken(secret, tokenStr string) (*Claims, error) {
 	token, err := jwt.ParseWithClaims(tokenStr, &Claims{}, func(t *jwt.Token) (interface{}, error) {
 		return []byte(secret), nil
-	})
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
 	if err != nil {
 		return nil, err
 	}
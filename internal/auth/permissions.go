@@ -0,0 +1,73 @@
+package auth
+
+import "encoding/json"
+
+// Permission names checked by the services
+const (
+	PermAdmin          = "admin"
+	PermImpersonate    = "users.impersonate"
+	PermManageSSO      = "sso.manage"
+	PermProvision      = "provisioning.manage"
+	PermReadUsers      = "users.read"
+	PermManageUsers    = "users.manage"
+	PermManageTenant   = "tenant.manage"
+	PermReadRoles      = "roles.read"
+	PermManageRoles    = "roles.manage"
+	PermReadOrgUnits   = "orgunits.read"
+	PermManageOrgUnits = "orgunits.manage"
+	PermReadGroups     = "groups.read"
+	PermManageGroups   = "groups.manage"
+	PermReadAudit      = "audit.read"
+	PermReadPolicies   = "policies.read"
+	PermManagePolicies = "policies.manage"
+	PermReadRelations  = "relationships.read"
+	PermWriteRelations = "relationships.write"
+	PermOperateTenants = "tenants.operate"
+)
+
+// restricted permissions are never implied by admin and must be granted explicitly
+var restricted = map[string]bool{
+	PermImpersonate:    true,
+	PermOperateTenants: true,
+}
+
+// implied lists the permissions that also grant a permission
+var implied = map[string][]string{
+	PermReadUsers:     {PermManageUsers},
+	PermReadRoles:     {PermManageRoles},
+	PermReadOrgUnits:  {PermManageOrgUnits},
+	PermReadGroups:    {PermManageGroups},
+	PermReadPolicies:  {PermManagePolicies},
+	PermReadRelations: {PermWriteRelations},
+}
+
+// HasPermission reports whether a role's permission document grants name
+func HasPermission(doc []byte, name string) bool {
+	perms := map[string]bool{}
+	if len(doc) == 0 || json.Unmarshal(doc, &perms) != nil {
+		return false
+	}
+	if perms[name] {
+		return true
+	}
+	for _, p := range implied[name] {
+		if perms[p] {
+			return true
+		}
+	}
+	return perms[PermAdmin] && !restricted[name]
+}
+
+// Privileged reports whether a role's permission document grants admin or a
+// restricted permission
+func Privileged(doc []byte) bool {
+	if HasPermission(doc, PermAdmin) {
+		return true
+	}
+	for name := range restricted {
+		if HasPermission(doc, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"net/url"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// masked replaces a secret in printed configuration
+const masked = "********"
+
+// YAML renders the effective configuration as a config file would hold it,
+// with secrets masked
+func (c *Config) YAML() ([]byte, error) {
+	doc := &yaml.Node{Kind: yaml.MappingNode}
+	for _, f := range c.fields() {
+		var value string
+		switch v := f.value.Interface().(type) {
+		case string:
+			value = maskSecret(f.secret, v)
+		case time.Duration:
+			value = v.String()
+		default:
+			n := &yaml.Node{}
+			if err := n.Encode(v); err != nil {
+				return nil, err
+			}
+			doc.Content = append(doc.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: f.key()}, n)
+			continue
+		}
+		doc.Content = append(doc.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: f.key()},
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value},
+		)
+	}
+	return yaml.Marshal(doc)
+}
+
+// maskSecret hides a secret value. URLs keep everything but the password.
+func maskSecret(kind, v string) string {
+	switch {
+	case kind == "" || v == "":
+		return v
+	case kind == "url":
+		if u, err := url.Parse(v); err == nil {
+			return u.Redacted()
+		}
+	}
+	return masked
+}
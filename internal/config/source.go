@@ -0,0 +1,130 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// field is one setting of Config
+type field struct {
+	env    string
+	secret string
+	value  reflect.Value
+}
+
+// key is the field's name in a config file
+func (f field) key() string {
+	return strings.ToLower(f.env)
+}
+
+// fields lists the settings of c in declaration order
+func (c *Config) fields() []field {
+	v := reflect.ValueOf(c).Elem()
+	t := v.Type()
+	fields := make([]field, 0, t.NumField())
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if env := sf.Tag.Get("env"); env != "" {
+			fields = append(fields, field{env: env, secret: sf.Tag.Get("secret"), value: v.Field(i)})
+		}
+	}
+	return fields
+}
+
+// set parses s into the field
+func (f field) set(s string) error {
+	switch f.value.Interface().(type) {
+	case string:
+		f.value.SetString(s)
+	case bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return fmt.Errorf("%s: %q is not a boolean", f.env, s)
+		}
+		f.value.SetBool(b)
+	case time.Duration:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return fmt.Errorf("%s: %q is not a duration such as 90s or 24h", f.env, s)
+		}
+		f.value.SetInt(int64(d))
+	default:
+		return fmt.Errorf("%s: unsupported type %s", f.env, f.value.Type())
+	}
+	return nil
+}
+
+// loadFile applies a YAML or TOML file, chosen by its extension
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	values := map[string]any{}
+	switch filepath.Ext(path) {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		if err := dec.Decode(&values); err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+	case ".toml":
+		if err := toml.Unmarshal(data, &values); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported format %q; use .yaml, .yml or .toml", filepath.Ext(path))
+	}
+
+	fields := c.fields()
+	for key, v := range values {
+		i := slices.IndexFunc(fields, func(f field) bool { return f.key() == key })
+		if i < 0 {
+			return fmt.Errorf("unknown setting %q", key)
+		}
+		switch v.(type) {
+		case map[string]any, []any, nil:
+			return fmt.Errorf("%s: expected a single value", key)
+		}
+		if err := fields[i].set(fmt.Sprint(v)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadEnv applies environment variables. A variable may instead name a file
+// holding the value through <VAR>_FILE; setting both is an error.
+func (c *Config) loadEnv(lookup func(string) (string, bool)) error {
+	for _, f := range c.fields() {
+		val, ok := lookup(f.env)
+		if path, fromFile := lookup(f.env + "_FILE"); fromFile {
+			if ok {
+				return fmt.Errorf("%s and %s_FILE are both set", f.env, f.env)
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("%s_FILE: %w", f.env, err)
+			}
+			val, ok = strings.TrimRight(string(data), "\r\n"), true
+		}
+		if !ok {
+			continue
+		}
+		if err := f.set(val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
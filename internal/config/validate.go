@@ -0,0 +1,73 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// minJWTSecretLength is the shortest signing secret accepted outside dev
+const minJWTSecretLength = 32
+
+// Validate checks that the settings make sense together. Outside dev it also
+// refuses the built-in credentials and other settings that are only safe on
+// a developer's machine.
+func (c *Config) Validate() error {
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	for _, f := range c.fields() {
+		if d, ok := f.value.Interface().(time.Duration); ok && d < 0 {
+			fail("%s must not be negative", f.env)
+		}
+	}
+	if c.AccessTokenTTL == 0 || c.RefreshTokenTTL == 0 {
+		fail("ACCESS_TOKEN_TTL and REFRESH_TOKEN_TTL must be positive")
+	}
+	if c.TenantPurgeInterval == 0 || c.AuditCheckpointInterval == 0 {
+		fail("TENANT_PURGE_INTERVAL and AUDIT_CHECKPOINT_INTERVAL must be positive")
+	}
+	if c.GRPCPort == "" || c.HTTPPort == "" {
+		fail("GRPC_PORT and HTTP_PORT are required")
+	}
+	if _, err := url.Parse(c.DBUrl); err != nil || c.DBUrl == "" {
+		fail("DB_URL is not a valid URL")
+	}
+	if (c.SAMLCertFile == "") != (c.SAMLKeyFile == "") {
+		fail("SAML_CERT_FILE and SAML_KEY_FILE must be set together")
+	}
+	if !slices.Contains([]string{"text", "json"}, c.LogFormat) {
+		fail("LOG_FORMAT must be text or json")
+	}
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.LogLevel)); err != nil {
+		fail("LOG_LEVEL must be debug, info, warn or error")
+	}
+	if !slices.Contains([]string{"none", "stdout", "otlp"}, c.TraceExporter) {
+		fail("TRACE_EXPORTER must be none, stdout or otlp")
+	}
+
+	if c.Env != EnvDev {
+		if c.JWTSecret == defaultJWTSecret {
+			fail("JWT_SECRET is the built-in development secret; set APP_ENV=dev to allow it")
+		} else if len(c.JWTSecret) < minJWTSecretLength {
+			fail("JWT_SECRET must be at least %d characters", minJWTSecretLength)
+		}
+		if c.DBUrl == defaultDBUrl {
+			fail("DB_URL is the built-in development database; set APP_ENV=dev to allow it")
+		}
+		if !strings.HasPrefix(c.PublicURL, "https://") {
+			fail("PUBLIC_URL must use https outside dev")
+		}
+		if c.TraceExporter == "stdout" {
+			fail("TRACE_EXPORTER=stdout is for local debugging only")
+		}
+	}
+	return errors.Join(errs...)
+}
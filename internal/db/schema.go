@@ -0,0 +1,32 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// SchemaVersion is the migration the code expects the database to be at.
+// Bump it with every new migration.
+const SchemaVersion = 19
+
+var ErrSchemaDirty = errors.New("a migration failed part way and needs fixing")
+
+// CheckSchema verifies that migrations have been applied up to
+// SchemaVersion. A newer schema is accepted so that instances of the
+// previous release stay up while a rollout migrates the database.
+func CheckSchema(ctx context.Context, db DBTX) error {
+	var version int64
+	var dirty bool
+	err := db.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if err != nil {
+		return fmt.Errorf("reading schema version: %w", err)
+	}
+	if dirty {
+		return fmt.Errorf("schema version %d: %w", version, ErrSchemaDirty)
+	}
+	if version < SchemaVersion {
+		return fmt.Errorf("schema is at version %d, expected %d", version, SchemaVersion)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package db
+
+import (
+	"auth-haven/internal/tracing"
+	"context"
+	"database/sql"
+	"errors"
+	"runtime"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Traced wraps conn so that every query made with a context is recorded as
+// a span named after the repository method that made it. Spans carry the
+// SQL text but never argument values.
+func Traced(conn DBTX) DBTX {
+	return &tracedDB{DBTX: conn}
+}
+
+type tracedDB struct {
+	DBTX
+}
+
+func (t *tracedDB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	ctx, span := startQuery(ctx, query)
+	res, err := t.DBTX.ExecContext(ctx, query, args...)
+	tracing.End(span, err)
+	return res, err
+}
+
+func (t *tracedDB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	ctx, span := startQuery(ctx, query)
+	rows, err := t.DBTX.QueryContext(ctx, query, args...)
+	tracing.End(span, err)
+	return rows, err
+}
+
+func (t *tracedDB) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	ctx, span := startQuery(ctx, query)
+	row := t.DBTX.QueryRowContext(ctx, query, args...)
+	err := row.Err()
+	if errors.Is(err, sql.ErrNoRows) {
+		err = nil
+	}
+	tracing.End(span, err)
+	return row
+}
+
+func startQuery(ctx context.Context, query string) (context.Context, trace.Span) {
+	op, _, _ := strings.Cut(strings.TrimSpace(query), " ")
+	return tracing.Start(ctx, caller(),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system.name", "postgresql"),
+			attribute.String("db.operation.name", strings.ToUpper(op)),
+			attribute.String("db.query.text", query),
+		),
+	)
+}
+
+// caller names the function that called into tracedDB, such as
+// "user.userRepository.FindById"
+func caller() string {
+	pc, _, _, ok := runtime.Caller(3)
+	if !ok {
+		return "db.query"
+	}
+	name := runtime.FuncForPC(pc).Name()
+	name = name[strings.LastIndex(name, "/")+1:]
+	return strings.NewReplacer("(*", "", ")", "").Replace(name)
+}
@@ -0,0 +1,303 @@
+package audit
+
+import (
+	"auth-haven/internal/db"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+var ErrChainContention = errors.New("audit chain head kept moving")
+
+type AuditRepository interface {
+	Create(ctx context.Context, l *AuditLog) error
+	CreateBatch(ctx context.Context, logs []*AuditLog) error
+	ListEvents(ctx context.Context, f EventFilter) ([]*AuditLog, error)
+	ListChainHeads(ctx context.Context) ([]ChainHead, error)
+	ListChain(ctx context.Context, chain string, afterSeq int64, limit int) ([]*AuditLog, error)
+	CreateCheckpoint(ctx context.Context, cp *Checkpoint) error
+	LastCheckpoint(ctx context.Context, chain string) (*Checkpoint, error)
+	ListCheckpoints(ctx context.Context, chain string) ([]*Checkpoint, error)
+}
+
+type auditRepository struct {
+	db db.DBTX
+}
+
+func AuditRepoImpl(db db.DBTX) AuditRepository {
+	return &auditRepository{db: db}
+}
+
+const auditColumns = `log_id, user_id, tenant_id, action, method, outcome, ip_address, user_agent,
+                      impersonator_id, impersonation_id, created_at, seq, prev_hash, hash`
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanAuditLog(row scanner) (*AuditLog, error) {
+	l := &AuditLog{}
+	var seq sql.NullInt64
+	err := row.Scan(&l.ID, &l.UserID, &l.TenantID, &l.Action, &l.Method, &l.Outcome, &l.IPAddress, &l.UserAgent,
+		&l.ImpersonatorID, &l.ImpersonationID, &l.CreatedAt, &seq, &l.PrevHash, &l.Hash)
+	l.Seq = seq.Int64
+	return l, err
+}
+
+// Create implements AuditRepository.
+func (r *auditRepository) Create(ctx context.Context, l *AuditLog) error {
+	if err := r.CreateBatch(ctx, []*AuditLog{l}); err != nil {
+		return fmt.Errorf("AuditRepo.Create: %w", err)
+	}
+	return nil
+}
+
+// appendAttempts bounds how often an append retries after another writer
+// moved the chain head
+const appendAttempts = 10
+
+// CreateBatch appends entries to their tenants' chains, keeping the time each
+// was recorded at. Each chain is appended to in one statement that also
+// moves the chain head, and only if nobody else moved it first. Chains are
+// written one after another, so an error can leave earlier chains stored;
+// callers that retry should pass entries of a single chain.
+func (r *auditRepository) CreateBatch(ctx context.Context, logs []*AuditLog) error {
+	order, chains := byChain(logs)
+	for _, c := range order {
+		if err := r.appendChain(ctx, c, chains[c]); err != nil {
+			return fmt.Errorf("AuditRepo.CreateBatch: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *auditRepository) appendChain(ctx context.Context, chain string, logs []*AuditLog) error {
+	_, err := r.db.ExecContext(ctx, `INSERT INTO audit_chain_heads (chain, seq, hash) VALUES ($1, 0, $2)
+                                     ON CONFLICT DO NOTHING`, chain, genesis)
+	if err != nil {
+		return err
+	}
+
+	query := `WITH head AS (
+                  UPDATE audit_chain_heads SET seq=$2, hash=$3
+                  WHERE chain=$1 AND seq=$4
+                  RETURNING chain
+              )
+              INSERT INTO audit_logs (log_id, user_id, tenant_id, action, method, outcome, ip_address, user_agent,
+                                      impersonator_id, impersonation_id, created_at, seq, prev_hash, hash)
+              SELECT e.log_id::uuid, NULLIF(e.user_id, '')::uuid, NULLIF(e.tenant_id, '')::uuid,
+                     e.action, NULLIF(e.method, ''), NULLIF(e.outcome, ''),
+                     NULLIF(e.ip_address, ''), NULLIF(e.user_agent, ''),
+                     NULLIF(e.impersonator_id, '')::uuid, NULLIF(e.impersonation_id, '')::uuid,
+                     e.created_at::timestamp, e.seq, e.prev_hash, e.hash
+              FROM unnest($5::text[], $6::text[], $7::text[], $8::text[], $9::text[], $10::text[],
+                          $11::text[], $12::text[], $13::text[], $14::text[], $15::text[],
+                          $16::bigint[], $17::bytea[], $18::bytea[])
+                  AS e(log_id, user_id, tenant_id, action, method, outcome, ip_address, user_agent,
+                       impersonator_id, impersonation_id, created_at, seq, prev_hash, hash)
+              WHERE EXISTS (SELECT 1 FROM head)`
+
+	for range appendAttempts {
+		var head ChainHead
+		err := r.db.QueryRowContext(ctx, `SELECT chain, seq, hash FROM audit_chain_heads WHERE chain=$1`, chain).
+			Scan(&head.Chain, &head.Seq, &head.Hash)
+		if err != nil {
+			return err
+		}
+		expected := head.Seq
+		head = link(head, logs)
+
+		args := []any{chain, head.Seq, head.Hash, expected}
+		args = append(args, chainColumns(logs)...)
+		res, err := r.db.ExecContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		if n, _ := res.RowsAffected(); n == int64(len(logs)) {
+			return nil
+		}
+	}
+	return ErrChainContention
+}
+
+// chainColumns splits entries into one array argument per column
+func chainColumns(logs []*AuditLog) []any {
+	text := make([][]string, 11)
+	seqs := make([]int64, 0, len(logs))
+	prevs := make([][]byte, 0, len(logs))
+	hashes := make([][]byte, 0, len(logs))
+	for _, l := range logs {
+		for i, v := range []string{
+			l.ID,
+			value(l.UserID),
+			value(l.TenantID),
+			l.Action,
+			value(l.Method),
+			value(l.Outcome),
+			value(l.IPAddress),
+			value(l.UserAgent),
+			value(l.ImpersonatorID),
+			value(l.ImpersonationID),
+			l.CreatedAt.Format("2006-01-02 15:04:05.999999"),
+		} {
+			text[i] = append(text[i], v)
+		}
+		seqs = append(seqs, l.Seq)
+		prevs = append(prevs, l.PrevHash)
+		hashes = append(hashes, l.Hash)
+	}
+	args := make([]any, 0, len(text)+3)
+	for _, c := range text {
+		args = append(args, pq.Array(c))
+	}
+	return append(args, pq.Array(seqs), pq.Array(prevs), pq.Array(hashes))
+}
+
+// ListEvents returns up to f.Limit entries matching f, in order
+func (r *auditRepository) ListEvents(ctx context.Context, f EventFilter) ([]*AuditLog, error) {
+	conds := []string{"tenant_id=$1"}
+	args := []interface{}{f.TenantID}
+
+	for _, c := range []struct{ col, val string }{
+		{"user_id", f.UserID},
+		{"action", f.Action},
+		{"outcome", f.Outcome},
+	} {
+		if c.val != "" {
+			args = append(args, c.val)
+			conds = append(conds, fmt.Sprintf("%s=$%d", c.col, len(args)))
+		}
+	}
+	if !f.From.IsZero() {
+		args = append(args, f.From)
+		conds = append(conds, fmt.Sprintf("created_at >= $%d", len(args)))
+	}
+	if !f.To.IsZero() {
+		args = append(args, f.To)
+		conds = append(conds, fmt.Sprintf("created_at < $%d", len(args)))
+	}
+	order, cmp := "DESC", "<"
+	if f.Ascending {
+		order, cmp = "ASC", ">"
+	}
+	if f.After != nil {
+		args = append(args, f.After.CreatedAt, f.After.ID)
+		conds = append(conds, fmt.Sprintf("(created_at, log_id) %s ($%d, $%d)", cmp, len(args)-1, len(args)))
+	}
+
+	limit := f.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+	query := fmt.Sprintf(`SELECT %s FROM audit_logs WHERE %s
+                          ORDER BY created_at %s, log_id %s LIMIT %d`,
+		auditColumns, strings.Join(conds, " AND "), order, order, limit)
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("AuditRepo.ListEvents: %w", err)
+	}
+	defer rows.Close()
+
+	logs := []*AuditLog{}
+	for rows.Next() {
+		l, err := scanAuditLog(rows)
+		if err != nil {
+			return nil, fmt.Errorf("AuditRepo.ListEvents: %w", err)
+		}
+		logs = append(logs, l)
+	}
+	return logs, rows.Err()
+}
+
+// ListChainHeads implements AuditRepository.
+func (r *auditRepository) ListChainHeads(ctx context.Context) ([]ChainHead, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT chain, seq, hash FROM audit_chain_heads ORDER BY chain`)
+	if err != nil {
+		return nil, fmt.Errorf("AuditRepo.ListChainHeads: %w", err)
+	}
+	defer rows.Close()
+
+	heads := []ChainHead{}
+	for rows.Next() {
+		var h ChainHead
+		if err := rows.Scan(&h.Chain, &h.Seq, &h.Hash); err != nil {
+			return nil, fmt.Errorf("AuditRepo.ListChainHeads: %w", err)
+		}
+		heads = append(heads, h)
+	}
+	return heads, rows.Err()
+}
+
+// ListChain returns up to limit entries of a chain after afterSeq, in order
+func (r *auditRepository) ListChain(ctx context.Context, chain string, afterSeq int64, limit int) ([]*AuditLog, error) {
+	query := fmt.Sprintf(`SELECT %s FROM audit_logs
+                          WHERE COALESCE(tenant_id::text, '')=$1 AND seq > $2
+                          ORDER BY seq LIMIT %d`, auditColumns, limit)
+	rows, err := r.db.QueryContext(ctx, query, chain, afterSeq)
+	if err != nil {
+		return nil, fmt.Errorf("AuditRepo.ListChain: %w", err)
+	}
+	defer rows.Close()
+
+	logs := []*AuditLog{}
+	for rows.Next() {
+		l, err := scanAuditLog(rows)
+		if err != nil {
+			return nil, fmt.Errorf("AuditRepo.ListChain: %w", err)
+		}
+		logs = append(logs, l)
+	}
+	return logs, rows.Err()
+}
+
+// CreateCheckpoint implements AuditRepository.
+func (r *auditRepository) CreateCheckpoint(ctx context.Context, cp *Checkpoint) error {
+	query := `INSERT INTO audit_checkpoints (chain, seq, hash, signature) VALUES ($1, $2, $3, $4)
+              ON CONFLICT DO NOTHING
+              RETURNING created_at`
+	err := r.db.QueryRowContext(ctx, query, cp.Chain, cp.Seq, cp.Hash, cp.Signature).Scan(&cp.CreatedAt)
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("AuditRepo.CreateCheckpoint: %w", err)
+	}
+	return nil
+}
+
+// LastCheckpoint returns the chain's latest checkpoint, or nil if it has none
+func (r *auditRepository) LastCheckpoint(ctx context.Context, chain string) (*Checkpoint, error) {
+	query := `SELECT chain, seq, hash, signature, created_at FROM audit_checkpoints
+              WHERE chain=$1 ORDER BY seq DESC LIMIT 1`
+	cp := &Checkpoint{}
+	err := r.db.QueryRowContext(ctx, query, chain).Scan(&cp.Chain, &cp.Seq, &cp.Hash, &cp.Signature, &cp.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("AuditRepo.LastCheckpoint: %w", err)
+	}
+	return cp, nil
+}
+
+// ListCheckpoints implements AuditRepository.
+func (r *auditRepository) ListCheckpoints(ctx context.Context, chain string) ([]*Checkpoint, error) {
+	query := `SELECT chain, seq, hash, signature, created_at FROM audit_checkpoints
+              WHERE chain=$1 ORDER BY seq`
+	rows, err := r.db.QueryContext(ctx, query, chain)
+	if err != nil {
+		return nil, fmt.Errorf("AuditRepo.ListCheckpoints: %w", err)
+	}
+	defer rows.Close()
+
+	checkpoints := []*Checkpoint{}
+	for rows.Next() {
+		cp := &Checkpoint{}
+		if err := rows.Scan(&cp.Chain, &cp.Seq, &cp.Hash, &cp.Signature, &cp.CreatedAt); err != nil {
+			return nil, fmt.Errorf("AuditRepo.ListCheckpoints: %w", err)
+		}
+		checkpoints = append(checkpoints, cp)
+	}
+	return checkpoints, rows.Err()
+}
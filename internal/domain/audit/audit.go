@@ -7,7 +7,65 @@ type AuditLog struct {
 	UserID    *string   `db:"user_id" json:"user_id,omitempty"`
 	TenantID  *string   `db:"tenant_id" json:"tenant_id,omitempty"`
 	Action    string    `db:"action" json:"action"`
+	Method    *string   `db:"method" json:"method,omitempty"`
+	Outcome   *string   `db:"outcome" json:"outcome,omitempty"` // gRPC status code name
 	IPAddress *string   `db:"ip_address" json:"ip_address,omitempty"`
 	UserAgent *string   `db:"user_agent" json:"user_agent,omitempty"`
 	CreatedAt time.Time `db:"created_at" json:"created_at"`
+
+	// Set when the action was performed by an operator impersonating UserID
+	ImpersonatorID  *string `db:"impersonator_id" json:"impersonator_id,omitempty"`
+	ImpersonationID *string `db:"impersonation_id" json:"impersonation_id,omitempty"`
+
+	// Position in the tenant's hash chain; Hash covers PrevHash and the
+	// entry's fields
+	Seq      int64  `db:"seq" json:"seq,omitempty"`
+	PrevHash []byte `db:"prev_hash" json:"prev_hash,omitempty"`
+	Hash     []byte `db:"hash" json:"hash,omitempty"`
+}
+
+// Chain names the hash chain an entry belongs to: its tenant, or "" for
+// entries outside any tenant
+func (l *AuditLog) Chain() string {
+	if l.TenantID == nil {
+		return ""
+	}
+	return *l.TenantID
+}
+
+// EventFilter selects audit entries; zero values match everything. Entries
+// are ordered newest first, or oldest first with Ascending, and After resumes
+// the order past a previously returned entry.
+type EventFilter struct {
+	TenantID  string
+	UserID    string
+	Action    string
+	Outcome   string
+	From      time.Time // inclusive
+	To        time.Time // exclusive
+	After     *Cursor
+	Ascending bool
+	Limit     int
+}
+
+// Cursor is the position of an entry in the created_at, log_id order
+type Cursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// Checkpoint is a signed statement that a chain had reached Seq with Hash
+type Checkpoint struct {
+	Chain     string    `db:"chain" json:"chain"`
+	Seq       int64     `db:"seq" json:"seq"`
+	Hash      []byte    `db:"hash" json:"hash"`
+	Signature []byte    `db:"signature" json:"signature"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+// ChainHead is the last link of a chain
+type ChainHead struct {
+	Chain string
+	Seq   int64
+	Hash  []byte
 }
@@ -0,0 +1,227 @@
+package audit
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// genesis is the previous hash of the first entry of every chain
+var genesis = make([]byte, sha256.Size)
+
+// normalize puts an entry in the form it is stored in, so that the hash
+// computed before insertion matches the row read back: IDs are canonical,
+// text is cut to the column sizes, empty optional fields are nil and the
+// time has the column's microsecond precision
+func (l *AuditLog) normalize() {
+	if l.ID == "" {
+		l.ID = uuid.NewString()
+	}
+	for _, id := range []**string{&l.UserID, &l.TenantID, &l.ImpersonatorID, &l.ImpersonationID} {
+		if *id == nil {
+			continue
+		}
+		parsed, err := uuid.Parse(**id)
+		if err != nil {
+			*id = nil
+			continue
+		}
+		s := parsed.String()
+		*id = &s
+	}
+	l.Action = truncate(l.Action, maxActionLength)
+	l.Method = nullable(truncate(value(l.Method), maxMethodLength))
+	l.Outcome = nullable(truncate(value(l.Outcome), maxOutcomeLength))
+	l.IPAddress = nullable(truncate(value(l.IPAddress), maxIPAddressLength))
+	l.UserAgent = nullable(truncate(value(l.UserAgent), maxUserAgentLength))
+	if l.CreatedAt.IsZero() {
+		l.CreatedAt = time.Now()
+	}
+	l.CreatedAt = l.CreatedAt.UTC().Truncate(time.Microsecond)
+}
+
+// ComputeHash returns the hash of the entry linked after prev
+func (l *AuditLog) ComputeHash(prev []byte) []byte {
+	h := sha256.New()
+	h.Write(prev)
+	for _, f := range []string{
+		strconv.FormatInt(l.Seq, 10),
+		l.ID,
+		value(l.TenantID),
+		value(l.UserID),
+		l.Action,
+		value(l.Method),
+		value(l.Outcome),
+		value(l.IPAddress),
+		value(l.UserAgent),
+		value(l.ImpersonatorID),
+		value(l.ImpersonationID),
+		l.CreatedAt.UTC().Format(time.RFC3339Nano),
+	} {
+		// Length prefixes keep field boundaries unambiguous
+		h.Write(binary.AppendUvarint(nil, uint64(len(f))))
+		h.Write([]byte(f))
+	}
+	return h.Sum(nil)
+}
+
+// byChain normalizes entries and groups them by chain, returning the chains
+// in the order they first appear
+func byChain(logs []*AuditLog) ([]string, map[string][]*AuditLog) {
+	chains := map[string][]*AuditLog{}
+	order := []string{}
+	for _, l := range logs {
+		l.normalize()
+		c := l.Chain()
+		if _, ok := chains[c]; !ok {
+			order = append(order, c)
+		}
+		chains[c] = append(chains[c], l)
+	}
+	return order, chains
+}
+
+// link assigns consecutive positions to entries appended after head
+func link(head ChainHead, logs []*AuditLog) ChainHead {
+	for _, l := range logs {
+		head.Seq++
+		l.Seq = head.Seq
+		l.PrevHash = head.Hash
+		l.Hash = l.ComputeHash(head.Hash)
+		head.Hash = l.Hash
+	}
+	return head
+}
+
+// Signer signs checkpoints with a key derived from the server's signing key
+type Signer struct {
+	key []byte
+}
+
+func NewSigner(serverKey string) *Signer {
+	mac := hmac.New(sha256.New, []byte(serverKey))
+	mac.Write([]byte("audit-checkpoint"))
+	return &Signer{key: mac.Sum(nil)}
+}
+
+func (s *Signer) Sign(chain string, seq int64, hash []byte) []byte {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(chain))
+	mac.Write([]byte{0})
+	mac.Write(binary.BigEndian.AppendUint64(nil, uint64(seq)))
+	mac.Write(hash)
+	return mac.Sum(nil)
+}
+
+func (s *Signer) Valid(cp *Checkpoint) bool {
+	return hmac.Equal(cp.Signature, s.Sign(cp.Chain, cp.Seq, cp.Hash))
+}
+
+// SignCheckpoints signs the head of every chain that has grown since its
+// last checkpoint, returning how many were signed
+func SignCheckpoints(ctx context.Context, repo AuditRepository, signer *Signer) (int, error) {
+	heads, err := repo.ListChainHeads(ctx)
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, h := range heads {
+		last, err := repo.LastCheckpoint(ctx, h.Chain)
+		if err != nil {
+			return n, err
+		}
+		if h.Seq == 0 || (last != nil && last.Seq >= h.Seq) {
+			continue
+		}
+		err = repo.CreateCheckpoint(ctx, &Checkpoint{
+			Chain:     h.Chain,
+			Seq:       h.Seq,
+			Hash:      h.Hash,
+			Signature: signer.Sign(h.Chain, h.Seq, h.Hash),
+		})
+		if err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, nil
+}
+
+// BrokenLink describes where a chain stops verifying
+type BrokenLink struct {
+	Chain  string
+	Seq    int64
+	Reason string
+}
+
+func (b *BrokenLink) Error() string {
+	chain := b.Chain
+	if chain == "" {
+		chain = "(no tenant)"
+	}
+	return fmt.Sprintf("chain %s broken at seq %d: %s", chain, b.Seq, b.Reason)
+}
+
+// verifyPageSize is how many entries Verify reads at a time
+const verifyPageSize = 1000
+
+// Verify walks a chain from its first entry, recomputing every hash, and
+// checks its signed checkpoints against it. It returns the number of entries
+// verified and the first broken link found, if any.
+func Verify(ctx context.Context, repo AuditRepository, signer *Signer, chain string) (int64, *BrokenLink, error) {
+	checkpoints, err := repo.ListCheckpoints(ctx, chain)
+	if err != nil {
+		return 0, nil, err
+	}
+	pending := map[int64]*Checkpoint{}
+	for _, cp := range checkpoints {
+		if !signer.Valid(cp) {
+			return 0, &BrokenLink{chain, cp.Seq, "checkpoint signature is invalid"}, nil
+		}
+		pending[cp.Seq] = cp
+	}
+
+	prev, seq := genesis, int64(0)
+	for {
+		logs, err := repo.ListChain(ctx, chain, seq, verifyPageSize)
+		if err != nil {
+			return seq, nil, err
+		}
+		for _, l := range logs {
+			want := seq + 1
+			switch {
+			case l.Seq != want:
+				return seq, &BrokenLink{chain, want, fmt.Sprintf("entry missing, next entry has seq %d", l.Seq)}, nil
+			case !hmac.Equal(l.PrevHash, prev):
+				return seq, &BrokenLink{chain, want, "previous hash does not match the preceding entry"}, nil
+			case !hmac.Equal(l.Hash, l.ComputeHash(prev)):
+				return seq, &BrokenLink{chain, want, "entry was modified"}, nil
+			}
+			if cp, ok := pending[want]; ok {
+				if !hmac.Equal(cp.Hash, l.Hash) {
+					return seq, &BrokenLink{chain, want, "entry does not match the signed checkpoint"}, nil
+				}
+				delete(pending, want)
+			}
+			prev, seq = l.Hash, want
+		}
+		if len(logs) < verifyPageSize {
+			break
+		}
+	}
+
+	// A checkpoint past the end means entries were removed from the tail
+	if len(pending) > 0 {
+		last := slices.Max(slices.Collect(maps.Keys(pending)))
+		return seq, &BrokenLink{chain, seq + 1, fmt.Sprintf("chain ends before signed checkpoint at seq %d", last)}, nil
+	}
+	return seq, nil, nil
+}
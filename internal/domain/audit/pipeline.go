@@ -0,0 +1,194 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	ErrQueueFull      = errors.New("audit queue is full")
+	ErrPipelineClosed = errors.New("audit pipeline is closed")
+)
+
+// PipelineOptions size the queue and its batches
+type PipelineOptions struct {
+	// QueueSize bounds how many entries may wait to be written
+	QueueSize int
+	// BatchSize is the most entries written in one statement
+	BatchSize int
+	// FlushInterval is the longest an entry waits for its batch to fill
+	FlushInterval time.Duration
+	// EnqueueTimeout is how long Create waits for room in a full queue
+	// before the entry is dropped
+	EnqueueTimeout time.Duration
+	// OnWrite, if set, receives the entries of each chain in a batch once
+	// they are stored, with their chain positions assigned
+	OnWrite func(logs []*AuditLog)
+}
+
+// Stats are the pipeline's counters since it started
+type Stats struct {
+	Queued   int    // entries waiting now
+	Enqueued uint64 // entries accepted
+	Dropped  uint64 // entries refused because the queue stayed full
+	Written  uint64 // entries inserted
+	Failed   uint64 // entries lost to failed inserts
+}
+
+// writeAttempts is how many times a chain's entries are tried before they are
+// given up on
+const writeAttempts = 3
+
+// Pipeline writes audit entries asynchronously. Create queues an entry and
+// returns; a background worker inserts queued entries in batches. When the
+// queue is full Create waits up to EnqueueTimeout for room, slowing callers
+// down, and then drops the entry and reports ErrQueueFull. Close stops
+// accepting entries and flushes everything queued.
+//
+// Pipeline is itself an AuditRepository; everything but Create goes straight
+// to the wrapped repository.
+type Pipeline struct {
+	AuditRepository
+	opts  PipelineOptions
+	queue chan *AuditLog
+	done  chan struct{}
+
+	mu     sync.RWMutex
+	closed bool
+
+	enqueued atomic.Uint64
+	dropped  atomic.Uint64
+	written  atomic.Uint64
+	failed   atomic.Uint64
+}
+
+// NewPipeline starts a pipeline writing to repo
+func NewPipeline(repo AuditRepository, opts PipelineOptions) *Pipeline {
+	p := &Pipeline{
+		AuditRepository: repo,
+		opts:            opts,
+		queue:           make(chan *AuditLog, opts.QueueSize),
+		done:            make(chan struct{}),
+	}
+	go p.run()
+	return p
+}
+
+// Create queues an entry, stamping it with the current time if it has none
+func (p *Pipeline) Create(ctx context.Context, l *AuditLog) error {
+	if l.CreatedAt.IsZero() {
+		l.CreatedAt = time.Now()
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return ErrPipelineClosed
+	}
+	select {
+	case p.queue <- l:
+		p.enqueued.Add(1)
+		return nil
+	default:
+	}
+
+	timer := time.NewTimer(p.opts.EnqueueTimeout)
+	defer timer.Stop()
+	select {
+	case p.queue <- l:
+		p.enqueued.Add(1)
+		return nil
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+	p.dropped.Add(1)
+	return ErrQueueFull
+}
+
+// Stats returns the pipeline's counters
+func (p *Pipeline) Stats() Stats {
+	return Stats{
+		Queued:   len(p.queue),
+		Enqueued: p.enqueued.Load(),
+		Dropped:  p.dropped.Load(),
+		Written:  p.written.Load(),
+		Failed:   p.failed.Load(),
+	}
+}
+
+// Close stops accepting entries and waits until those queued are written or
+// ctx ends
+func (p *Pipeline) Close(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.queue)
+	}
+	p.mu.Unlock()
+
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *Pipeline) run() {
+	defer close(p.done)
+	ticker := time.NewTicker(p.opts.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*AuditLog, 0, p.opts.BatchSize)
+	for {
+		select {
+		case l, ok := <-p.queue:
+			if !ok {
+				p.write(batch)
+				return
+			}
+			batch = append(batch, l)
+			if len(batch) < p.opts.BatchSize {
+				continue
+			}
+		case <-ticker.C:
+		}
+		p.write(batch)
+		batch = batch[:0]
+	}
+}
+
+// write stores a batch one chain at a time. Each chain is appended in a
+// single statement, so retrying a chain that failed never rewrites the
+// entries of one already stored.
+func (p *Pipeline) write(batch []*AuditLog) {
+	order, chains := byChain(batch)
+	for _, c := range order {
+		p.writeChain(chains[c])
+	}
+}
+
+func (p *Pipeline) writeChain(logs []*AuditLog) {
+	var err error
+	for attempt := range writeAttempts {
+		if attempt > 0 {
+			time.Sleep(time.Duration(attempt) * 100 * time.Millisecond)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		err = p.AuditRepository.CreateBatch(ctx, logs)
+		cancel()
+		if err == nil {
+			p.written.Add(uint64(len(logs)))
+			if p.opts.OnWrite != nil {
+				p.opts.OnWrite(logs)
+			}
+			return
+		}
+	}
+	p.failed.Add(uint64(len(logs)))
+	slog.Error("audit entries lost", "count", len(logs), "error", err)
+}
@@ -0,0 +1,70 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// flakyRepo stores batches in memory, failing the first attempt at chains
+// listed in failOnce
+type flakyRepo struct {
+	AuditRepository
+	mu       sync.Mutex
+	failOnce map[string]bool
+	stored   map[string]int // log ID -> times stored
+}
+
+func (r *flakyRepo) CreateBatch(_ context.Context, logs []*AuditLog) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	order, chains := byChain(logs)
+	for _, c := range order {
+		if r.failOnce[c] {
+			r.failOnce[c] = false
+			return errors.New("connection reset")
+		}
+		for _, l := range chains[c] {
+			r.stored[l.ID]++
+		}
+	}
+	return nil
+}
+
+func TestPipelineRetriesOnlyFailedChains(t *testing.T) {
+	tenantA := "00000000-0000-4000-8000-00000000000a"
+	tenantB := "00000000-0000-4000-8000-00000000000b"
+	repo := &flakyRepo{failOnce: map[string]bool{tenantB: true}, stored: map[string]int{}}
+	p := NewPipeline(repo, PipelineOptions{
+		QueueSize:      10,
+		BatchSize:      10,
+		FlushInterval:  time.Hour,
+		EnqueueTimeout: time.Second,
+	})
+
+	ctx := context.Background()
+	for _, tenant := range []string{tenantA, tenantB, tenantA} {
+		if err := p.Create(ctx, &AuditLog{TenantID: &tenant, Action: "/test"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	closeCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := p.Close(closeCtx); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(repo.stored) != 3 {
+		t.Errorf("stored %d entries, want 3", len(repo.stored))
+	}
+	for id, n := range repo.stored {
+		if n != 1 {
+			t.Errorf("entry %s stored %d times", id, n)
+		}
+	}
+	if s := p.Stats(); s.Written != 3 || s.Failed != 0 {
+		t.Errorf("Stats() = %+v", s)
+	}
+}
@@ -0,0 +1,67 @@
+package audit
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// Column sizes in audit_logs; longer values are truncated
+const (
+	maxActionLength    = 100
+	maxMethodLength    = 255
+	maxOutcomeLength   = 32
+	maxIPAddressLength = 45
+	maxUserAgentLength = 255
+)
+
+// FromRequest fills in the RPC method, peer address and user agent of the
+// request in ctx, keeping any already set
+func (l *AuditLog) FromRequest(ctx context.Context) *AuditLog {
+	if l.Method == nil {
+		if method, ok := grpc.Method(ctx); ok {
+			l.Method = &method
+		}
+	}
+	if l.IPAddress == nil {
+		if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+			ip := p.Addr.String()
+			if host, _, err := net.SplitHostPort(ip); err == nil {
+				ip = host
+			}
+			l.IPAddress = &ip
+		}
+	}
+	if l.UserAgent == nil {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if ua := md.Get("user-agent"); len(ua) > 0 {
+			l.UserAgent = &ua[0]
+		}
+	}
+	return l
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
+
+// value returns the value of an optional column, or ""
+func value(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func nullable(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,63 @@
+package client
+
+import (
+	"auth-haven/internal/db"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrClientNotFound = errors.New("client not found")
+	ErrPolicyNotFound = errors.New("exchange policy not found")
+)
+
+type ClientRepository interface {
+	FindById(ctx context.Context, clientID string) (*Client, error)
+	FindExchangePolicy(ctx context.Context, clientID, audience string) (*ExchangePolicy, error)
+}
+
+type clientRepository struct {
+	db db.DBTX
+}
+
+func ClientRepoImpl(db db.DBTX) ClientRepository {
+	return &clientRepository{db: db}
+}
+
+// FindById implements ClientRepository.
+func (r *clientRepository) FindById(ctx context.Context, clientID string) (*Client, error) {
+	query := `SELECT client_id, client_secret_hash, name, created_at
+              FROM oauth_clients WHERE client_id=$1`
+	row := r.db.QueryRowContext(ctx, query, clientID)
+
+	c := &Client{}
+	err := row.Scan(&c.ID, &c.SecretHash, &c.Name, &c.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrClientNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ClientRepo.FindById: %w", err)
+	}
+	return c, nil
+}
+
+// FindExchangePolicy implements ClientRepository.
+func (r *clientRepository) FindExchangePolicy(ctx context.Context, clientID, audience string) (*ExchangePolicy, error) {
+	query := `SELECT policy_id, client_id, audience, scopes, token_ttl_seconds
+              FROM token_exchange_policies WHERE client_id=$1 AND audience=$2`
+	row := r.db.QueryRowContext(ctx, query, clientID, audience)
+
+	p := &ExchangePolicy{}
+	err := row.Scan(&p.ID, &p.ClientID, &p.Audience, pq.Array(&p.Scopes), &p.TokenTTLSeconds)
+	if err == sql.ErrNoRows {
+		return nil, ErrPolicyNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ClientRepo.FindExchangePolicy: %w", err)
+	}
+	return p, nil
+}
@@ -0,0 +1,20 @@
+package client
+
+import "time"
+
+// Client is a service that authenticates to us, e.g. to exchange tokens
+type Client struct {
+	ID         string    `db:"client_id" json:"id"`
+	SecretHash string    `db:"client_secret_hash" json:"-"`
+	Name       string    `db:"name" json:"name"`
+	CreatedAt  time.Time `db:"created_at" json:"created_at"`
+}
+
+// ExchangePolicy lists the scopes a client may request for one audience
+type ExchangePolicy struct {
+	ID              int64    `db:"policy_id" json:"id"`
+	ClientID        string   `db:"client_id" json:"client_id"`
+	Audience        string   `db:"audience" json:"audience"`
+	Scopes          []string `db:"scopes" json:"scopes"`
+	TokenTTLSeconds int      `db:"token_ttl_seconds" json:"token_ttl_seconds"`
+}
@@ -0,0 +1,152 @@
+package federation
+
+import (
+	"auth-haven/internal/db"
+	"auth-haven/internal/domain/identity"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrProviderAlreadyExists = errors.New("provider with this name already exists for this tenant")
+	ErrProviderNotFound      = errors.New("identity provider not found")
+	ErrStateNotFound         = errors.New("login state not found or expired")
+)
+
+type FederationRepository interface {
+	CreateProvider(ctx context.Context, p *OIDCProvider) (*OIDCProvider, error)
+	FindProviderById(ctx context.Context, providerID string) (*OIDCProvider, error)
+	ListProviders(ctx context.Context, tenantID string) ([]*OIDCProvider, error)
+	DeleteProvider(ctx context.Context, providerID string) error
+	CreateState(ctx context.Context, s *LoginState) error
+	ConsumeState(ctx context.Context, state string) (*LoginState, error)
+}
+
+type federationRepository struct {
+	db db.DBTX
+}
+
+func FederationRepoImpl(db db.DBTX) FederationRepository {
+	return &federationRepository{db: db}
+}
+
+const providerColumns = `provider_id, tenant_id, name, issuer, client_id, client_secret,
+                         redirect_url, scopes, claim_mappings, enabled, created_at`
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanProvider(row scanner) (*OIDCProvider, error) {
+	p := &OIDCProvider{}
+	err := row.Scan(&p.ID, &p.TenantID, &p.Name, &p.Issuer, &p.ClientID, &p.ClientSecret,
+		&p.RedirectURL, pq.Array(&p.Scopes), &p.ClaimMappings, &p.Enabled, &p.CreatedAt)
+	return p, err
+}
+
+// CreateProvider implements FederationRepository.
+func (r *federationRepository) CreateProvider(ctx context.Context, p *OIDCProvider) (*OIDCProvider, error) {
+	if len(p.ClaimMappings) == 0 {
+		p.ClaimMappings = []byte(`{}`)
+	}
+	query := `INSERT INTO oidc_providers (tenant_id, name, issuer, client_id, client_secret, redirect_url,
+                                          scopes, claim_mappings, enabled)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+              RETURNING provider_id, created_at`
+	err := r.db.QueryRowContext(ctx, query, p.TenantID, p.Name, p.Issuer, p.ClientID, p.ClientSecret,
+		p.RedirectURL, pq.Array(p.Scopes), p.ClaimMappings, p.Enabled).Scan(&p.ID, &p.CreatedAt)
+	if err != nil {
+		if pgErr, ok := err.(*pq.Error); ok {
+			if pgErr.Code == "23505" && pgErr.Constraint == "oidc_providers_tenant_id_name_key" {
+				return nil, ErrProviderAlreadyExists
+			}
+		}
+		return nil, fmt.Errorf("FederationRepo.CreateProvider: %w", err)
+	}
+	return p, nil
+}
+
+// FindProviderById implements FederationRepository.
+func (r *federationRepository) FindProviderById(ctx context.Context, providerID string) (*OIDCProvider, error) {
+	query := `SELECT ` + providerColumns + ` FROM oidc_providers WHERE provider_id=$1`
+	p, err := scanProvider(r.db.QueryRowContext(ctx, query, providerID))
+	if err == sql.ErrNoRows {
+		return nil, ErrProviderNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("FederationRepo.FindProviderById: %w", err)
+	}
+	return p, nil
+}
+
+// ListProviders implements FederationRepository.
+func (r *federationRepository) ListProviders(ctx context.Context, tenantID string) ([]*OIDCProvider, error) {
+	query := `SELECT ` + providerColumns + ` FROM oidc_providers WHERE tenant_id=$1 ORDER BY name`
+	rows, err := r.db.QueryContext(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("FederationRepo.ListProviders: %w", err)
+	}
+	defer rows.Close()
+
+	providers := []*OIDCProvider{}
+	for rows.Next() {
+		p, err := scanProvider(rows)
+		if err != nil {
+			return nil, fmt.Errorf("FederationRepo.ListProviders: %w", err)
+		}
+		providers = append(providers, p)
+	}
+	return providers, rows.Err()
+}
+
+// DeleteProvider removes the provider together with its identity links
+func (r *federationRepository) DeleteProvider(ctx context.Context, providerID string) error {
+	query := `WITH links AS (DELETE FROM user_identities WHERE provider_id=$1 AND provider_type=$2)
+              DELETE FROM oidc_providers WHERE provider_id=$1`
+	res, err := r.db.ExecContext(ctx, query, providerID, identity.ProviderOIDC)
+	if err != nil {
+		return fmt.Errorf("FederationRepo.DeleteProvider: %w", err)
+	}
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return ErrProviderNotFound
+	}
+	return nil
+}
+
+// CreateState implements FederationRepository.
+func (r *federationRepository) CreateState(ctx context.Context, s *LoginState) error {
+	query := `INSERT INTO federated_login_states (state, provider_id, nonce, code_verifier, expires_at)
+              VALUES ($1, $2, $3, $4, $5)
+              RETURNING created_at`
+	err := r.db.QueryRowContext(ctx, query, s.State, s.ProviderID, s.Nonce, s.CodeVerifier,
+		s.ExpiresAt).Scan(&s.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("FederationRepo.CreateState: %w", err)
+	}
+	return nil
+}
+
+// ConsumeState deletes and returns an unexpired state so it cannot be replayed
+func (r *federationRepository) ConsumeState(ctx context.Context, state string) (*LoginState, error) {
+	query := `DELETE FROM federated_login_states WHERE state=$1
+              RETURNING state, provider_id, nonce, code_verifier, expires_at, created_at`
+	s := &LoginState{}
+	err := r.db.QueryRowContext(ctx, query, state).Scan(&s.State, &s.ProviderID, &s.Nonce,
+		&s.CodeVerifier, &s.ExpiresAt, &s.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrStateNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("FederationRepo.ConsumeState: %w", err)
+	}
+	if time.Now().After(s.ExpiresAt) {
+		return nil, ErrStateNotFound
+	}
+	return s, nil
+}
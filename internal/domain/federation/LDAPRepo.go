@@ -0,0 +1,117 @@
+package federation
+
+import (
+	"auth-haven/internal/db"
+	"auth-haven/internal/domain/identity"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrDirectoryNotFound      = errors.New("ldap directory not found")
+	ErrDirectoryAlreadyExists = errors.New("ldap directory with this name already exists for this tenant")
+)
+
+type LDAPRepository interface {
+	CreateDirectory(ctx context.Context, d *LDAPDirectory) (*LDAPDirectory, error)
+	FindDirectoryById(ctx context.Context, directoryID string) (*LDAPDirectory, error)
+	ListDirectories(ctx context.Context, tenantID string) ([]*LDAPDirectory, error)
+	DeleteDirectory(ctx context.Context, directoryID string) error
+}
+
+type ldapRepository struct {
+	db db.DBTX
+}
+
+func LDAPRepoImpl(db db.DBTX) LDAPRepository {
+	return &ldapRepository{db: db}
+}
+
+const ldapDirectoryColumns = `directory_id, tenant_id, name, url, start_tls, ca_cert, bind_dn, bind_password,
+                              user_base_dn, user_filter, attribute_mappings, group_mappings, enabled, created_at`
+
+func scanLDAPDirectory(row scanner) (*LDAPDirectory, error) {
+	d := &LDAPDirectory{}
+	err := row.Scan(&d.ID, &d.TenantID, &d.Name, &d.URL, &d.StartTLS, &d.CACert, &d.BindDN, &d.BindPassword,
+		&d.UserBaseDN, &d.UserFilter, &d.AttributeMappings, &d.GroupMappings, &d.Enabled, &d.CreatedAt)
+	return d, err
+}
+
+// CreateDirectory implements LDAPRepository.
+func (r *ldapRepository) CreateDirectory(ctx context.Context, d *LDAPDirectory) (*LDAPDirectory, error) {
+	if len(d.AttributeMappings) == 0 {
+		d.AttributeMappings = []byte(`{}`)
+	}
+	if len(d.GroupMappings) == 0 {
+		d.GroupMappings = []byte(`[]`)
+	}
+	query := `INSERT INTO ldap_directories (tenant_id, name, url, start_tls, ca_cert, bind_dn, bind_password,
+                                            user_base_dn, user_filter, attribute_mappings, group_mappings, enabled)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+              RETURNING directory_id, created_at`
+	err := r.db.QueryRowContext(ctx, query, d.TenantID, d.Name, d.URL, d.StartTLS, d.CACert, d.BindDN,
+		d.BindPassword, d.UserBaseDN, d.UserFilter, d.AttributeMappings, d.GroupMappings, d.Enabled).
+		Scan(&d.ID, &d.CreatedAt)
+	if err != nil {
+		if pgErr, ok := err.(*pq.Error); ok {
+			if pgErr.Code == "23505" && pgErr.Constraint == "ldap_directories_tenant_id_name_key" {
+				return nil, ErrDirectoryAlreadyExists
+			}
+		}
+		return nil, fmt.Errorf("LDAPRepo.CreateDirectory: %w", err)
+	}
+	return d, nil
+}
+
+// FindDirectoryById implements LDAPRepository.
+func (r *ldapRepository) FindDirectoryById(ctx context.Context, directoryID string) (*LDAPDirectory, error) {
+	query := `SELECT ` + ldapDirectoryColumns + ` FROM ldap_directories WHERE directory_id=$1`
+	d, err := scanLDAPDirectory(r.db.QueryRowContext(ctx, query, directoryID))
+	if err == sql.ErrNoRows {
+		return nil, ErrDirectoryNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("LDAPRepo.FindDirectoryById: %w", err)
+	}
+	return d, nil
+}
+
+// ListDirectories returns the tenant's directories in the order logins try them
+func (r *ldapRepository) ListDirectories(ctx context.Context, tenantID string) ([]*LDAPDirectory, error) {
+	query := `SELECT ` + ldapDirectoryColumns + ` FROM ldap_directories
+              WHERE tenant_id=$1 ORDER BY created_at, name`
+	rows, err := r.db.QueryContext(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("LDAPRepo.ListDirectories: %w", err)
+	}
+	defer rows.Close()
+
+	directories := []*LDAPDirectory{}
+	for rows.Next() {
+		d, err := scanLDAPDirectory(rows)
+		if err != nil {
+			return nil, fmt.Errorf("LDAPRepo.ListDirectories: %w", err)
+		}
+		directories = append(directories, d)
+	}
+	return directories, rows.Err()
+}
+
+// DeleteDirectory removes the directory together with its identity links
+func (r *ldapRepository) DeleteDirectory(ctx context.Context, directoryID string) error {
+	query := `WITH links AS (DELETE FROM user_identities WHERE provider_id=$1 AND provider_type=$2)
+              DELETE FROM ldap_directories WHERE directory_id=$1`
+	res, err := r.db.ExecContext(ctx, query, directoryID, identity.ProviderLDAP)
+	if err != nil {
+		return fmt.Errorf("LDAPRepo.DeleteDirectory: %w", err)
+	}
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return ErrDirectoryNotFound
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package federation
+
+import (
+	"auth-haven/internal/db"
+	"auth-haven/internal/domain/identity"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrRequestNotFound = errors.New("saml request not found or expired")
+)
+
+type SAMLRepository interface {
+	CreateProvider(ctx context.Context, p *SAMLProvider) (*SAMLProvider, error)
+	FindProviderById(ctx context.Context, providerID string) (*SAMLProvider, error)
+	ListProviders(ctx context.Context, tenantID string) ([]*SAMLProvider, error)
+	DeleteProvider(ctx context.Context, providerID string) error
+	CreateRequest(ctx context.Context, req *SAMLRequest) error
+	ConsumeRequest(ctx context.Context, providerID, requestID string) (*SAMLRequest, error)
+}
+
+type samlRepository struct {
+	db db.DBTX
+}
+
+func SAMLRepoImpl(db db.DBTX) SAMLRepository {
+	return &samlRepository{db: db}
+}
+
+const samlProviderColumns = `provider_id, tenant_id, name, entity_id, acs_url, idp_metadata, idp_entity_id,
+                             allow_idp_initiated, attribute_mappings, role_attribute, role_mappings,
+                             enabled, created_at`
+
+func scanSAMLProvider(row scanner) (*SAMLProvider, error) {
+	p := &SAMLProvider{}
+	err := row.Scan(&p.ID, &p.TenantID, &p.Name, &p.EntityID, &p.ACSURL, &p.IDPMetadata, &p.IDPEntityID,
+		&p.AllowIDPInitiated, &p.AttributeMappings, &p.RoleAttribute, &p.RoleMappings,
+		&p.Enabled, &p.CreatedAt)
+	return p, err
+}
+
+// CreateProvider implements SAMLRepository.
+func (r *samlRepository) CreateProvider(ctx context.Context, p *SAMLProvider) (*SAMLProvider, error) {
+	if len(p.AttributeMappings) == 0 {
+		p.AttributeMappings = []byte(`{}`)
+	}
+	if len(p.RoleMappings) == 0 {
+		p.RoleMappings = []byte(`[]`)
+	}
+	query := `INSERT INTO saml_providers (tenant_id, name, entity_id, acs_url, idp_metadata, idp_entity_id,
+                                          allow_idp_initiated, attribute_mappings, role_attribute,
+                                          role_mappings, enabled)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+              RETURNING provider_id, created_at`
+	err := r.db.QueryRowContext(ctx, query, p.TenantID, p.Name, p.EntityID, p.ACSURL, p.IDPMetadata,
+		p.IDPEntityID, p.AllowIDPInitiated, p.AttributeMappings, p.RoleAttribute, p.RoleMappings,
+		p.Enabled).Scan(&p.ID, &p.CreatedAt)
+	if err != nil {
+		if pgErr, ok := err.(*pq.Error); ok {
+			if pgErr.Code == "23505" && pgErr.Constraint == "saml_providers_tenant_id_name_key" {
+				return nil, ErrProviderAlreadyExists
+			}
+		}
+		return nil, fmt.Errorf("SAMLRepo.CreateProvider: %w", err)
+	}
+	return p, nil
+}
+
+// FindProviderById implements SAMLRepository.
+func (r *samlRepository) FindProviderById(ctx context.Context, providerID string) (*SAMLProvider, error) {
+	query := `SELECT ` + samlProviderColumns + ` FROM saml_providers WHERE provider_id=$1`
+	p, err := scanSAMLProvider(r.db.QueryRowContext(ctx, query, providerID))
+	if err == sql.ErrNoRows {
+		return nil, ErrProviderNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("SAMLRepo.FindProviderById: %w", err)
+	}
+	return p, nil
+}
+
+// ListProviders implements SAMLRepository.
+func (r *samlRepository) ListProviders(ctx context.Context, tenantID string) ([]*SAMLProvider, error) {
+	query := `SELECT ` + samlProviderColumns + ` FROM saml_providers WHERE tenant_id=$1 ORDER BY name`
+	rows, err := r.db.QueryContext(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("SAMLRepo.ListProviders: %w", err)
+	}
+	defer rows.Close()
+
+	providers := []*SAMLProvider{}
+	for rows.Next() {
+		p, err := scanSAMLProvider(rows)
+		if err != nil {
+			return nil, fmt.Errorf("SAMLRepo.ListProviders: %w", err)
+		}
+		providers = append(providers, p)
+	}
+	return providers, rows.Err()
+}
+
+// DeleteProvider removes the provider together with its identity links
+func (r *samlRepository) DeleteProvider(ctx context.Context, providerID string) error {
+	query := `WITH links AS (DELETE FROM user_identities WHERE provider_id=$1 AND provider_type=$2)
+              DELETE FROM saml_providers WHERE provider_id=$1`
+	res, err := r.db.ExecContext(ctx, query, providerID, identity.ProviderSAML)
+	if err != nil {
+		return fmt.Errorf("SAMLRepo.DeleteProvider: %w", err)
+	}
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return ErrProviderNotFound
+	}
+	return nil
+}
+
+// CreateRequest implements SAMLRepository.
+func (r *samlRepository) CreateRequest(ctx context.Context, req *SAMLRequest) error {
+	query := `INSERT INTO saml_requests (request_id, provider_id, expires_at)
+              VALUES ($1, $2, $3)
+              RETURNING created_at`
+	err := r.db.QueryRowContext(ctx, query, req.ID, req.ProviderID, req.ExpiresAt).Scan(&req.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("SAMLRepo.CreateRequest: %w", err)
+	}
+	return nil
+}
+
+// ConsumeRequest deletes and returns an unexpired request so a response
+// cannot be replayed against it
+func (r *samlRepository) ConsumeRequest(ctx context.Context, providerID, requestID string) (*SAMLRequest, error) {
+	query := `DELETE FROM saml_requests WHERE provider_id=$1 AND request_id=$2
+              RETURNING request_id, provider_id, expires_at, created_at`
+	req := &SAMLRequest{}
+	err := r.db.QueryRowContext(ctx, query, providerID, requestID).
+		Scan(&req.ID, &req.ProviderID, &req.ExpiresAt, &req.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrRequestNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("SAMLRepo.ConsumeRequest: %w", err)
+	}
+	if time.Now().After(req.ExpiresAt) {
+		return nil, ErrRequestNotFound
+	}
+	return req, nil
+}
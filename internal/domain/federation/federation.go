@@ -0,0 +1,28 @@
+package federation
+
+import "time"
+
+// OIDCProvider is an upstream OpenID Connect issuer a tenant signs in with
+type OIDCProvider struct {
+	ID            string    `db:"provider_id" json:"id"`
+	TenantID      string    `db:"tenant_id" json:"tenant_id"`
+	Name          string    `db:"name" json:"name"`
+	Issuer        string    `db:"issuer" json:"issuer"`
+	ClientID      string    `db:"client_id" json:"client_id"`
+	ClientSecret  string    `db:"client_secret" json:"-"`
+	RedirectURL   string    `db:"redirect_url" json:"redirect_url"`
+	Scopes        []string  `db:"scopes" json:"scopes"`
+	ClaimMappings []byte    `db:"claim_mappings" json:"claim_mappings"` // JSON object: local field -> upstream claim
+	Enabled       bool      `db:"enabled" json:"enabled"`
+	CreatedAt     time.Time `db:"created_at" json:"created_at"`
+}
+
+// LoginState ties an authorization redirect to its callback
+type LoginState struct {
+	State        string    `db:"state" json:"state"`
+	ProviderID   string    `db:"provider_id" json:"provider_id"`
+	Nonce        string    `db:"nonce" json:"-"`
+	CodeVerifier string    `db:"code_verifier" json:"-"`
+	ExpiresAt    time.Time `db:"expires_at" json:"expires_at"`
+	CreatedAt    time.Time `db:"created_at" json:"created_at"`
+}
@@ -0,0 +1,22 @@
+package federation
+
+import "time"
+
+// LDAPDirectory is a tenant's LDAP or Active Directory server. Password logins
+// for the tenant are verified by binding as the user found under UserBaseDN.
+type LDAPDirectory struct {
+	ID                string    `db:"directory_id" json:"id"`
+	TenantID          string    `db:"tenant_id" json:"tenant_id"`
+	Name              string    `db:"name" json:"name"`
+	URL               string    `db:"url" json:"url"`
+	StartTLS          bool      `db:"start_tls" json:"start_tls"`
+	CACert            *string   `db:"ca_cert" json:"ca_cert,omitempty"`
+	BindDN            *string   `db:"bind_dn" json:"bind_dn,omitempty"`
+	BindPassword      *string   `db:"bind_password" json:"-"`
+	UserBaseDN        string    `db:"user_base_dn" json:"user_base_dn"`
+	UserFilter        string    `db:"user_filter" json:"user_filter"`
+	AttributeMappings []byte    `db:"attribute_mappings" json:"attribute_mappings"` // JSON object: local field -> LDAP attribute
+	GroupMappings     []byte    `db:"group_mappings" json:"group_mappings"`         // JSON array of RoleMapping
+	Enabled           bool      `db:"enabled" json:"enabled"`
+	CreatedAt         time.Time `db:"created_at" json:"created_at"`
+}
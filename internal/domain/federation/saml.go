@@ -0,0 +1,34 @@
+package federation
+
+import "time"
+
+// SAMLProvider is a tenant's SAML 2.0 identity provider; we are the SP
+type SAMLProvider struct {
+	ID                string    `db:"provider_id" json:"id"`
+	TenantID          string    `db:"tenant_id" json:"tenant_id"`
+	Name              string    `db:"name" json:"name"`
+	EntityID          string    `db:"entity_id" json:"entity_id"`
+	ACSURL            string    `db:"acs_url" json:"acs_url"`
+	IDPMetadata       string    `db:"idp_metadata" json:"-"`
+	IDPEntityID       string    `db:"idp_entity_id" json:"idp_entity_id"`
+	AllowIDPInitiated bool      `db:"allow_idp_initiated" json:"allow_idp_initiated"`
+	AttributeMappings []byte    `db:"attribute_mappings" json:"attribute_mappings"` // JSON object: local field -> SAML attribute
+	RoleAttribute     *string   `db:"role_attribute" json:"role_attribute,omitempty"`
+	RoleMappings      []byte    `db:"role_mappings" json:"role_mappings"` // JSON array of RoleMapping
+	Enabled           bool      `db:"enabled" json:"enabled"`
+	CreatedAt         time.Time `db:"created_at" json:"created_at"`
+}
+
+// RoleMapping grants Role when the role attribute carries Value
+type RoleMapping struct {
+	Value string `json:"value"`
+	Role  string `json:"role"`
+}
+
+// SAMLRequest is an AuthnRequest we sent and expect a response to
+type SAMLRequest struct {
+	ID         string    `db:"request_id" json:"id"`
+	ProviderID string    `db:"provider_id" json:"provider_id"`
+	ExpiresAt  time.Time `db:"expires_at" json:"expires_at"`
+	CreatedAt  time.Time `db:"created_at" json:"created_at"`
+}
@@ -0,0 +1,356 @@
+package group
+
+import (
+	"auth-haven/internal/db"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrGroupAlreadyExists = errors.New("group with this name already exists for this tenant")
+	ErrGroupNotFound      = errors.New("group not found")
+	ErrMemberNotFound     = errors.New("not a member of this group")
+	ErrRoleNotAssigned    = errors.New("role is not assigned to this group")
+	ErrCycle              = errors.New("a group cannot contain itself")
+)
+
+type GroupRepository interface {
+	Create(ctx context.Context, g *Group) (*Group, error)
+	FindById(ctx context.Context, groupID string) (*Group, error)
+	ListByTenant(ctx context.Context, tenantID string, f ListFilter) ([]*Group, int, error)
+	Update(ctx context.Context, groupID string, g *UpdateGroup) error
+	Delete(ctx context.Context, groupID string) error
+	AddUser(ctx context.Context, groupID, userID string) error
+	RemoveUser(ctx context.Context, groupID, userID string) error
+	AddSubgroup(ctx context.Context, groupID, memberGroupID string) error
+	RemoveSubgroup(ctx context.Context, groupID, memberGroupID string) error
+	ListMembers(ctx context.Context, groupID string) (userIDs, groupIDs []string, err error)
+	ListUserGroups(ctx context.Context, userID string) ([]*Group, error)
+	ListEdges(ctx context.Context, tenantID string) ([]Edge, error)
+	AddRole(ctx context.Context, groupID string, roleID int64) error
+	RemoveRole(ctx context.Context, groupID string, roleID int64) error
+	ListRoles(ctx context.Context, groupID string) ([]int64, error)
+}
+
+type groupRepository struct {
+	db db.DBTX
+}
+
+func GroupRepoImpl(db db.DBTX) GroupRepository {
+	return &groupRepository{db: db}
+}
+
+const groupColumns = `group_id, tenant_id, name, description, created_at, updated_at`
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanGroup(row scanner) (*Group, error) {
+	g := &Group{}
+	err := row.Scan(&g.ID, &g.TenantID, &g.Name, &g.Description, &g.CreatedAt, &g.UpdatedAt)
+	return g, err
+}
+
+func isNameConflict(err error) bool {
+	pgErr, ok := err.(*pq.Error)
+	return ok && pgErr.Code == "23505" && pgErr.Constraint == "groups_tenant_id_name_key"
+}
+
+// Create implements GroupRepository.
+func (r *groupRepository) Create(ctx context.Context, g *Group) (*Group, error) {
+	query := `INSERT INTO groups (tenant_id, name, description)
+              VALUES ($1, $2, $3)
+              RETURNING group_id, created_at, updated_at`
+	err := r.db.QueryRowContext(ctx, query, g.TenantID, g.Name, g.Description).
+		Scan(&g.ID, &g.CreatedAt, &g.UpdatedAt)
+	if err != nil {
+		if isNameConflict(err) {
+			return nil, ErrGroupAlreadyExists
+		}
+		return nil, fmt.Errorf("GroupRepo.Create: %w", err)
+	}
+	return g, nil
+}
+
+// FindById implements GroupRepository.
+func (r *groupRepository) FindById(ctx context.Context, groupID string) (*Group, error) {
+	query := `SELECT ` + groupColumns + ` FROM groups WHERE group_id=$1`
+	g, err := scanGroup(r.db.QueryRowContext(ctx, query, groupID))
+	if err == sql.ErrNoRows {
+		return nil, ErrGroupNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("GroupRepo.FindById: %w", err)
+	}
+	return g, nil
+}
+
+// ListByTenant returns a page of the tenant's groups matching f, and the
+// total number of matches
+func (r *groupRepository) ListByTenant(ctx context.Context, tenantID string, f ListFilter) ([]*Group, int, error) {
+	conds := []string{"tenant_id=$1"}
+	args := []interface{}{tenantID}
+
+	if f.Name != "" {
+		args = append(args, f.Name)
+		conds = append(conds, fmt.Sprintf("name=$%d", len(args)))
+	}
+	where := strings.Join(conds, " AND ")
+
+	var total int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM groups WHERE `+where, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("GroupRepo.ListByTenant: %w", err)
+	}
+
+	limit := f.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+	query := fmt.Sprintf(`SELECT %s FROM groups WHERE %s ORDER BY name LIMIT %d OFFSET %d`,
+		groupColumns, where, limit, max(f.Offset, 0))
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("GroupRepo.ListByTenant: %w", err)
+	}
+	defer rows.Close()
+
+	groups := []*Group{}
+	for rows.Next() {
+		g, err := scanGroup(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("GroupRepo.ListByTenant: %w", err)
+		}
+		groups = append(groups, g)
+	}
+	return groups, total, rows.Err()
+}
+
+// Update implements GroupRepository.
+func (r *groupRepository) Update(ctx context.Context, groupID string, g *UpdateGroup) error {
+	fields := []string{}
+	args := []interface{}{}
+	argPos := 1
+
+	if g.Name != nil {
+		fields = append(fields, fmt.Sprintf("name=$%d", argPos))
+		args = append(args, *g.Name)
+		argPos++
+	}
+	if g.Description != nil {
+		fields = append(fields, fmt.Sprintf("description=$%d", argPos))
+		args = append(args, *g.Description)
+		argPos++
+	}
+
+	if len(fields) == 0 {
+		return errors.New("nothing to update")
+	}
+
+	query := fmt.Sprintf(`UPDATE groups SET %s, updated_at=NOW() WHERE group_id=$%d`,
+		strings.Join(fields, ", "), argPos)
+	args = append(args, groupID)
+
+	res, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		if isNameConflict(err) {
+			return ErrGroupAlreadyExists
+		}
+		return fmt.Errorf("GroupRepo.Update: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrGroupNotFound
+	}
+	return nil
+}
+
+// Delete implements GroupRepository.
+func (r *groupRepository) Delete(ctx context.Context, groupID string) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM groups WHERE group_id=$1`, groupID)
+	if err != nil {
+		return fmt.Errorf("GroupRepo.Delete: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrGroupNotFound
+	}
+	return nil
+}
+
+// AddUser adds a user to a group; adding an existing member is a no-op
+func (r *groupRepository) AddUser(ctx context.Context, groupID, userID string) error {
+	query := `INSERT INTO group_users (group_id, user_id) VALUES ($1, $2)
+              ON CONFLICT DO NOTHING`
+	if _, err := r.db.ExecContext(ctx, query, groupID, userID); err != nil {
+		return fmt.Errorf("GroupRepo.AddUser: %w", err)
+	}
+	return nil
+}
+
+// RemoveUser implements GroupRepository.
+func (r *groupRepository) RemoveUser(ctx context.Context, groupID, userID string) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM group_users WHERE group_id=$1 AND user_id=$2`, groupID, userID)
+	if err != nil {
+		return fmt.Errorf("GroupRepo.RemoveUser: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrMemberNotFound
+	}
+	return nil
+}
+
+// AddSubgroup nests memberGroupID inside groupID. The check that groupID is
+// not already below memberGroupID runs in the same statement.
+func (r *groupRepository) AddSubgroup(ctx context.Context, groupID, memberGroupID string) error {
+	query := `WITH RECURSIVE descendants AS (
+                  SELECT $2::uuid AS group_id
+                  UNION
+                  SELECT gs.member_group_id
+                  FROM group_subgroups gs JOIN descendants d ON gs.group_id=d.group_id
+              ), cycle AS (
+                  SELECT EXISTS (SELECT 1 FROM descendants WHERE group_id=$1::uuid) AS found
+              ), inserted AS (
+                  INSERT INTO group_subgroups (group_id, member_group_id)
+                  SELECT $1, $2 WHERE NOT (SELECT found FROM cycle)
+                  ON CONFLICT DO NOTHING
+              )
+              SELECT found FROM cycle`
+	var cycle bool
+	if err := r.db.QueryRowContext(ctx, query, groupID, memberGroupID).Scan(&cycle); err != nil {
+		return fmt.Errorf("GroupRepo.AddSubgroup: %w", err)
+	}
+	if cycle {
+		return ErrCycle
+	}
+	return nil
+}
+
+// RemoveSubgroup implements GroupRepository.
+func (r *groupRepository) RemoveSubgroup(ctx context.Context, groupID, memberGroupID string) error {
+	query := `DELETE FROM group_subgroups WHERE group_id=$1 AND member_group_id=$2`
+	res, err := r.db.ExecContext(ctx, query, groupID, memberGroupID)
+	if err != nil {
+		return fmt.Errorf("GroupRepo.RemoveSubgroup: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrMemberNotFound
+	}
+	return nil
+}
+
+// ListMembers returns the group's direct members
+func (r *groupRepository) ListMembers(ctx context.Context, groupID string) ([]string, []string, error) {
+	query := `SELECT user_id, '' FROM group_users WHERE group_id=$1
+              UNION ALL
+              SELECT '', member_group_id::text FROM group_subgroups WHERE group_id=$1
+              ORDER BY 1, 2`
+	rows, err := r.db.QueryContext(ctx, query, groupID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("GroupRepo.ListMembers: %w", err)
+	}
+	defer rows.Close()
+
+	userIDs, groupIDs := []string{}, []string{}
+	for rows.Next() {
+		var userID, memberGroupID string
+		if err := rows.Scan(&userID, &memberGroupID); err != nil {
+			return nil, nil, fmt.Errorf("GroupRepo.ListMembers: %w", err)
+		}
+		if userID != "" {
+			userIDs = append(userIDs, userID)
+		} else {
+			groupIDs = append(groupIDs, memberGroupID)
+		}
+	}
+	return userIDs, groupIDs, rows.Err()
+}
+
+// ListUserGroups returns the groups the user belongs to directly
+func (r *groupRepository) ListUserGroups(ctx context.Context, userID string) ([]*Group, error) {
+	query := `SELECT ` + groupColumns + ` FROM groups
+              WHERE group_id IN (SELECT group_id FROM group_users WHERE user_id=$1)
+              ORDER BY name`
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("GroupRepo.ListUserGroups: %w", err)
+	}
+	defer rows.Close()
+
+	groups := []*Group{}
+	for rows.Next() {
+		g, err := scanGroup(rows)
+		if err != nil {
+			return nil, fmt.Errorf("GroupRepo.ListUserGroups: %w", err)
+		}
+		groups = append(groups, g)
+	}
+	return groups, rows.Err()
+}
+
+// ListEdges returns every nesting of one of the tenant's groups in another
+func (r *groupRepository) ListEdges(ctx context.Context, tenantID string) ([]Edge, error) {
+	query := `SELECT gs.group_id, gs.member_group_id
+              FROM group_subgroups gs JOIN groups g ON g.group_id=gs.group_id
+              WHERE g.tenant_id=$1`
+	rows, err := r.db.QueryContext(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("GroupRepo.ListEdges: %w", err)
+	}
+	defer rows.Close()
+
+	edges := []Edge{}
+	for rows.Next() {
+		var e Edge
+		if err := rows.Scan(&e.GroupID, &e.MemberGroupID); err != nil {
+			return nil, fmt.Errorf("GroupRepo.ListEdges: %w", err)
+		}
+		edges = append(edges, e)
+	}
+	return edges, rows.Err()
+}
+
+// AddRole assigns a role to a group; assigning it again is a no-op
+func (r *groupRepository) AddRole(ctx context.Context, groupID string, roleID int64) error {
+	query := `INSERT INTO group_roles (group_id, role_id) VALUES ($1, $2)
+              ON CONFLICT DO NOTHING`
+	if _, err := r.db.ExecContext(ctx, query, groupID, roleID); err != nil {
+		return fmt.Errorf("GroupRepo.AddRole: %w", err)
+	}
+	return nil
+}
+
+// RemoveRole implements GroupRepository.
+func (r *groupRepository) RemoveRole(ctx context.Context, groupID string, roleID int64) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM group_roles WHERE group_id=$1 AND role_id=$2`, groupID, roleID)
+	if err != nil {
+		return fmt.Errorf("GroupRepo.RemoveRole: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrRoleNotAssigned
+	}
+	return nil
+}
+
+// ListRoles returns the IDs of the roles assigned to the group
+func (r *groupRepository) ListRoles(ctx context.Context, groupID string) ([]int64, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT role_id FROM group_roles WHERE group_id=$1 ORDER BY role_id`, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("GroupRepo.ListRoles: %w", err)
+	}
+	defer rows.Close()
+
+	roleIDs := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("GroupRepo.ListRoles: %w", err)
+		}
+		roleIDs = append(roleIDs, id)
+	}
+	return roleIDs, rows.Err()
+}
@@ -0,0 +1,136 @@
+package group
+
+import (
+	"auth-haven/internal/domain/role"
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Expander resolves the groups a user belongs to, directly or through nested
+// groups. Each tenant's nesting graph is cached until Invalidate is called for
+// the tenant or ttl passes, which bounds staleness when another instance
+// changes it.
+type Expander struct {
+	repo GroupRepository
+	ttl  time.Duration
+
+	mu     sync.Mutex
+	graphs map[string]*graph
+}
+
+// graph maps each group to the groups that directly contain it
+type graph struct {
+	parents  map[string][]string
+	loadedAt time.Time
+}
+
+func NewExpander(repo GroupRepository, ttl time.Duration) *Expander {
+	return &Expander{repo: repo, ttl: ttl, graphs: map[string]*graph{}}
+}
+
+// UserGroups returns the IDs of every group the user is a member of,
+// including groups that contain those groups
+func (e *Expander) UserGroups(ctx context.Context, userID string) ([]string, error) {
+	direct, err := e.repo.ListUserGroups(ctx, userID)
+	if err != nil || len(direct) == 0 {
+		return nil, err
+	}
+	ids := make([]string, 0, len(direct))
+	for _, d := range direct {
+		ids = append(ids, d.ID)
+	}
+	return e.Expand(ctx, direct[0].TenantID, ids)
+}
+
+// Expand returns groupIDs together with every group that contains one of
+// them, directly or through nesting
+func (e *Expander) Expand(ctx context.Context, tenantID string, groupIDs []string) ([]string, error) {
+	g, err := e.graph(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	queue := slices.Clone(groupIDs)
+	groups := []string{}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		groups = append(groups, id)
+		queue = append(queue, g.parents[id]...)
+	}
+	return groups, nil
+}
+
+// Invalidate drops the cached graph of a tenant after its groups changed
+func (e *Expander) Invalidate(tenantID string) {
+	e.mu.Lock()
+	delete(e.graphs, tenantID)
+	e.mu.Unlock()
+}
+
+func (e *Expander) graph(ctx context.Context, tenantID string) (*graph, error) {
+	e.mu.Lock()
+	g, ok := e.graphs[tenantID]
+	e.mu.Unlock()
+	if ok && time.Since(g.loadedAt) < e.ttl {
+		return g, nil
+	}
+
+	edges, err := e.repo.ListEdges(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	g = &graph{parents: map[string][]string{}, loadedAt: time.Now()}
+	for _, edge := range edges {
+		g.parents[edge.MemberGroupID] = append(g.parents[edge.MemberGroupID], edge.GroupID)
+	}
+	e.mu.Lock()
+	e.graphs[tenantID] = g
+	e.mu.Unlock()
+	return g, nil
+}
+
+// WithGroupRoles wraps a role repository so that ListByUser also reports the
+// roles a user holds through group membership
+func WithGroupRoles(roles role.RoleRepository, groups *Expander) role.RoleRepository {
+	return &groupRoles{RoleRepository: roles, groups: groups}
+}
+
+type groupRoles struct {
+	role.RoleRepository
+	groups *Expander
+}
+
+// ListByUser implements role.RoleRepository.
+func (r *groupRoles) ListByUser(ctx context.Context, userID string) ([]*role.Role, error) {
+	held, err := r.RoleRepository.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	groupIDs, err := r.groups.UserGroups(ctx, userID)
+	if err != nil || len(groupIDs) == 0 {
+		return held, err
+	}
+	inherited, err := r.RoleRepository.ListByGroups(ctx, groupIDs)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[int64]bool, len(held))
+	for _, h := range held {
+		seen[h.ID] = true
+	}
+	for _, h := range inherited {
+		if !seen[h.ID] {
+			seen[h.ID] = true
+			held = append(held, h)
+		}
+	}
+	return held, nil
+}
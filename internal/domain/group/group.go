@@ -0,0 +1,32 @@
+package group
+
+import "time"
+
+// Group is a tenant-scoped set of users and other groups. Roles assigned to a
+// group apply to its members, including the members of nested groups.
+type Group struct {
+	ID          string    `db:"group_id" json:"id"`
+	TenantID    string    `db:"tenant_id" json:"tenant_id"`
+	Name        string    `db:"name" json:"name"`
+	Description string    `db:"description" json:"description"`
+	CreatedAt   time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at" json:"updated_at"`
+}
+
+type UpdateGroup struct {
+	Name        *string
+	Description *string
+}
+
+// Edge records that GroupID contains MemberGroupID
+type Edge struct {
+	GroupID       string
+	MemberGroupID string
+}
+
+// ListFilter narrows ListByTenant; zero values match everything
+type ListFilter struct {
+	Name   string
+	Offset int
+	Limit  int
+}
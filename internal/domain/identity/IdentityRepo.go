@@ -0,0 +1,99 @@
+package identity
+
+import (
+	"auth-haven/internal/db"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrIdentityAlreadyLinked = errors.New("external identity is already linked to a user")
+	ErrIdentityNotFound      = errors.New("identity not found")
+)
+
+type IdentityRepository interface {
+	Create(ctx context.Context, i *Identity) (*Identity, error)
+	FindBySubject(ctx context.Context, providerID, subject string) (*Identity, error)
+	ListByUser(ctx context.Context, userID string) ([]*Identity, error)
+	TouchLogin(ctx context.Context, identityID string) error
+}
+
+type identityRepository struct {
+	db db.DBTX
+}
+
+func IdentityRepoImpl(db db.DBTX) IdentityRepository {
+	return &identityRepository{db: db}
+}
+
+// Create implements IdentityRepository.
+func (r *identityRepository) Create(ctx context.Context, i *Identity) (*Identity, error) {
+	query := `INSERT INTO user_identities (user_id, provider_id, provider_type, subject, email, last_login_at)
+              VALUES ($1, $2, $3, $4, $5, NOW())
+              RETURNING identity_id, created_at, last_login_at`
+	err := r.db.QueryRowContext(ctx, query, i.UserID, i.ProviderID, i.ProviderType, i.Subject, i.Email).
+		Scan(&i.ID, &i.CreatedAt, &i.LastLoginAt)
+	if err != nil {
+		if pgErr, ok := err.(*pq.Error); ok {
+			if pgErr.Code == "23505" && pgErr.Constraint == "user_identities_provider_id_subject_key" {
+				return nil, ErrIdentityAlreadyLinked
+			}
+		}
+		return nil, fmt.Errorf("IdentityRepo.Create: %w", err)
+	}
+	return i, nil
+}
+
+// FindBySubject implements IdentityRepository.
+func (r *identityRepository) FindBySubject(ctx context.Context, providerID, subject string) (*Identity, error) {
+	query := `SELECT identity_id, user_id, provider_id, provider_type, subject, email, created_at, last_login_at
+              FROM user_identities WHERE provider_id=$1 AND subject=$2`
+	row := r.db.QueryRowContext(ctx, query, providerID, subject)
+
+	i := &Identity{}
+	err := row.Scan(&i.ID, &i.UserID, &i.ProviderID, &i.ProviderType, &i.Subject, &i.Email,
+		&i.CreatedAt, &i.LastLoginAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrIdentityNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("IdentityRepo.FindBySubject: %w", err)
+	}
+	return i, nil
+}
+
+// ListByUser implements IdentityRepository.
+func (r *identityRepository) ListByUser(ctx context.Context, userID string) ([]*Identity, error) {
+	query := `SELECT identity_id, user_id, provider_id, provider_type, subject, email, created_at, last_login_at
+              FROM user_identities WHERE user_id=$1 ORDER BY created_at`
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("IdentityRepo.ListByUser: %w", err)
+	}
+	defer rows.Close()
+
+	identities := []*Identity{}
+	for rows.Next() {
+		i := &Identity{}
+		if err := rows.Scan(&i.ID, &i.UserID, &i.ProviderID, &i.ProviderType, &i.Subject, &i.Email,
+			&i.CreatedAt, &i.LastLoginAt); err != nil {
+			return nil, fmt.Errorf("IdentityRepo.ListByUser: %w", err)
+		}
+		identities = append(identities, i)
+	}
+	return identities, rows.Err()
+}
+
+// TouchLogin implements IdentityRepository.
+func (r *identityRepository) TouchLogin(ctx context.Context, identityID string) error {
+	query := `UPDATE user_identities SET last_login_at=NOW() WHERE identity_id=$1`
+	_, err := r.db.ExecContext(ctx, query, identityID)
+	if err != nil {
+		return fmt.Errorf("IdentityRepo.TouchLogin: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package identity
+
+import "time"
+
+const (
+	ProviderOIDC = "oidc"
+	ProviderSAML = "saml"
+	ProviderLDAP = "ldap"
+)
+
+// Identity links a local user to an account at an external identity provider
+type Identity struct {
+	ID           string     `db:"identity_id" json:"id"`
+	UserID       string     `db:"user_id" json:"user_id"`
+	ProviderID   string     `db:"provider_id" json:"provider_id"`
+	ProviderType string     `db:"provider_type" json:"provider_type"`
+	Subject      string     `db:"subject" json:"subject"`
+	Email        *string    `db:"email" json:"email,omitempty"`
+	CreatedAt    time.Time  `db:"created_at" json:"created_at"`
+	LastLoginAt  *time.Time `db:"last_login_at" json:"last_login_at,omitempty"`
+}
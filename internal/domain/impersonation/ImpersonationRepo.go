@@ -0,0 +1,73 @@
+package impersonation
+
+import (
+	"auth-haven/internal/db"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrSessionNotFound = errors.New("impersonation session not found")
+)
+
+type ImpersonationRepository interface {
+	Create(ctx context.Context, s *Session) (*Session, error)
+	FindById(ctx context.Context, sessionID string) (*Session, error)
+	End(ctx context.Context, sessionID string) error
+}
+
+type impersonationRepository struct {
+	db db.DBTX
+}
+
+func ImpersonationRepoImpl(db db.DBTX) ImpersonationRepository {
+	return &impersonationRepository{db: db}
+}
+
+// Create implements ImpersonationRepository.
+func (r *impersonationRepository) Create(ctx context.Context, s *Session) (*Session, error) {
+	query := `INSERT INTO impersonation_sessions (operator_id, target_user_id, tenant_id, reason, expires_at)
+              VALUES ($1, $2, $3, $4, $5)
+              RETURNING session_id, created_at`
+	err := r.db.QueryRowContext(ctx, query, s.OperatorID, s.TargetUserID, s.TenantID, s.Reason, s.ExpiresAt).
+		Scan(&s.ID, &s.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("ImpersonationRepo.Create: %w", err)
+	}
+	return s, nil
+}
+
+// FindById implements ImpersonationRepository.
+func (r *impersonationRepository) FindById(ctx context.Context, sessionID string) (*Session, error) {
+	query := `SELECT session_id, operator_id, target_user_id, tenant_id, reason, expires_at, ended_at, created_at
+              FROM impersonation_sessions WHERE session_id=$1`
+	row := r.db.QueryRowContext(ctx, query, sessionID)
+
+	s := &Session{}
+	err := row.Scan(&s.ID, &s.OperatorID, &s.TargetUserID, &s.TenantID, &s.Reason,
+		&s.ExpiresAt, &s.EndedAt, &s.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrSessionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ImpersonationRepo.FindById: %w", err)
+	}
+	return s, nil
+}
+
+// End implements ImpersonationRepository.
+func (r *impersonationRepository) End(ctx context.Context, sessionID string) error {
+	query := `UPDATE impersonation_sessions SET ended_at=NOW()
+              WHERE session_id=$1 AND ended_at IS NULL`
+	res, err := r.db.ExecContext(ctx, query, sessionID)
+	if err != nil {
+		return fmt.Errorf("ImpersonationRepo.End: %w", err)
+	}
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return ErrSessionNotFound
+	}
+	return nil
+}
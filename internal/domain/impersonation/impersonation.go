@@ -0,0 +1,20 @@
+package impersonation
+
+import "time"
+
+// Session records an operator acting as another user
+type Session struct {
+	ID           string     `db:"session_id" json:"id"`
+	OperatorID   string     `db:"operator_id" json:"operator_id"`
+	TargetUserID string     `db:"target_user_id" json:"target_user_id"`
+	TenantID     *string    `db:"tenant_id" json:"tenant_id,omitempty"`
+	Reason       string     `db:"reason" json:"reason"`
+	ExpiresAt    time.Time  `db:"expires_at" json:"expires_at"`
+	EndedAt      *time.Time `db:"ended_at" json:"ended_at,omitempty"`
+	CreatedAt    time.Time  `db:"created_at" json:"created_at"`
+}
+
+// Active reports whether tokens issued for the session are still usable
+func (s *Session) Active() bool {
+	return s.EndedAt == nil && time.Now().Before(s.ExpiresAt)
+}
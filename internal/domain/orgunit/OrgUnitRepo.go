@@ -0,0 +1,237 @@
+package orgunit
+
+import (
+	"auth-haven/internal/db"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrOrgUnitAlreadyExists = errors.New("org unit with this name already exists for this tenant")
+	ErrOrgUnitNotFound      = errors.New("org unit not found")
+	ErrCycle                = errors.New("an org unit cannot be moved below itself")
+)
+
+type OrgUnitRepository interface {
+	Create(ctx context.Context, u *OrgUnit) (*OrgUnit, error)
+	FindById(ctx context.Context, unitID string) (*OrgUnit, error)
+	ListByTenant(ctx context.Context, tenantID string) ([]*OrgUnit, error)
+	Rename(ctx context.Context, unitID, name string) error
+	Move(ctx context.Context, unitID, parentID string) error
+	Delete(ctx context.Context, unitID string) error
+	ListRoleAssignments(ctx context.Context, tenantID string) (map[string][]int64, error)
+	AddRole(ctx context.Context, unitID string, roleID int64) error
+	RemoveRole(ctx context.Context, unitID string, roleID int64) error
+	CountMembers(ctx context.Context, tenantID string) (map[string]int, error)
+}
+
+type orgUnitRepository struct {
+	db db.DBTX
+}
+
+func OrgUnitRepoImpl(db db.DBTX) OrgUnitRepository {
+	return &orgUnitRepository{db: db}
+}
+
+const orgUnitColumns = `org_unit_id, tenant_id, name, COALESCE(parent_org_unit_id::text, ''), created_at`
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanOrgUnit(row scanner) (*OrgUnit, error) {
+	u := &OrgUnit{}
+	err := row.Scan(&u.ID, &u.TenantID, &u.Name, &u.ParentID, &u.CreatedAt)
+	return u, err
+}
+
+func mapConflict(err error) error {
+	if pgErr, ok := err.(*pq.Error); ok && pgErr.Code == "23505" && pgErr.Constraint == "org_units_tenant_id_name_key" {
+		return ErrOrgUnitAlreadyExists
+	}
+	return nil
+}
+
+// Create implements OrgUnitRepository.
+func (r *orgUnitRepository) Create(ctx context.Context, u *OrgUnit) (*OrgUnit, error) {
+	query := `INSERT INTO org_units (tenant_id, name, parent_org_unit_id)
+              VALUES ($1, $2, NULLIF($3, '')::uuid)
+              RETURNING org_unit_id, created_at`
+	err := r.db.QueryRowContext(ctx, query, u.TenantID, u.Name, u.ParentID).Scan(&u.ID, &u.CreatedAt)
+	if err != nil {
+		if conflict := mapConflict(err); conflict != nil {
+			return nil, conflict
+		}
+		return nil, fmt.Errorf("OrgUnitRepo.Create: %w", err)
+	}
+	return u, nil
+}
+
+// FindById implements OrgUnitRepository.
+func (r *orgUnitRepository) FindById(ctx context.Context, unitID string) (*OrgUnit, error) {
+	query := `SELECT ` + orgUnitColumns + ` FROM org_units WHERE org_unit_id=$1`
+	u, err := scanOrgUnit(r.db.QueryRowContext(ctx, query, unitID))
+	if err == sql.ErrNoRows {
+		return nil, ErrOrgUnitNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("OrgUnitRepo.FindById: %w", err)
+	}
+	return u, nil
+}
+
+// ListByTenant returns all of the tenant's org units
+func (r *orgUnitRepository) ListByTenant(ctx context.Context, tenantID string) ([]*OrgUnit, error) {
+	query := `SELECT ` + orgUnitColumns + ` FROM org_units WHERE tenant_id=$1 ORDER BY name`
+	rows, err := r.db.QueryContext(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("OrgUnitRepo.ListByTenant: %w", err)
+	}
+	defer rows.Close()
+
+	units := []*OrgUnit{}
+	for rows.Next() {
+		u, err := scanOrgUnit(rows)
+		if err != nil {
+			return nil, fmt.Errorf("OrgUnitRepo.ListByTenant: %w", err)
+		}
+		units = append(units, u)
+	}
+	return units, rows.Err()
+}
+
+// Rename implements OrgUnitRepository.
+func (r *orgUnitRepository) Rename(ctx context.Context, unitID, name string) error {
+	res, err := r.db.ExecContext(ctx, `UPDATE org_units SET name=$1 WHERE org_unit_id=$2`, name, unitID)
+	if err != nil {
+		if conflict := mapConflict(err); conflict != nil {
+			return conflict
+		}
+		return fmt.Errorf("OrgUnitRepo.Rename: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrOrgUnitNotFound
+	}
+	return nil
+}
+
+// Move places a unit below parentID, or at the root when parentID is empty.
+// The new parent's ancestry is checked in the same statement, so a unit can
+// never end up below itself.
+func (r *orgUnitRepository) Move(ctx context.Context, unitID, parentID string) error {
+	query := `WITH RECURSIVE ancestors AS (
+                  SELECT org_unit_id, parent_org_unit_id FROM org_units
+                  WHERE org_unit_id=NULLIF($2, '')::uuid
+                  UNION
+                  SELECT o.org_unit_id, o.parent_org_unit_id
+                  FROM org_units o JOIN ancestors a ON o.org_unit_id=a.parent_org_unit_id
+              )
+              UPDATE org_units SET parent_org_unit_id=NULLIF($2, '')::uuid
+              WHERE org_unit_id=$1
+                AND NOT EXISTS (SELECT 1 FROM ancestors WHERE org_unit_id=$1)`
+	res, err := r.db.ExecContext(ctx, query, unitID, parentID)
+	if err != nil {
+		return fmt.Errorf("OrgUnitRepo.Move: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		if _, err := r.FindById(ctx, unitID); err != nil {
+			return err
+		}
+		return ErrCycle
+	}
+	return nil
+}
+
+// Delete removes a unit, handing its sub-units and members to its parent
+func (r *orgUnitRepository) Delete(ctx context.Context, unitID string) error {
+	query := `WITH target AS (
+                  SELECT parent_org_unit_id FROM org_units WHERE org_unit_id=$1
+              ), children AS (
+                  UPDATE org_units SET parent_org_unit_id=(SELECT parent_org_unit_id FROM target)
+                  WHERE parent_org_unit_id=$1
+              ), members AS (
+                  UPDATE users SET org_unit_id=(SELECT parent_org_unit_id FROM target), updated_at=NOW()
+                  WHERE org_unit_id=$1
+              )
+              DELETE FROM org_units WHERE org_unit_id=$1`
+	res, err := r.db.ExecContext(ctx, query, unitID)
+	if err != nil {
+		return fmt.Errorf("OrgUnitRepo.Delete: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrOrgUnitNotFound
+	}
+	return nil
+}
+
+// ListRoleAssignments returns the roles assigned at each of the tenant's
+// units, keyed by unit ID
+func (r *orgUnitRepository) ListRoleAssignments(ctx context.Context, tenantID string) (map[string][]int64, error) {
+	query := `SELECT our.org_unit_id, our.role_id
+              FROM org_unit_roles our JOIN org_units o ON o.org_unit_id=our.org_unit_id
+              WHERE o.tenant_id=$1
+              ORDER BY our.org_unit_id, our.role_id`
+	rows, err := r.db.QueryContext(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("OrgUnitRepo.ListRoleAssignments: %w", err)
+	}
+	defer rows.Close()
+
+	assignments := map[string][]int64{}
+	for rows.Next() {
+		var unitID string
+		var roleID int64
+		if err := rows.Scan(&unitID, &roleID); err != nil {
+			return nil, fmt.Errorf("OrgUnitRepo.ListRoleAssignments: %w", err)
+		}
+		assignments[unitID] = append(assignments[unitID], roleID)
+	}
+	return assignments, rows.Err()
+}
+
+// AddRole assigns a role at a unit; assigning it again is a no-op
+func (r *orgUnitRepository) AddRole(ctx context.Context, unitID string, roleID int64) error {
+	query := `INSERT INTO org_unit_roles (org_unit_id, role_id) VALUES ($1, $2)
+              ON CONFLICT DO NOTHING`
+	if _, err := r.db.ExecContext(ctx, query, unitID, roleID); err != nil {
+		return fmt.Errorf("OrgUnitRepo.AddRole: %w", err)
+	}
+	return nil
+}
+
+// RemoveRole implements OrgUnitRepository.
+func (r *orgUnitRepository) RemoveRole(ctx context.Context, unitID string, roleID int64) error {
+	query := `DELETE FROM org_unit_roles WHERE org_unit_id=$1 AND role_id=$2`
+	if _, err := r.db.ExecContext(ctx, query, unitID, roleID); err != nil {
+		return fmt.Errorf("OrgUnitRepo.RemoveRole: %w", err)
+	}
+	return nil
+}
+
+// CountMembers returns how many users belong directly to each of the
+// tenant's units
+func (r *orgUnitRepository) CountMembers(ctx context.Context, tenantID string) (map[string]int, error) {
+	query := `SELECT org_unit_id, COUNT(*) FROM users
+              WHERE tenant_id=$1 AND org_unit_id IS NOT NULL
+              GROUP BY org_unit_id`
+	rows, err := r.db.QueryContext(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("OrgUnitRepo.CountMembers: %w", err)
+	}
+	defer rows.Close()
+
+	counts := map[string]int{}
+	for rows.Next() {
+		var unitID string
+		var n int
+		if err := rows.Scan(&unitID, &n); err != nil {
+			return nil, fmt.Errorf("OrgUnitRepo.CountMembers: %w", err)
+		}
+		counts[unitID] = n
+	}
+	return counts, rows.Err()
+}
@@ -0,0 +1,13 @@
+package orgunit
+
+import "time"
+
+// OrgUnit is a node in a tenant's organisation tree. Roles assigned to a unit
+// apply to its members and to the members of every unit below it.
+type OrgUnit struct {
+	ID        string    `db:"org_unit_id" json:"id"`
+	TenantID  string    `db:"tenant_id" json:"tenant_id"`
+	Name      string    `db:"name" json:"name"`
+	ParentID  string    `db:"parent_org_unit_id" json:"parent_id,omitempty"` // empty for a root unit
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
@@ -0,0 +1,205 @@
+package policy
+
+import (
+	"auth-haven/internal/db"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrPolicyAlreadyExists = errors.New("policy with this name already exists for this tenant")
+	ErrPolicyNotFound      = errors.New("policy not found")
+)
+
+type PolicyRepository interface {
+	Create(ctx context.Context, p *Policy) (*Policy, error)
+	FindById(ctx context.Context, policyID string) (*Policy, error)
+	ListByTenant(ctx context.Context, tenantID string, f ListFilter) ([]*Policy, int, error)
+	ListApplicable(ctx context.Context, tenantID string, roleIDs []int64, permission string) ([]*Policy, error)
+	Update(ctx context.Context, policyID string, p *UpdatePolicy) error
+	Delete(ctx context.Context, policyID string) error
+}
+
+type policyRepository struct {
+	db db.DBTX
+}
+
+func PolicyRepoImpl(db db.DBTX) PolicyRepository {
+	return &policyRepository{db: db}
+}
+
+const policyColumns = `policy_id, tenant_id, name, description, role_id, COALESCE(permission, ''), condition, created_at, updated_at`
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanPolicy(row scanner) (*Policy, error) {
+	p := &Policy{}
+	var roleID sql.NullInt64
+	err := row.Scan(&p.ID, &p.TenantID, &p.Name, &p.Description, &roleID, &p.Permission, &p.Condition, &p.CreatedAt, &p.UpdatedAt)
+	if roleID.Valid {
+		p.RoleID = &roleID.Int64
+	}
+	return p, err
+}
+
+func isNameConflict(err error) bool {
+	pgErr, ok := err.(*pq.Error)
+	return ok && pgErr.Code == "23505" && pgErr.Constraint == "policies_tenant_id_name_key"
+}
+
+// Create implements PolicyRepository.
+func (r *policyRepository) Create(ctx context.Context, p *Policy) (*Policy, error) {
+	query := `INSERT INTO policies (tenant_id, name, description, role_id, permission, condition)
+              VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6)
+              RETURNING policy_id, created_at, updated_at`
+	err := r.db.QueryRowContext(ctx, query, p.TenantID, p.Name, p.Description, p.RoleID, p.Permission, p.Condition).
+		Scan(&p.ID, &p.CreatedAt, &p.UpdatedAt)
+	if err != nil {
+		if isNameConflict(err) {
+			return nil, ErrPolicyAlreadyExists
+		}
+		return nil, fmt.Errorf("PolicyRepo.Create: %w", err)
+	}
+	return p, nil
+}
+
+// FindById implements PolicyRepository.
+func (r *policyRepository) FindById(ctx context.Context, policyID string) (*Policy, error) {
+	query := `SELECT ` + policyColumns + ` FROM policies WHERE policy_id=$1`
+	p, err := scanPolicy(r.db.QueryRowContext(ctx, query, policyID))
+	if err == sql.ErrNoRows {
+		return nil, ErrPolicyNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("PolicyRepo.FindById: %w", err)
+	}
+	return p, nil
+}
+
+// ListByTenant returns a page of the tenant's policies matching f, and the
+// total number of matches
+func (r *policyRepository) ListByTenant(ctx context.Context, tenantID string, f ListFilter) ([]*Policy, int, error) {
+	conds := []string{"tenant_id=$1"}
+	args := []interface{}{tenantID}
+
+	if f.RoleID != 0 {
+		args = append(args, f.RoleID)
+		conds = append(conds, fmt.Sprintf("role_id=$%d", len(args)))
+	}
+	if f.Permission != "" {
+		args = append(args, f.Permission)
+		conds = append(conds, fmt.Sprintf("permission=$%d", len(args)))
+	}
+	where := strings.Join(conds, " AND ")
+
+	var total int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM policies WHERE `+where, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("PolicyRepo.ListByTenant: %w", err)
+	}
+
+	limit := f.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+	query := fmt.Sprintf(`SELECT %s FROM policies WHERE %s ORDER BY name LIMIT %d OFFSET %d`,
+		policyColumns, where, limit, max(f.Offset, 0))
+	policies, err := r.list(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("PolicyRepo.ListByTenant: %w", err)
+	}
+	return policies, total, nil
+}
+
+// ListApplicable returns the policies attached to any of the roles or to the
+// permission
+func (r *policyRepository) ListApplicable(ctx context.Context, tenantID string, roleIDs []int64, permission string) ([]*Policy, error) {
+	query := `SELECT ` + policyColumns + ` FROM policies
+              WHERE tenant_id=$1 AND (role_id = ANY($2) OR permission=$3)
+              ORDER BY name`
+	policies, err := r.list(ctx, query, tenantID, pq.Array(roleIDs), permission)
+	if err != nil {
+		return nil, fmt.Errorf("PolicyRepo.ListApplicable: %w", err)
+	}
+	return policies, nil
+}
+
+func (r *policyRepository) list(ctx context.Context, query string, args ...any) ([]*Policy, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	policies := []*Policy{}
+	for rows.Next() {
+		p, err := scanPolicy(rows)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, p)
+	}
+	return policies, rows.Err()
+}
+
+// Update implements PolicyRepository.
+func (r *policyRepository) Update(ctx context.Context, policyID string, p *UpdatePolicy) error {
+	fields := []string{}
+	args := []interface{}{}
+	argPos := 1
+
+	if p.Name != nil {
+		fields = append(fields, fmt.Sprintf("name=$%d", argPos))
+		args = append(args, *p.Name)
+		argPos++
+	}
+	if p.Description != nil {
+		fields = append(fields, fmt.Sprintf("description=$%d", argPos))
+		args = append(args, *p.Description)
+		argPos++
+	}
+	if p.Condition != nil {
+		fields = append(fields, fmt.Sprintf("condition=$%d", argPos))
+		args = append(args, *p.Condition)
+		argPos++
+	}
+
+	if len(fields) == 0 {
+		return errors.New("nothing to update")
+	}
+
+	query := fmt.Sprintf(`UPDATE policies SET %s, updated_at=NOW() WHERE policy_id=$%d`,
+		strings.Join(fields, ", "), argPos)
+	args = append(args, policyID)
+
+	res, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		if isNameConflict(err) {
+			return ErrPolicyAlreadyExists
+		}
+		return fmt.Errorf("PolicyRepo.Update: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrPolicyNotFound
+	}
+	return nil
+}
+
+// Delete implements PolicyRepository.
+func (r *policyRepository) Delete(ctx context.Context, policyID string) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM policies WHERE policy_id=$1`, policyID)
+	if err != nil {
+		return fmt.Errorf("PolicyRepo.Delete: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrPolicyNotFound
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package policy
+
+import "time"
+
+// Policy attaches a CEL condition to a role or to a permission. A role only
+// grants its permissions while the conditions attached to it hold, and a
+// permission attached to is only granted, by any role, while its conditions
+// hold.
+type Policy struct {
+	ID          string    `db:"policy_id" json:"id"`
+	TenantID    string    `db:"tenant_id" json:"tenant_id"`
+	Name        string    `db:"name" json:"name"`
+	Description string    `db:"description" json:"description"`
+	RoleID      *int64    `db:"role_id" json:"role_id,omitempty"`
+	Permission  string    `db:"permission" json:"permission,omitempty"`
+	Condition   string    `db:"condition" json:"condition"`
+	CreatedAt   time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at" json:"updated_at"`
+}
+
+type UpdatePolicy struct {
+	Name        *string
+	Description *string
+	Condition   *string
+}
+
+// ListFilter narrows ListByTenant; zero values match everything
+type ListFilter struct {
+	RoleID     int64
+	Permission string
+	Offset     int
+	Limit      int
+}
@@ -0,0 +1,147 @@
+package provisioning
+
+import (
+	"auth-haven/internal/db"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrTokenNotFound  = errors.New("provisioning token not found")
+	ErrPolicyNotFound = errors.New("provisioning policy not found")
+)
+
+type ProvisioningRepository interface {
+	Create(ctx context.Context, t *Token) (*Token, error)
+	FindByHash(ctx context.Context, hash string) (*Token, error)
+	ListByTenant(ctx context.Context, tenantID string) ([]*Token, error)
+	Revoke(ctx context.Context, tenantID, tokenID string) error
+	TouchUsed(ctx context.Context, tokenID string) error
+	FindPolicy(ctx context.Context, tenantID string) (*JITPolicy, error)
+	SavePolicy(ctx context.Context, p *JITPolicy) (*JITPolicy, error)
+}
+
+type provisioningRepository struct {
+	db db.DBTX
+}
+
+func ProvisioningRepoImpl(db db.DBTX) ProvisioningRepository {
+	return &provisioningRepository{db: db}
+}
+
+const tokenColumns = `token_id, tenant_id, token_hash, description, revoked, last_used_at, created_at`
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanToken(row scanner) (*Token, error) {
+	t := &Token{}
+	err := row.Scan(&t.ID, &t.TenantID, &t.TokenHash, &t.Description, &t.Revoked, &t.LastUsedAt, &t.CreatedAt)
+	return t, err
+}
+
+// Create implements ProvisioningRepository.
+func (r *provisioningRepository) Create(ctx context.Context, t *Token) (*Token, error) {
+	query := `INSERT INTO scim_tokens (tenant_id, token_hash, description)
+              VALUES ($1, $2, $3)
+              RETURNING token_id, created_at`
+	err := r.db.QueryRowContext(ctx, query, t.TenantID, t.TokenHash, t.Description).
+		Scan(&t.ID, &t.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("ProvisioningRepo.Create: %w", err)
+	}
+	return t, nil
+}
+
+// FindByHash implements ProvisioningRepository.
+func (r *provisioningRepository) FindByHash(ctx context.Context, hash string) (*Token, error) {
+	query := `SELECT ` + tokenColumns + ` FROM scim_tokens WHERE token_hash=$1`
+	t, err := scanToken(r.db.QueryRowContext(ctx, query, hash))
+	if err == sql.ErrNoRows {
+		return nil, ErrTokenNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ProvisioningRepo.FindByHash: %w", err)
+	}
+	return t, nil
+}
+
+// ListByTenant implements ProvisioningRepository.
+func (r *provisioningRepository) ListByTenant(ctx context.Context, tenantID string) ([]*Token, error) {
+	query := `SELECT ` + tokenColumns + ` FROM scim_tokens
+              WHERE tenant_id=$1 ORDER BY created_at`
+	rows, err := r.db.QueryContext(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("ProvisioningRepo.ListByTenant: %w", err)
+	}
+	defer rows.Close()
+
+	tokens := []*Token{}
+	for rows.Next() {
+		t, err := scanToken(rows)
+		if err != nil {
+			return nil, fmt.Errorf("ProvisioningRepo.ListByTenant: %w", err)
+		}
+		tokens = append(tokens, t)
+	}
+	return tokens, rows.Err()
+}
+
+// Revoke implements ProvisioningRepository.
+func (r *provisioningRepository) Revoke(ctx context.Context, tenantID, tokenID string) error {
+	query := `UPDATE scim_tokens SET revoked=TRUE WHERE tenant_id=$1 AND token_id=$2`
+	res, err := r.db.ExecContext(ctx, query, tenantID, tokenID)
+	if err != nil {
+		return fmt.Errorf("ProvisioningRepo.Revoke: %w", err)
+	}
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return ErrTokenNotFound
+	}
+	return nil
+}
+
+// TouchUsed implements ProvisioningRepository.
+func (r *provisioningRepository) TouchUsed(ctx context.Context, tokenID string) error {
+	query := `UPDATE scim_tokens SET last_used_at=NOW() WHERE token_id=$1`
+	_, err := r.db.ExecContext(ctx, query, tokenID)
+	if err != nil {
+		return fmt.Errorf("ProvisioningRepo.TouchUsed: %w", err)
+	}
+	return nil
+}
+
+// FindPolicy implements ProvisioningRepository.
+func (r *provisioningRepository) FindPolicy(ctx context.Context, tenantID string) (*JITPolicy, error) {
+	query := `SELECT tenant_id, auto_create, default_role, rules, updated_at
+              FROM jit_policies WHERE tenant_id=$1`
+	p := &JITPolicy{}
+	err := r.db.QueryRowContext(ctx, query, tenantID).
+		Scan(&p.TenantID, &p.AutoCreate, &p.DefaultRole, &p.Rules, &p.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrPolicyNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ProvisioningRepo.FindPolicy: %w", err)
+	}
+	return p, nil
+}
+
+// SavePolicy implements ProvisioningRepository.
+func (r *provisioningRepository) SavePolicy(ctx context.Context, p *JITPolicy) (*JITPolicy, error) {
+	query := `INSERT INTO jit_policies (tenant_id, auto_create, default_role, rules)
+              VALUES ($1, $2, $3, $4)
+              ON CONFLICT (tenant_id) DO UPDATE
+              SET auto_create=EXCLUDED.auto_create, default_role=EXCLUDED.default_role,
+                  rules=EXCLUDED.rules, updated_at=NOW()
+              RETURNING updated_at`
+	err := r.db.QueryRowContext(ctx, query, p.TenantID, p.AutoCreate, p.DefaultRole, p.Rules).
+		Scan(&p.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("ProvisioningRepo.SavePolicy: %w", err)
+	}
+	return p, nil
+}
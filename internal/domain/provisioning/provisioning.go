@@ -0,0 +1,25 @@
+package provisioning
+
+import "time"
+
+// Token authenticates a tenant's identity provider on the SCIM endpoints.
+// Only a hash of the bearer secret is stored.
+type Token struct {
+	ID          string     `db:"token_id" json:"id"`
+	TenantID    string     `db:"tenant_id" json:"tenant_id"`
+	TokenHash   string     `db:"token_hash" json:"-"`
+	Description string     `db:"description" json:"description"`
+	Revoked     bool       `db:"revoked" json:"revoked"`
+	LastUsedAt  *time.Time `db:"last_used_at" json:"last_used_at,omitempty"`
+	CreatedAt   time.Time  `db:"created_at" json:"created_at"`
+}
+
+// JITPolicy is a tenant's just-in-time provisioning rule set for federated
+// sign-ins
+type JITPolicy struct {
+	TenantID    string    `db:"tenant_id" json:"tenant_id"`
+	AutoCreate  bool      `db:"auto_create" json:"auto_create"`
+	DefaultRole *string   `db:"default_role" json:"default_role,omitempty"`
+	Rules       []byte    `db:"rules" json:"rules"` // JSON array of sso.Rule
+	UpdatedAt   time.Time `db:"updated_at" json:"updated_at"`
+}
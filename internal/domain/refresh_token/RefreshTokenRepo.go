@@ -0,0 +1,82 @@
+package refreshtoken
+
+import (
+	"auth-haven/internal/db"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrTokenNotFound = errors.New("refresh token not found")
+)
+
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, t *RefreshToken) (*RefreshToken, error)
+	FindByHash(ctx context.Context, tokenHash string) (*RefreshToken, error)
+	Revoke(ctx context.Context, tokenID string) error
+	RevokeAllForUser(ctx context.Context, userID string) error
+}
+
+type refreshTokenRepository struct {
+	db db.DBTX
+}
+
+func RefreshTokenRepoImpl(db db.DBTX) RefreshTokenRepository {
+	return &refreshTokenRepository{db: db}
+}
+
+// Create implements RefreshTokenRepository.
+func (r *refreshTokenRepository) Create(ctx context.Context, t *RefreshToken) (*RefreshToken, error) {
+	query := `INSERT INTO refresh_tokens (user_id, token_hash, expires_at)
+              VALUES ($1, $2, $3)
+              RETURNING token_id, created_at`
+	err := r.db.QueryRowContext(ctx, query, t.UserID, t.TokenHash, t.ExpiresAt).
+		Scan(&t.ID, &t.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("RefreshTokenRepo.Create: %w", err)
+	}
+	return t, nil
+}
+
+// FindByHash implements RefreshTokenRepository.
+func (r *refreshTokenRepository) FindByHash(ctx context.Context, tokenHash string) (*RefreshToken, error) {
+	query := `SELECT token_id, user_id, token_hash, revoked, expires_at, created_at
+              FROM refresh_tokens WHERE token_hash=$1`
+	row := r.db.QueryRowContext(ctx, query, tokenHash)
+
+	t := &RefreshToken{}
+	err := row.Scan(&t.ID, &t.UserID, &t.TokenHash, &t.Revoked, &t.ExpiresAt, &t.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrTokenNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("RefreshTokenRepo.FindByHash: %w", err)
+	}
+	return t, nil
+}
+
+// Revoke implements RefreshTokenRepository.
+func (r *refreshTokenRepository) Revoke(ctx context.Context, tokenID string) error {
+	query := `UPDATE refresh_tokens SET revoked=TRUE WHERE token_id=$1 AND NOT revoked`
+	res, err := r.db.ExecContext(ctx, query, tokenID)
+	if err != nil {
+		return fmt.Errorf("RefreshTokenRepo.Revoke: %w", err)
+	}
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return ErrTokenNotFound
+	}
+	return nil
+}
+
+// RevokeAllForUser implements RefreshTokenRepository.
+func (r *refreshTokenRepository) RevokeAllForUser(ctx context.Context, userID string) error {
+	query := `UPDATE refresh_tokens SET revoked=TRUE WHERE user_id=$1 AND revoked=FALSE`
+	_, err := r.db.ExecContext(ctx, query, userID)
+	if err != nil {
+		return fmt.Errorf("RefreshTokenRepo.RevokeAllForUser: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package relation
+
+import (
+	"auth-haven/internal/db"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrSchemaNotFound = errors.New("no namespace configuration for this tenant")
+)
+
+// RelationRepository stores relationship tuples. Reads take the revision to
+// evaluate at; tuples written after it, or deleted at or before it, are not
+// visible.
+type RelationRepository interface {
+	Write(ctx context.Context, tenantID string, writes, deletes []Tuple) (int64, error)
+	Read(ctx context.Context, tenantID string, rev int64, f Filter) ([]Tuple, error)
+	HeadRevision(ctx context.Context) (int64, error)
+	FindSchema(ctx context.Context, tenantID string) (*Schema, error)
+	SaveSchema(ctx context.Context, tenantID, source string) (*Schema, error)
+}
+
+type relationRepository struct {
+	db db.DBTX
+}
+
+func RelationRepoImpl(db db.DBTX) RelationRepository {
+	return &relationRepository{db: db}
+}
+
+// Write applies deletes and writes as one change at a new revision, which it
+// returns. Writing a tuple that exists or deleting one that does not is a
+// no-op.
+func (r *relationRepository) Write(ctx context.Context, tenantID string, writes, deletes []Tuple) (int64, error) {
+	query := `WITH rev AS (
+                  UPDATE relation_head SET revision=revision+1 RETURNING revision AS n
+              ), deletes AS (
+                  SELECT * FROM unnest($8::text[], $9::text[], $10::text[], $11::text[], $12::text[], $13::text[])
+                      AS d(object_type, object_id, relation, subject_type, subject_id, subject_relation)
+              ), deleted AS (
+                  UPDATE relation_tuples t SET deleted_rev=(SELECT n FROM rev)
+                  FROM deletes d
+                  WHERE t.tenant_id=$1 AND t.deleted_rev IS NULL
+                    AND t.object_type=d.object_type AND t.object_id=d.object_id AND t.relation=d.relation
+                    AND t.subject_type=d.subject_type AND t.subject_id=d.subject_id
+                    AND t.subject_relation=d.subject_relation
+              ), inserted AS (
+                  INSERT INTO relation_tuples (tenant_id, object_type, object_id, relation,
+                                               subject_type, subject_id, subject_relation, created_rev)
+                  SELECT $1, w.*, (SELECT n FROM rev)
+                  FROM unnest($2::text[], $3::text[], $4::text[], $5::text[], $6::text[], $7::text[]) AS w
+                  ON CONFLICT (tenant_id, object_type, object_id, relation, subject_type, subject_id, subject_relation)
+                      WHERE deleted_rev IS NULL DO NOTHING
+              )
+              SELECT n FROM rev`
+	args := []any{tenantID}
+	args = append(args, columns(writes)...)
+	args = append(args, columns(deletes)...)
+
+	var rev int64
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(&rev); err != nil {
+		return 0, fmt.Errorf("RelationRepo.Write: %w", err)
+	}
+	return rev, nil
+}
+
+// columns splits tuples into one array argument per column
+func columns(tuples []Tuple) []any {
+	cols := make([][]string, 6)
+	for _, t := range tuples {
+		for i, v := range []string{t.ObjectType, t.ObjectID, t.Relation, t.SubjectType, t.SubjectID, t.SubjectRelation} {
+			cols[i] = append(cols[i], v)
+		}
+	}
+	args := make([]any, len(cols))
+	for i, c := range cols {
+		args[i] = pq.Array(c)
+	}
+	return args
+}
+
+// Read returns the tuples matching f as of rev
+func (r *relationRepository) Read(ctx context.Context, tenantID string, rev int64, f Filter) ([]Tuple, error) {
+	conds := []string{"tenant_id=$1", "created_rev <= $2", "(deleted_rev IS NULL OR deleted_rev > $2)"}
+	args := []any{tenantID, rev}
+	for _, c := range []struct{ col, val string }{
+		{"object_type", f.ObjectType},
+		{"object_id", f.ObjectID},
+		{"relation", f.Relation},
+		{"subject_type", f.SubjectType},
+		{"subject_id", f.SubjectID},
+	} {
+		if c.val != "" {
+			args = append(args, c.val)
+			conds = append(conds, fmt.Sprintf("%s=$%d", c.col, len(args)))
+		}
+	}
+	query := `SELECT object_type, object_id, relation, subject_type, subject_id, subject_relation
+              FROM relation_tuples WHERE ` + strings.Join(conds, " AND ") + `
+              ORDER BY object_type, object_id, relation, subject_type, subject_id, subject_relation`
+	if f.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", f.Limit)
+	}
+	if f.Offset > 0 {
+		query += fmt.Sprintf(" OFFSET %d", f.Offset)
+	}
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("RelationRepo.Read: %w", err)
+	}
+	defer rows.Close()
+
+	tuples := []Tuple{}
+	for rows.Next() {
+		var t Tuple
+		if err := rows.Scan(&t.ObjectType, &t.ObjectID, &t.Relation, &t.SubjectType, &t.SubjectID, &t.SubjectRelation); err != nil {
+			return nil, fmt.Errorf("RelationRepo.Read: %w", err)
+		}
+		tuples = append(tuples, t)
+	}
+	return tuples, rows.Err()
+}
+
+// HeadRevision returns the latest committed revision. Writes take their
+// revision from relation_head under its row lock, so no write can still
+// commit at or below it.
+func (r *relationRepository) HeadRevision(ctx context.Context) (int64, error) {
+	var rev int64
+	query := `SELECT revision FROM relation_head`
+	if err := r.db.QueryRowContext(ctx, query).Scan(&rev); err != nil {
+		return 0, fmt.Errorf("RelationRepo.HeadRevision: %w", err)
+	}
+	return rev, nil
+}
+
+// FindSchema implements RelationRepository.
+func (r *relationRepository) FindSchema(ctx context.Context, tenantID string) (*Schema, error) {
+	query := `SELECT tenant_id, source, revision, updated_at FROM relation_schemas WHERE tenant_id=$1`
+	s := &Schema{}
+	err := r.db.QueryRowContext(ctx, query, tenantID).Scan(&s.TenantID, &s.Source, &s.Revision, &s.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrSchemaNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("RelationRepo.FindSchema: %w", err)
+	}
+	return s, nil
+}
+
+// SaveSchema replaces the tenant's namespace configuration at a new revision
+func (r *relationRepository) SaveSchema(ctx context.Context, tenantID, source string) (*Schema, error) {
+	query := `WITH rev AS (
+                  UPDATE relation_head SET revision=revision+1 RETURNING revision AS n
+              )
+              INSERT INTO relation_schemas (tenant_id, source, revision)
+              SELECT $1, $2, n FROM rev
+              ON CONFLICT (tenant_id) DO UPDATE
+                  SET source=EXCLUDED.source, revision=EXCLUDED.revision, updated_at=NOW()
+              RETURNING tenant_id, source, revision, updated_at`
+	s := &Schema{}
+	err := r.db.QueryRowContext(ctx, query, tenantID, source).Scan(&s.TenantID, &s.Source, &s.Revision, &s.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("RelationRepo.SaveSchema: %w", err)
+	}
+	return s, nil
+}
@@ -0,0 +1,43 @@
+package relation
+
+import "time"
+
+// Tuple states that the subject has relation to the object:
+// object_type:object_id#relation@subject_type:subject_id[#subject_relation].
+// A subject relation makes the subject a userset, everyone holding that
+// relation to the subject object.
+type Tuple struct {
+	ObjectType      string `db:"object_type" json:"object_type"`
+	ObjectID        string `db:"object_id" json:"object_id"`
+	Relation        string `db:"relation" json:"relation"`
+	SubjectType     string `db:"subject_type" json:"subject_type"`
+	SubjectID       string `db:"subject_id" json:"subject_id"`
+	SubjectRelation string `db:"subject_relation" json:"subject_relation,omitempty"`
+}
+
+func (t Tuple) String() string {
+	s := t.ObjectType + ":" + t.ObjectID + "#" + t.Relation + "@" + t.SubjectType + ":" + t.SubjectID
+	if t.SubjectRelation != "" {
+		s += "#" + t.SubjectRelation
+	}
+	return s
+}
+
+// Filter selects tuples; empty fields match everything
+type Filter struct {
+	ObjectType  string
+	ObjectID    string
+	Relation    string
+	SubjectType string
+	SubjectID   string
+	Offset      int
+	Limit       int
+}
+
+// Schema is a tenant's namespace configuration source
+type Schema struct {
+	TenantID  string    `db:"tenant_id" json:"tenant_id"`
+	Source    string    `db:"source" json:"source"`
+	Revision  int64     `db:"revision" json:"revision"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+}
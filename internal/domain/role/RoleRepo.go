@@ -22,6 +22,18 @@ type RoleRepository interface {
 	FindByTenantAndName(ctx context.Context, tenantID, name string) (*Role, error)
 	Update(ctx context.Context, roleID int64, r *UpdateRole) error
 	Delete(ctx context.Context, roleID int64) error
+	ListByTenant(ctx context.Context, tenantID string, f ListFilter) ([]*Role, int, error)
+	ListByUser(ctx context.Context, userID string) ([]*Role, error)
+	ListByGroups(ctx context.Context, groupIDs []string) ([]*Role, error)
+	ListMembers(ctx context.Context, roleID int64) ([]string, error)
+	AddMember(ctx context.Context, roleID int64, userID string) error
+	RemoveMember(ctx context.Context, roleID int64, userID string) error
+	SetMembers(ctx context.Context, roleID int64, userIDs []string) error
+	Replace(ctx context.Context, roleID int64, name, externalID string, userIDs []string) error
+	SyncGrants(ctx context.Context, userID, source string, roleIDs []int64) error
+	CountMembers(ctx context.Context, roleIDs []int64) (map[int64]int, error)
+	CountOwnerRoles(ctx context.Context, tenantID string) (int, error)
+	ListOwnerGrants(ctx context.Context, tenantID string) ([]OwnerGrant, error)
 }
 
 type roleRepository struct {
@@ -32,12 +44,27 @@ func RoleRepoImpl(db db.DBTX) RoleRepository {
 	return &roleRepository{db: db}
 }
 
+const roleColumns = `role_id, tenant_id, name, permissions, created_at, external_id`
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanRole(row scanner) (*Role, error) {
+	role := &Role{}
+	err := row.Scan(&role.ID, &role.TenantID, &role.Name, &role.Permissions, &role.CreatedAt, &role.ExternalID)
+	return role, err
+}
+
 // Create inserts a new role
 func (r *roleRepository) Create(ctx context.Context, role *Role) (*Role, error) {
-	query := `INSERT INTO roles (tenant_id, name, permissions)
-              VALUES ($1, $2, $3)
+	if role.Permissions == nil {
+		role.Permissions = []byte("{}")
+	}
+	query := `INSERT INTO roles (tenant_id, name, permissions, external_id)
+              VALUES ($1, $2, $3, $4)
               RETURNING role_id, created_at`
-	err := r.db.QueryRowContext(ctx, query, role.TenantID, role.Name, role.Permissions).
+	err := r.db.QueryRowContext(ctx, query, role.TenantID, role.Name, role.Permissions, role.ExternalID).
 		Scan(&role.ID, &role.CreatedAt)
 	if err != nil {
 		if pgErr, ok := err.(*pq.Error); ok {
@@ -52,12 +79,10 @@ func (r *roleRepository) Create(ctx context.Context, role *Role) (*Role, error)
 
 // FindById returns a role by ID
 func (r *roleRepository) FindById(ctx context.Context, roleID int64) (*Role, error) {
-	query := `SELECT role_id, tenant_id, name, permissions, created_at
-              FROM roles WHERE role_id=$1`
+	query := `SELECT ` + roleColumns + ` FROM roles WHERE role_id=$1`
 	row := r.db.QueryRowContext(ctx, query, roleID)
 
-	role := &Role{}
-	err := row.Scan(&role.ID, &role.TenantID, &role.Name, &role.Permissions, &role.CreatedAt)
+	role, err := scanRole(row)
 	if err == sql.ErrNoRows {
 		return nil, ErrRoleNotFound
 	}
@@ -69,12 +94,10 @@ func (r *roleRepository) FindById(ctx context.Context, roleID int64) (*Role, err
 
 // FindByTenantAndName returns a role by tenant ID and role name
 func (r *roleRepository) FindByTenantAndName(ctx context.Context, tenantID, name string) (*Role, error) {
-	query := `SELECT role_id, tenant_id, name, permissions, created_at
-              FROM roles WHERE tenant_id=$1 AND name=$2`
+	query := `SELECT ` + roleColumns + ` FROM roles WHERE tenant_id=$1 AND name=$2`
 	row := r.db.QueryRowContext(ctx, query, tenantID, name)
 
-	role := &Role{}
-	err := row.Scan(&role.ID, &role.TenantID, &role.Name, &role.Permissions, &role.CreatedAt)
+	role, err := scanRole(row)
 	if err == sql.ErrNoRows {
 		return nil, ErrRoleNotFound
 	}
@@ -100,6 +123,11 @@ func (r *roleRepository) Update(ctx context.Context, roleID int64, role *UpdateR
 		args = append(args, *role.Permissions)
 		argPos++
 	}
+	if role.ExternalID != nil {
+		fields = append(fields, fmt.Sprintf("external_id=NULLIF($%d, '')", argPos))
+		args = append(args, *role.ExternalID)
+		argPos++
+	}
 
 	if len(fields) == 0 {
 		return errors.New("nothing to update")
@@ -111,6 +139,11 @@ func (r *roleRepository) Update(ctx context.Context, roleID int64, role *UpdateR
 
 	_, err := r.db.ExecContext(ctx, query, args...)
 	if err != nil {
+		if pgErr, ok := err.(*pq.Error); ok {
+			if pgErr.Code == "23505" && strings.Contains(pgErr.Constraint, "roles_tenant_id_name_key") {
+				return ErrRoleAlreadyExists
+			}
+		}
 		return fmt.Errorf("RoleRepo.Update: %w", err)
 	}
 	return nil
@@ -129,3 +162,303 @@ func (r *roleRepository) Delete(ctx context.Context, roleID int64) error {
 	}
 	return nil
 }
+
+// ListByTenant returns a page of the tenant's roles matching f, and the total
+// number of matches
+func (r *roleRepository) ListByTenant(ctx context.Context, tenantID string, f ListFilter) ([]*Role, int, error) {
+	conds := []string{"tenant_id=$1"}
+	args := []interface{}{tenantID}
+
+	if f.Name != "" {
+		args = append(args, f.Name)
+		conds = append(conds, fmt.Sprintf("name=$%d", len(args)))
+	}
+	if f.ExternalID != "" {
+		args = append(args, f.ExternalID)
+		conds = append(conds, fmt.Sprintf("external_id=$%d", len(args)))
+	}
+	where := strings.Join(conds, " AND ")
+
+	var total int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM roles WHERE `+where, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("RoleRepo.ListByTenant: %w", err)
+	}
+
+	limit := f.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+	query := fmt.Sprintf(`SELECT %s FROM roles WHERE %s ORDER BY role_id LIMIT %d OFFSET %d`,
+		roleColumns, where, limit, max(f.Offset, 0))
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("RoleRepo.ListByTenant: %w", err)
+	}
+	defer rows.Close()
+
+	roles := []*Role{}
+	for rows.Next() {
+		role, err := scanRole(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("RoleRepo.ListByTenant: %w", err)
+		}
+		roles = append(roles, role)
+	}
+	return roles, total, rows.Err()
+}
+
+// ListByUser returns the user's primary role together with any roles granted
+// through membership or inherited from the user's org unit and its ancestors
+func (r *roleRepository) ListByUser(ctx context.Context, userID string) ([]*Role, error) {
+	query := `WITH RECURSIVE units AS (
+                  SELECT org_unit_id, parent_org_unit_id FROM org_units
+                  WHERE org_unit_id=(SELECT org_unit_id FROM users WHERE user_id=$1)
+                  UNION
+                  SELECT o.org_unit_id, o.parent_org_unit_id
+                  FROM org_units o JOIN units u ON o.org_unit_id=u.parent_org_unit_id
+              )
+              SELECT ` + roleColumns + ` FROM roles
+              WHERE role_id IN (
+                  SELECT role_id FROM users WHERE user_id=$1 AND role_id IS NOT NULL
+                  UNION
+                  SELECT role_id FROM user_roles WHERE user_id=$1
+                  UNION
+                  SELECT role_id FROM org_unit_roles WHERE org_unit_id IN (SELECT org_unit_id FROM units)
+              )
+              ORDER BY role_id`
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("RoleRepo.ListByUser: %w", err)
+	}
+	defer rows.Close()
+
+	roles := []*Role{}
+	for rows.Next() {
+		role, err := scanRole(rows)
+		if err != nil {
+			return nil, fmt.Errorf("RoleRepo.ListByUser: %w", err)
+		}
+		roles = append(roles, role)
+	}
+	return roles, rows.Err()
+}
+
+// ListByGroups returns the roles assigned to any of the groups
+func (r *roleRepository) ListByGroups(ctx context.Context, groupIDs []string) ([]*Role, error) {
+	query := `SELECT ` + roleColumns + ` FROM roles
+              WHERE role_id IN (SELECT role_id FROM group_roles WHERE group_id = ANY($1::uuid[]))
+              ORDER BY role_id`
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(groupIDs))
+	if err != nil {
+		return nil, fmt.Errorf("RoleRepo.ListByGroups: %w", err)
+	}
+	defer rows.Close()
+
+	roles := []*Role{}
+	for rows.Next() {
+		role, err := scanRole(rows)
+		if err != nil {
+			return nil, fmt.Errorf("RoleRepo.ListByGroups: %w", err)
+		}
+		roles = append(roles, role)
+	}
+	return roles, rows.Err()
+}
+
+// ListMembers returns the IDs of users holding the role through membership
+func (r *roleRepository) ListMembers(ctx context.Context, roleID int64) ([]string, error) {
+	query := `SELECT user_id FROM user_roles WHERE role_id=$1 ORDER BY user_id`
+	rows, err := r.db.QueryContext(ctx, query, roleID)
+	if err != nil {
+		return nil, fmt.Errorf("RoleRepo.ListMembers: %w", err)
+	}
+	defer rows.Close()
+
+	members := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("RoleRepo.ListMembers: %w", err)
+		}
+		members = append(members, id)
+	}
+	return members, rows.Err()
+}
+
+// AddMember grants the role to a user; adding an existing member is a no-op
+func (r *roleRepository) AddMember(ctx context.Context, roleID int64, userID string) error {
+	query := `INSERT INTO user_roles (user_id, role_id) VALUES ($1, $2)
+              ON CONFLICT DO NOTHING`
+	_, err := r.db.ExecContext(ctx, query, userID, roleID)
+	if err != nil {
+		return fmt.Errorf("RoleRepo.AddMember: %w", err)
+	}
+	return nil
+}
+
+// RemoveMember revokes a membership grant of the role
+func (r *roleRepository) RemoveMember(ctx context.Context, roleID int64, userID string) error {
+	query := `DELETE FROM user_roles WHERE role_id=$1 AND user_id=$2`
+	_, err := r.db.ExecContext(ctx, query, roleID, userID)
+	if err != nil {
+		return fmt.Errorf("RoleRepo.RemoveMember: %w", err)
+	}
+	return nil
+}
+
+// SetMembers replaces the role's membership with exactly userIDs
+func (r *roleRepository) SetMembers(ctx context.Context, roleID int64, userIDs []string) error {
+	query := `WITH removed AS (
+                  DELETE FROM user_roles
+                  WHERE role_id=$1 AND NOT (user_id = ANY($2::uuid[]))
+              )
+              INSERT INTO user_roles (user_id, role_id)
+              SELECT unnest($2::uuid[]), $1
+              ON CONFLICT DO NOTHING`
+	_, err := r.db.ExecContext(ctx, query, roleID, pq.Array(userIDs))
+	if err != nil {
+		return fmt.Errorf("RoleRepo.SetMembers: %w", err)
+	}
+	return nil
+}
+
+// Replace renames the role and sets its membership to exactly userIDs in a
+// single statement, so a failure leaves both unchanged
+func (r *roleRepository) Replace(ctx context.Context, roleID int64, name, externalID string, userIDs []string) error {
+	query := `WITH updated AS (
+                  UPDATE roles SET name=$2, external_id=NULLIF($3, '')
+                  WHERE role_id=$1
+                  RETURNING role_id
+              ), removed AS (
+                  DELETE FROM user_roles
+                  WHERE role_id IN (SELECT role_id FROM updated)
+                    AND NOT (user_id = ANY($4::uuid[]))
+              )
+              INSERT INTO user_roles (user_id, role_id)
+              SELECT m.user_id, updated.role_id
+              FROM updated, unnest($4::uuid[]) AS m(user_id)
+              ON CONFLICT DO NOTHING`
+	_, err := r.db.ExecContext(ctx, query, roleID, name, externalID, pq.Array(userIDs))
+	if err != nil {
+		if pgErr, ok := err.(*pq.Error); ok {
+			if pgErr.Code == "23505" && strings.Contains(pgErr.Constraint, "roles_tenant_id_name_key") {
+				return ErrRoleAlreadyExists
+			}
+		}
+		return fmt.Errorf("RoleRepo.Replace: %w", err)
+	}
+	return nil
+}
+
+// SyncGrants makes roleIDs the user's full set of memberships from source.
+// Memberships from other sources are left alone.
+func (r *roleRepository) SyncGrants(ctx context.Context, userID, source string, roleIDs []int64) error {
+	query := `WITH removed AS (
+                  DELETE FROM user_roles
+                  WHERE user_id=$1 AND source=$2 AND NOT (role_id = ANY($3::int[]))
+              )
+              INSERT INTO user_roles (user_id, role_id, source)
+              SELECT $1, unnest($3::int[]), $2
+              ON CONFLICT DO NOTHING`
+	_, err := r.db.ExecContext(ctx, query, userID, source, pq.Array(roleIDs))
+	if err != nil {
+		return fmt.Errorf("RoleRepo.SyncGrants: %w", err)
+	}
+	return nil
+}
+
+// CountMembers returns how many distinct users hold each role, either as
+// their primary role or through membership
+func (r *roleRepository) CountMembers(ctx context.Context, roleIDs []int64) (map[int64]int, error) {
+	query := `SELECT role_id, COUNT(DISTINCT user_id) FROM (
+                  SELECT role_id, user_id FROM users WHERE role_id = ANY($1::int[])
+                  UNION ALL
+                  SELECT role_id, user_id FROM user_roles WHERE role_id = ANY($1::int[])
+              ) holders
+              GROUP BY role_id`
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(roleIDs))
+	if err != nil {
+		return nil, fmt.Errorf("RoleRepo.CountMembers: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[int64]int, len(roleIDs))
+	for rows.Next() {
+		var id int64
+		var n int
+		if err := rows.Scan(&id, &n); err != nil {
+			return nil, fmt.Errorf("RoleRepo.CountMembers: %w", err)
+		}
+		counts[id] = n
+	}
+	return counts, rows.Err()
+}
+
+// CountOwnerRoles returns how many of the tenant's roles grant admin
+func (r *roleRepository) CountOwnerRoles(ctx context.Context, tenantID string) (int, error) {
+	query := `SELECT COUNT(*) FROM roles WHERE tenant_id=$1 AND permissions @> '{"admin": true}'`
+	var n int
+	if err := r.db.QueryRowContext(ctx, query, tenantID).Scan(&n); err != nil {
+		return 0, fmt.Errorf("RoleRepo.CountOwnerRoles: %w", err)
+	}
+	return n, nil
+}
+
+// ListOwnerGrants returns every grant of an owner role to an active user of
+// the tenant, including grants inherited through org units and through each
+// path of nested groups
+func (r *roleRepository) ListOwnerGrants(ctx context.Context, tenantID string) ([]OwnerGrant, error) {
+	query := `WITH RECURSIVE unit_members AS (
+                  SELECT user_id, org_unit_id FROM users
+                  WHERE tenant_id=$1 AND status='ACTIVE' AND org_unit_id IS NOT NULL
+                  UNION
+                  SELECT m.user_id, o.parent_org_unit_id
+                  FROM unit_members m JOIN org_units o ON o.org_unit_id=m.org_unit_id
+                  WHERE o.parent_org_unit_id IS NOT NULL
+              ), group_paths AS (
+                  SELECT gu.user_id, gu.group_id, ARRAY[gu.group_id::text] AS path
+                  FROM group_users gu JOIN users u ON u.user_id=gu.user_id
+                  WHERE u.tenant_id=$1 AND u.status='ACTIVE'
+                  UNION ALL
+                  SELECT p.user_id, gs.group_id, p.path || gs.group_id::text
+                  FROM group_paths p JOIN group_subgroups gs ON gs.member_group_id=p.group_id
+                  WHERE NOT gs.group_id::text = ANY(p.path)
+              )
+              SELECT u.user_id, r.role_id, TRUE, '', '{}'::text[]
+              FROM users u JOIN roles r ON r.role_id=u.role_id
+              WHERE u.tenant_id=$1 AND u.status='ACTIVE' AND r.permissions @> '{"admin": true}'
+              UNION ALL
+              SELECT u.user_id, r.role_id, FALSE, '', '{}'::text[]
+              FROM user_roles ur
+              JOIN users u ON u.user_id=ur.user_id
+              JOIN roles r ON r.role_id=ur.role_id
+              WHERE u.tenant_id=$1 AND u.status='ACTIVE' AND r.permissions @> '{"admin": true}'
+              UNION ALL
+              SELECT m.user_id, r.role_id, FALSE, m.org_unit_id::text, '{}'::text[]
+              FROM unit_members m
+              JOIN org_unit_roles our ON our.org_unit_id=m.org_unit_id
+              JOIN roles r ON r.role_id=our.role_id
+              WHERE r.permissions @> '{"admin": true}'
+              UNION ALL
+              SELECT p.user_id, r.role_id, FALSE, '', p.path
+              FROM group_paths p
+              JOIN group_roles gr ON gr.group_id=p.group_id
+              JOIN roles r ON r.role_id=gr.role_id
+              WHERE r.permissions @> '{"admin": true}'`
+	rows, err := r.db.QueryContext(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("RoleRepo.ListOwnerGrants: %w", err)
+	}
+	defer rows.Close()
+
+	grants := []OwnerGrant{}
+	for rows.Next() {
+		var g OwnerGrant
+		if err := rows.Scan(&g.UserID, &g.RoleID, &g.Primary, &g.OrgUnitID, pq.Array(&g.GroupPath)); err != nil {
+			return nil, fmt.Errorf("RoleRepo.ListOwnerGrants: %w", err)
+		}
+		grants = append(grants, g)
+	}
+	return grants, rows.Err()
+}
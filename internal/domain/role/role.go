@@ -1,6 +1,10 @@
 package role
 
-import "time"
+import (
+	"context"
+	"errors"
+	"time"
+)
 
 type Role struct {
 	ID          int64     `db:"role_id" json:"id"`
@@ -8,9 +12,70 @@ type Role struct {
 	Name        string    `db:"name" json:"name"`
 	Permissions []byte    `db:"permissions" json:"permissions"` // store JSON as []byte or map[string]any
 	CreatedAt   time.Time `db:"created_at" json:"created_at"`
+	ExternalID  *string   `db:"external_id" json:"external_id,omitempty"`
 }
 
 type UpdateRole struct {
 	Name        *string
 	Permissions *[]byte
+	ExternalID  *string
+}
+
+// Membership sources recorded on user_roles
+const (
+	SourceManual = "MANUAL"
+	SourceJIT    = "JIT"
+	SourceLDAP   = "LDAP"
+	SourceSAML   = "SAML"
+)
+
+// OwnerGrant is one way an active user holds an owner role, one granting
+// admin: as their primary role, through membership, inherited from the org
+// unit OrgUnitID, or through the groups of GroupPath
+type OwnerGrant struct {
+	UserID    string
+	RoleID    int64
+	Primary   bool
+	OrgUnitID string
+	// GroupPath runs from the group the user is a direct member of to the
+	// group the role is assigned to, one entry per nesting
+	GroupPath []string
+}
+
+// Membership reports whether the grant is a direct membership of the role
+func (g OwnerGrant) Membership() bool {
+	return !g.Primary && g.OrgUnitID == "" && len(g.GroupPath) == 0
+}
+
+// ErrLastOwner is returned when a change would leave a tenant without an
+// active owner
+var ErrLastOwner = errors.New("a tenant must keep at least one active owner")
+
+// EnsureOwnerRemains returns ErrLastOwner when dropping the owner grants
+// matched by removed would leave the tenant without an active user holding
+// an owner role
+func EnsureOwnerRemains(ctx context.Context, roles RoleRepository, tenantID string, removed func(OwnerGrant) bool) error {
+	grants, err := roles.ListOwnerGrants(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	removesAny := false
+	for _, g := range grants {
+		if !removed(g) {
+			return nil
+		}
+		removesAny = true
+	}
+	if removesAny {
+		return ErrLastOwner
+	}
+	return nil
+}
+
+// ListFilter narrows ListByTenant; zero values match everything
+type ListFilter struct {
+	Name       string
+	ExternalID string
+	Offset     int
+	Limit      int
 }
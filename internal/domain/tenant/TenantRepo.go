@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/lib/pq"
 )
@@ -20,7 +21,10 @@ type TenantRepository interface {
 	Create(ctx context.Context, t *Tenant) (*Tenant, error)
 	FindById(ctx context.Context, tenantID string) (*Tenant, error)
 	FindByDomain(ctx context.Context, domain string) (*Tenant, error)
+	FindByVerifiedDomain(ctx context.Context, domain string) (*Tenant, error)
+	ListDueForDeletion(ctx context.Context, now time.Time) ([]*Tenant, error)
 	Update(ctx context.Context, tenantID string, t *UpdateTenant) error
+	MarkDomainVerified(ctx context.Context, tenantID, domain string) error
 	Delete(ctx context.Context, tenantID string) error
 }
 
@@ -50,14 +54,32 @@ func (r *tenantRepository) Create(ctx context.Context, t *Tenant) (*Tenant, erro
 	return t, nil
 }
 
-// FindByDomain implements TenantRepository.
-func (r *tenantRepository) FindByDomain(ctx context.Context, domain string) (*Tenant, error) {
-	query := `SELECT tenant_id, name, domain, status, created_at, updated_at
-              FROM tenants WHERE domain=$1`
-	row := r.db.QueryRowContext(ctx, query, domain)
+const tenantColumns = `tenant_id, name, COALESCE(domain, ''), status, created_at, updated_at,
+                     domain_verified, allow_impersonation, COALESCE(suspended_reason, ''),
+                     deletion_scheduled_at`
 
+func scanTenant(row scanner) (*Tenant, error) {
 	t := &Tenant{}
-	err := row.Scan(&t.ID, &t.Name, &t.Domain, &t.Status, &t.CreatedAt, &t.UpdatedAt)
+	var deletionAt sql.NullTime
+	err := row.Scan(&t.ID, &t.Name, &t.Domain, &t.Status, &t.CreatedAt, &t.UpdatedAt,
+		&t.DomainVerified, &t.AllowImpersonation, &t.SuspendedReason, &deletionAt)
+	if err != nil {
+		return nil, err
+	}
+	if deletionAt.Valid {
+		t.DeletionScheduledAt = &deletionAt.Time
+	}
+	return t, nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// FindByDomain implements TenantRepository.
+func (r *tenantRepository) FindByDomain(ctx context.Context, domain string) (*Tenant, error) {
+	query := `SELECT ` + tenantColumns + ` FROM tenants WHERE domain=$1`
+	t, err := scanTenant(r.db.QueryRowContext(ctx, query, domain))
 	if err == sql.ErrNoRows {
 		return nil, ErrTenantNotFound
 	}
@@ -67,14 +89,23 @@ func (r *tenantRepository) FindByDomain(ctx context.Context, domain string) (*Te
 	return t, nil
 }
 
+// FindByVerifiedDomain returns the tenant that has proven ownership of domain
+func (r *tenantRepository) FindByVerifiedDomain(ctx context.Context, domain string) (*Tenant, error) {
+	query := `SELECT ` + tenantColumns + ` FROM tenants WHERE lower(domain)=lower($1) AND domain_verified`
+	t, err := scanTenant(r.db.QueryRowContext(ctx, query, domain))
+	if err == sql.ErrNoRows {
+		return nil, ErrTenantNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("TenantRepo.FindByVerifiedDomain: %w", err)
+	}
+	return t, nil
+}
+
 // FindById implements TenantRepository.
 func (r *tenantRepository) FindById(ctx context.Context, tenantID string) (*Tenant, error) {
-	query := `SELECT tenant_id, name, domain, status, created_at, updated_at
-              FROM tenants WHERE tenant_id=$1`
-	row := r.db.QueryRowContext(ctx, query, tenantID)
-
-	t := &Tenant{}
-	err := row.Scan(&t.ID, &t.Name, &t.Domain, &t.Status, &t.CreatedAt, &t.UpdatedAt)
+	query := `SELECT ` + tenantColumns + ` FROM tenants WHERE tenant_id=$1`
+	t, err := scanTenant(r.db.QueryRowContext(ctx, query, tenantID))
 	if err == sql.ErrNoRows {
 		return nil, ErrTenantNotFound
 	}
@@ -84,6 +115,29 @@ func (r *tenantRepository) FindById(ctx context.Context, tenantID string) (*Tena
 	return t, nil
 }
 
+// ListDueForDeletion returns the tenants whose scheduled deletion is at or
+// before now
+func (r *tenantRepository) ListDueForDeletion(ctx context.Context, now time.Time) ([]*Tenant, error) {
+	query := `SELECT ` + tenantColumns + ` FROM tenants
+              WHERE deletion_scheduled_at IS NOT NULL AND deletion_scheduled_at <= $1
+              ORDER BY deletion_scheduled_at`
+	rows, err := r.db.QueryContext(ctx, query, now)
+	if err != nil {
+		return nil, fmt.Errorf("TenantRepo.ListDueForDeletion: %w", err)
+	}
+	defer rows.Close()
+
+	var tenants []*Tenant
+	for rows.Next() {
+		t, err := scanTenant(rows)
+		if err != nil {
+			return nil, fmt.Errorf("TenantRepo.ListDueForDeletion: %w", err)
+		}
+		tenants = append(tenants, t)
+	}
+	return tenants, rows.Err()
+}
+
 // Update implements TenantRepository.
 func (r *tenantRepository) Update(ctx context.Context, tenantID string, t *UpdateTenant) error {
 	fields := []string{}
@@ -99,12 +153,38 @@ func (r *tenantRepository) Update(ctx context.Context, tenantID string, t *Updat
 		fields = append(fields, fmt.Sprintf("domain=$%d", argPos))
 		args = append(args, t.Domain)
 		argPos++
+		// A new domain has to be verified again
+		if t.DomainVerified == nil {
+			fields = append(fields, "domain_verified=FALSE")
+		}
 	}
 	if t.Status != nil {
 		fields = append(fields, fmt.Sprintf("status=$%d", argPos))
 		args = append(args, t.Status)
 		argPos++
 	}
+	if t.DomainVerified != nil {
+		fields = append(fields, fmt.Sprintf("domain_verified=$%d", argPos))
+		args = append(args, *t.DomainVerified)
+		argPos++
+	}
+	if t.AllowImpersonation != nil {
+		fields = append(fields, fmt.Sprintf("allow_impersonation=$%d", argPos))
+		args = append(args, *t.AllowImpersonation)
+		argPos++
+	}
+	if t.SuspendedReason != nil {
+		fields = append(fields, fmt.Sprintf("suspended_reason=NULLIF($%d, '')", argPos))
+		args = append(args, *t.SuspendedReason)
+		argPos++
+	}
+	if t.DeletionScheduledAt != nil {
+		fields = append(fields, fmt.Sprintf("deletion_scheduled_at=$%d", argPos))
+		args = append(args, *t.DeletionScheduledAt)
+		argPos++
+	} else if t.CancelDeletion {
+		fields = append(fields, "deletion_scheduled_at=NULL")
+	}
 
 	if len(fields) == 0 {
 		return errors.New("nothing to update")
@@ -114,10 +194,33 @@ func (r *tenantRepository) Update(ctx context.Context, tenantID string, t *Updat
 		strings.Join(fields, ", "), argPos)
 	args = append(args, tenantID)
 
-	_, err := r.db.ExecContext(ctx, query, args...)
+	res, err := r.db.ExecContext(ctx, query, args...)
 	if err != nil {
+		if pgErr, ok := err.(*pq.Error); ok && pgErr.Code == "23505" {
+			return ErrTenantAlreadyExists
+		}
 		return fmt.Errorf("TenantRepo.Update: %w", err)
 	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrTenantNotFound
+	}
+	return nil
+}
+
+// MarkDomainVerified records that the tenant proved ownership of domain. It
+// returns ErrTenantNotFound when the tenant's domain has changed since.
+func (r *tenantRepository) MarkDomainVerified(ctx context.Context, tenantID, domain string) error {
+	query := `UPDATE tenants SET domain_verified=TRUE, updated_at=NOW() WHERE tenant_id=$1 AND domain=$2`
+	res, err := r.db.ExecContext(ctx, query, tenantID, domain)
+	if err != nil {
+		if pgErr, ok := err.(*pq.Error); ok && pgErr.Code == "23505" {
+			return ErrTenantAlreadyExists
+		}
+		return fmt.Errorf("TenantRepo.MarkDomainVerified: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrTenantNotFound
+	}
 	return nil
 }
 
@@ -2,6 +2,12 @@ package tenant
 
 import "time"
 
+// Tenant statuses
+const (
+	StatusActive    = "ACTIVE"
+	StatusSuspended = "SUSPENDED"
+)
+
 type Tenant struct {
 	ID        string    `db:"tenant_id" json:"id"`
 	Name      string    `db:"name" json:"name"`
@@ -9,10 +15,24 @@ type Tenant struct {
 	Status    string    `db:"status" json:"status"`
 	CreatedAt time.Time `db:"created_at" json:"created_at"`
 	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+
+	DomainVerified     bool `db:"domain_verified" json:"domain_verified"`
+	AllowImpersonation bool `db:"allow_impersonation" json:"allow_impersonation"`
+
+	SuspendedReason     string     `db:"suspended_reason" json:"suspended_reason,omitempty"`
+	DeletionScheduledAt *time.Time `db:"deletion_scheduled_at" json:"deletion_scheduled_at,omitempty"`
 }
 
 type UpdateTenant struct {
 	Name   *string
 	Domain *string
 	Status *string
+
+	DomainVerified     *bool
+	AllowImpersonation *bool
+
+	SuspendedReason     *string
+	DeletionScheduledAt *time.Time
+	// CancelDeletion clears a scheduled deletion
+	CancelDeletion bool
 }
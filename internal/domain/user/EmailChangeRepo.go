@@ -0,0 +1,62 @@
+package user
+
+import (
+	"auth-haven/internal/db"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrEmailChangeNotFound = errors.New("email change not found or expired")
+)
+
+type EmailChangeRepository interface {
+	Create(ctx context.Context, c *EmailChange) (*EmailChange, error)
+	Consume(ctx context.Context, tokenHash string) (*EmailChange, error)
+}
+
+type emailChangeRepository struct {
+	db db.DBTX
+}
+
+func EmailChangeRepoImpl(db db.DBTX) EmailChangeRepository {
+	return &emailChangeRepository{db: db}
+}
+
+// Create records a pending change, replacing any earlier one for the user
+func (r *emailChangeRepository) Create(ctx context.Context, c *EmailChange) (*EmailChange, error) {
+	query := `INSERT INTO email_changes (user_id, new_email, token_hash, expires_at)
+              VALUES ($1, $2, $3, $4)
+              ON CONFLICT (user_id) DO UPDATE
+              SET new_email=EXCLUDED.new_email, token_hash=EXCLUDED.token_hash,
+                  expires_at=EXCLUDED.expires_at, created_at=NOW()
+              RETURNING change_id, created_at`
+	err := r.db.QueryRowContext(ctx, query, c.UserID, c.NewEmail, c.TokenHash, c.ExpiresAt).
+		Scan(&c.ID, &c.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("EmailChangeRepo.Create: %w", err)
+	}
+	return c, nil
+}
+
+// Consume deletes and returns an unexpired change so its token works once
+func (r *emailChangeRepository) Consume(ctx context.Context, tokenHash string) (*EmailChange, error) {
+	query := `DELETE FROM email_changes WHERE token_hash=$1
+              RETURNING change_id, user_id, new_email, token_hash, expires_at, created_at`
+	c := &EmailChange{}
+	err := r.db.QueryRowContext(ctx, query, tokenHash).
+		Scan(&c.ID, &c.UserID, &c.NewEmail, &c.TokenHash, &c.ExpiresAt, &c.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrEmailChangeNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("EmailChangeRepo.Consume: %w", err)
+	}
+	if time.Now().After(c.ExpiresAt) {
+		return nil, ErrEmailChangeNotFound
+	}
+	return c, nil
+}
@@ -21,6 +21,7 @@ type UserRepository interface {
 	Create(ctx context.Context, user *User) (*User, error)
 	FindById(ctx context.Context, userID string) (*User, error)
 	FindByEmail(ctx context.Context, tenantID string, email string) (*User, error)
+	List(ctx context.Context, tenantID string, f ListFilter) ([]*User, int, error)
 	Update(ctx context.Context, userID string, u *UpdateUser) error
 	Delete(ctx context.Context, userID string) error
 }
@@ -33,18 +34,39 @@ func UserRepoImpl(db db.DBTX) UserRepository {
 	return &userRepository{db: db}
 }
 
+const userColumns = `user_id, COALESCE(tenant_id::text, ''), COALESCE(role_id, 0), email, password_hash,
+                     COALESCE(full_name, ''), status, created_at, updated_at, last_login_at, external_id,
+                     COALESCE(org_unit_id::text, '')`
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanUser(row scanner) (*User, error) {
+	u := &User{}
+	err := row.Scan(&u.ID, &u.TenantID, &u.RoleId, &u.Email, &u.PasswordHash,
+		&u.FullName, &u.Status, &u.CreatedAt, &u.UpdatedAt, &u.LastLoginAt, &u.ExternalID, &u.OrgUnitID)
+	return u, err
+}
+
+// emailConstraint is the unique constraint on a tenant's user addresses
+const emailConstraint = "users_tenant_id_email_key"
+
+// isDuplicateEmail reports whether err violates emailConstraint
+func isDuplicateEmail(err error) bool {
+	var pgErr *pq.Error
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.Constraint == emailConstraint
+}
+
 func (r *userRepository) Create(ctx context.Context, u *User) (*User, error) {
-	query := `INSERT INTO users (tenant_id, role_id, email, password_hash, full_name, status)
-              VALUES ($1, $2, $3, $4, $5, $6)
-              RETURNING user_id, created_at, updated_at`
+	query := `INSERT INTO users (tenant_id, role_id, email, password_hash, full_name, status, external_id)
+              VALUES (NULLIF($1, '')::uuid, NULLIF($2, 0), $3, $4, $5, COALESCE(NULLIF($6, ''), 'ACTIVE'), $7)
+              RETURNING user_id, status, created_at, updated_at`
 	err := r.db.QueryRowContext(ctx, query, u.TenantID, u.RoleId, u.Email, u.PasswordHash,
-		u.FullName, u.Status).Scan(&u.ID, &u.CreatedAt, &u.UpdatedAt)
+		u.FullName, u.Status, u.ExternalID).Scan(&u.ID, &u.Status, &u.CreatedAt, &u.UpdatedAt)
 	if err != nil {
-		// Detect unique constraint violation (Postgres specific)
-		if pgErr, ok := err.(*pq.Error); ok {
-			if pgErr.Code == "23505" && strings.Contains(pgErr.Constraint, "users_email") {
-				return nil, ErrEmailAlreadyExists
-			}
+		if isDuplicateEmail(err) {
+			return nil, ErrEmailAlreadyExists
 		}
 		return nil, fmt.Errorf("UserRepo.Create: %w", err)
 	}
@@ -53,14 +75,10 @@ func (r *userRepository) Create(ctx context.Context, u *User) (*User, error) {
 
 // FindById implements UserRepository.
 func (r *userRepository) FindById(ctx context.Context, userID string) (*User, error) {
-	query := `SELECT user_id, tenant_id, role_id, email, password_hash, full_name,
-                     status, created_at, updated_at, last_login_at
-              FROM users WHERE user_id=$1`
+	query := `SELECT ` + userColumns + ` FROM users WHERE user_id=$1`
 	row := r.db.QueryRowContext(ctx, query, userID)
 
-	u := &User{}
-	err := row.Scan(&u.ID, &u.TenantID, &u.RoleId, &u.Email, &u.PasswordHash,
-		&u.FullName, &u.Status, &u.CreatedAt, &u.UpdatedAt, &u.LastLoginAt)
+	u, err := scanUser(row)
 	if err == sql.ErrNoRows {
 		return nil, ErrUserNotFound
 	}
@@ -69,20 +87,66 @@ func (r *userRepository) FindById(ctx context.Context, userID string) (*User, er
 
 // FindByEmail implements UserRepository.
 func (r *userRepository) FindByEmail(ctx context.Context, tenantID string, email string) (*User, error) {
-	query := `SELECT user_id, tenant_id, role_id, email, password_hash, full_name,
-					status, created_at, updated_at, last_login_at
+	query := `SELECT ` + userColumns + `
 				FROM users 
-				WHERE tenant_id=$1 AND email=$2`
+				WHERE tenant_id IS NOT DISTINCT FROM NULLIF($1, '')::uuid AND email=$2`
 	row := r.db.QueryRowContext(ctx, query, tenantID, email)
-	u := &User{}
-	err := row.Scan(&u.ID, &u.TenantID, &u.RoleId, &u.Email, &u.PasswordHash,
-		&u.FullName, &u.Status, &u.CreatedAt, &u.UpdatedAt, &u.LastLoginAt)
+	u, err := scanUser(row)
 	if err == sql.ErrNoRows {
 		return nil, ErrUserNotFound
 	}
 	return u, err
 }
 
+// List returns a page of the tenant's users matching f, and the total number
+// of matches
+func (r *userRepository) List(ctx context.Context, tenantID string, f ListFilter) ([]*User, int, error) {
+	conds := []string{"tenant_id=$1"}
+	args := []interface{}{tenantID}
+
+	if f.Email != "" {
+		args = append(args, f.Email)
+		conds = append(conds, fmt.Sprintf("lower(email)=lower($%d)", len(args)))
+	}
+	if f.ExternalID != "" {
+		args = append(args, f.ExternalID)
+		conds = append(conds, fmt.Sprintf("external_id=$%d", len(args)))
+	}
+	if f.Status != "" {
+		args = append(args, f.Status)
+		conds = append(conds, fmt.Sprintf("status=$%d", len(args)))
+	}
+	where := strings.Join(conds, " AND ")
+
+	var total int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM users WHERE `+where, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("UserRepo.List: %w", err)
+	}
+
+	limit := f.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+	query := fmt.Sprintf(`SELECT %s FROM users WHERE %s ORDER BY created_at, user_id LIMIT %d OFFSET %d`,
+		userColumns, where, limit, max(f.Offset, 0))
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("UserRepo.List: %w", err)
+	}
+	defer rows.Close()
+
+	users := []*User{}
+	for rows.Next() {
+		u, err := scanUser(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("UserRepo.List: %w", err)
+		}
+		users = append(users, u)
+	}
+	return users, total, rows.Err()
+}
+
 // Update implements UserRepository.
 func (r *userRepository) Update(ctx context.Context, userID string, u *UpdateUser) error {
 	fields := []string{}
@@ -109,11 +173,26 @@ func (r *userRepository) Update(ctx context.Context, userID string, u *UpdateUse
 		args = append(args, *u.Status)
 		argPos++
 	}
+	if u.RoleId != nil {
+		fields = append(fields, fmt.Sprintf("role_id=NULLIF($%d, 0)", argPos))
+		args = append(args, *u.RoleId)
+		argPos++
+	}
 	if u.LastLoginAt != nil {
 		fields = append(fields, fmt.Sprintf("last_login_at=$%d", argPos))
 		args = append(args, *u.LastLoginAt)
 		argPos++
 	}
+	if u.ExternalID != nil {
+		fields = append(fields, fmt.Sprintf("external_id=NULLIF($%d, '')", argPos))
+		args = append(args, *u.ExternalID)
+		argPos++
+	}
+	if u.OrgUnitID != nil {
+		fields = append(fields, fmt.Sprintf("org_unit_id=NULLIF($%d, '')::uuid", argPos))
+		args = append(args, *u.OrgUnitID)
+		argPos++
+	}
 
 	if len(fields) == 0 {
 		return ErrNothingToUpdate
@@ -126,6 +205,9 @@ func (r *userRepository) Update(ctx context.Context, userID string, u *UpdateUse
 
 	_, err := r.db.ExecContext(ctx, query, args...)
 	if err != nil {
+		if isDuplicateEmail(err) {
+			return ErrEmailAlreadyExists
+		}
 		return fmt.Errorf("UserRepo.Update: %w", err)
 	}
 	return nil
@@ -0,0 +1,82 @@
+package user
+
+import (
+	"auth-haven/internal/db"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/lib/pq"
+)
+
+// testDB connects to the migrated database named by TEST_DB_URL, skipping
+// the test when it is unset
+func testDB(t *testing.T) *sql.DB {
+	t.Helper()
+	url := os.Getenv("TEST_DB_URL")
+	if url == "" {
+		t.Skip("TEST_DB_URL is not set")
+	}
+	conn, err := db.Connect(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	if err := db.CheckSchema(context.Background(), conn); err != nil {
+		t.Fatal(err)
+	}
+	return conn
+}
+
+func TestIsDuplicateEmail(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "email constraint", err: &pq.Error{Code: "23505", Constraint: emailConstraint}, want: true},
+		{name: "wrapped", err: fmt.Errorf("insert: %w", &pq.Error{Code: "23505", Constraint: emailConstraint}), want: true},
+		{name: "other constraint", err: &pq.Error{Code: "23505", Constraint: "users_pkey"}},
+		{name: "other code", err: &pq.Error{Code: "23503", Constraint: emailConstraint}},
+		{name: "not a driver error", err: errors.New("users_tenant_id_email_key")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isDuplicateEmail(tt.err); got != tt.want {
+				t.Errorf("isDuplicateEmail(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuplicateEmail(t *testing.T) {
+	conn := testDB(t)
+	ctx := context.Background()
+
+	var tenantID string
+	err := conn.QueryRowContext(ctx, `INSERT INTO tenants (name) VALUES ('duplicate email test') RETURNING tenant_id`).Scan(&tenantID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Exec(`DELETE FROM tenants WHERE tenant_id=$1`, tenantID) })
+
+	repo := UserRepoImpl(conn)
+	first, err := repo.Create(ctx, &User{TenantID: tenantID, Email: "first@example.com"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	second, err := repo.Create(ctx, &User{TenantID: tenantID, Email: "second@example.com"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if _, err := repo.Create(ctx, &User{TenantID: tenantID, Email: first.Email}); !errors.Is(err, ErrEmailAlreadyExists) {
+		t.Errorf("Create() with a taken address error = %v, want %v", err, ErrEmailAlreadyExists)
+	}
+	if err := repo.Update(ctx, second.ID, &UpdateUser{Email: &first.Email}); !errors.Is(err, ErrEmailAlreadyExists) {
+		t.Errorf("Update() to a taken address error = %v, want %v", err, ErrEmailAlreadyExists)
+	}
+}
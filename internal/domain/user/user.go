@@ -2,6 +2,11 @@ package user
 
 import "time"
 
+const (
+	StatusActive      = "ACTIVE"
+	StatusDeactivated = "DEACTIVATED"
+)
+
 type User struct {
 	ID           string     `db:"user_id" json:"id"`
 	TenantID     string     `db:"tenant_id" json:"tenant_id"`
@@ -13,6 +18,8 @@ type User struct {
 	CreatedAt    time.Time  `db:"created_at" json:"created_at"`
 	UpdatedAt    time.Time  `db:"updated_at" json:"updated_at"`
 	LastLoginAt  *time.Time `db:"last_login_at" json:"last_login_at,omitempty"`
+	ExternalID   *string    `db:"external_id" json:"external_id,omitempty"`
+	OrgUnitID    string     `db:"org_unit_id" json:"org_unit_id,omitempty"`
 }
 
 type UpdateUser struct {
@@ -20,5 +27,28 @@ type UpdateUser struct {
 	Email        *string
 	PasswordHash *string
 	Status       *string
+	RoleId       *int64
 	LastLoginAt  *time.Time
+	ExternalID   *string
+	OrgUnitID    *string // empty moves the user out of any org unit
+}
+
+// EmailChange is a requested address change awaiting confirmation from the
+// new address
+type EmailChange struct {
+	ID        string    `db:"change_id" json:"id"`
+	UserID    string    `db:"user_id" json:"user_id"`
+	NewEmail  string    `db:"new_email" json:"new_email"`
+	TokenHash string    `db:"token_hash" json:"-"`
+	ExpiresAt time.Time `db:"expires_at" json:"expires_at"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+// ListFilter narrows List; zero values match everything
+type ListFilter struct {
+	Email      string
+	ExternalID string
+	Status     string
+	Offset     int
+	Limit      int
 }
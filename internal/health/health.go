@@ -0,0 +1,72 @@
+package health
+
+import (
+	"auth-haven/internal/db"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// checkTimeout bounds a single readiness check
+const checkTimeout = 2 * time.Second
+
+var ErrShuttingDown = errors.New("server is shutting down")
+
+// Database is what readiness needs from the connection pool
+type Database interface {
+	db.DBTX
+	PingContext(ctx context.Context) error
+}
+
+// Probe answers the readiness question of orchestrators: the server is
+// ready when Postgres answers, the schema is migrated far enough and
+// shutdown has not begun
+type Probe struct {
+	DB Database
+
+	mu           sync.Mutex
+	shuttingDown bool
+	onShutdown   []func()
+}
+
+// Ready returns nil when the server can take requests, or why it cannot
+func (p *Probe) Ready(ctx context.Context) error {
+	p.mu.Lock()
+	shuttingDown := p.shuttingDown
+	p.mu.Unlock()
+	if shuttingDown {
+		return ErrShuttingDown
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+	if err := p.DB.PingContext(ctx); err != nil {
+		return fmt.Errorf("database unreachable: %w", err)
+	}
+	return db.CheckSchema(ctx, p.DB)
+}
+
+// OnShutdown registers fn to be called when Shutdown is, so other health
+// reporters can flip along with the probe
+func (p *Probe) OnShutdown(fn func()) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.onShutdown = append(p.onShutdown, fn)
+}
+
+// Shutdown makes the server report not ready from now on
+func (p *Probe) Shutdown() {
+	p.mu.Lock()
+	if p.shuttingDown {
+		p.mu.Unlock()
+		return
+	}
+	p.shuttingDown = true
+	fns := p.onShutdown
+	p.mu.Unlock()
+	for _, fn := range fns {
+		fn()
+	}
+}
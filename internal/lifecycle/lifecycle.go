@@ -0,0 +1,94 @@
+package lifecycle
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// workerStopTimeout bounds how long a worker gets to return once canceled
+const workerStopTimeout = 10 * time.Second
+
+// Lifecycle tracks what the process has started so that it can be stopped
+// in order. Stop runs the registered steps in the reverse order of
+// registration: what was set up first, such as the database, is torn down
+// last.
+type Lifecycle struct {
+	mu     sync.Mutex
+	steps  []step
+	failed chan error
+}
+
+type step struct {
+	name    string
+	timeout time.Duration
+	stop    func(ctx context.Context) error
+}
+
+func New() *Lifecycle {
+	return &Lifecycle{failed: make(chan error, 1)}
+}
+
+// OnStop registers stop to run at shutdown with at most timeout to finish
+func (l *Lifecycle) OnStop(name string, timeout time.Duration, stop func(ctx context.Context) error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.steps = append(l.steps, step{name: name, timeout: timeout, stop: stop})
+}
+
+// Go runs a background worker until shutdown reaches it, when its context
+// is canceled and Stop waits for it to return
+func (l *Lifecycle) Go(name string, worker func(ctx context.Context)) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		worker(ctx)
+	}()
+	l.OnStop(name, workerStopTimeout, func(stopCtx context.Context) error {
+		cancel()
+		select {
+		case <-done:
+			return nil
+		case <-stopCtx.Done():
+			return stopCtx.Err()
+		}
+	})
+}
+
+// Fail reports that something the process depends on, such as a listener,
+// stopped unexpectedly
+func (l *Lifecycle) Fail(err error) {
+	select {
+	case l.failed <- err:
+	default:
+	}
+}
+
+// Failed delivers the first error passed to Fail
+func (l *Lifecycle) Failed() <-chan error {
+	return l.failed
+}
+
+// Stop runs the registered steps, last registered first. A step that fails
+// or overruns its timeout is logged and the rest still run.
+func (l *Lifecycle) Stop() {
+	l.mu.Lock()
+	steps := l.steps
+	l.steps = nil
+	l.mu.Unlock()
+
+	for i := len(steps) - 1; i >= 0; i-- {
+		s := steps[i]
+		start := time.Now()
+		ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+		err := s.stop(ctx)
+		cancel()
+		if err != nil {
+			slog.Error("shutdown step failed", "step", s.name, "error", err)
+			continue
+		}
+		slog.Info("stopped", "step", s.name, "duration", time.Since(start))
+	}
+}
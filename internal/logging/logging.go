@@ -0,0 +1,69 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+)
+
+// New builds a logger writing text or JSON lines at or above level
+// (debug, info, warn or error). Every line carries the attributes attached
+// to its context with With, and attributes with secret-looking keys are
+// masked.
+func New(w io.Writer, format, level string) (*slog.Logger, error) {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("invalid log level %q", level)
+	}
+	opts := &slog.HandlerOptions{Level: lvl, ReplaceAttr: redactAttr}
+
+	var h slog.Handler
+	switch format {
+	case "text":
+		h = slog.NewTextHandler(w, opts)
+	case "json":
+		h = slog.NewJSONHandler(w, opts)
+	default:
+		return nil, fmt.Errorf("invalid log format %q", format)
+	}
+	return slog.New(contextHandler{h}), nil
+}
+
+type attrsKey struct{}
+
+// With returns a context whose log lines carry attrs as well as any attached
+// to ctx before
+func With(ctx context.Context, attrs ...slog.Attr) context.Context {
+	prev := attrsFrom(ctx)
+	all := make([]slog.Attr, 0, len(prev)+len(attrs))
+	all = append(all, prev...)
+	all = append(all, attrs...)
+	return context.WithValue(ctx, attrsKey{}, all)
+}
+
+func attrsFrom(ctx context.Context) []slog.Attr {
+	if ctx == nil {
+		return nil
+	}
+	attrs, _ := ctx.Value(attrsKey{}).([]slog.Attr)
+	return attrs
+}
+
+// contextHandler adds the context's attributes to each record
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	r.AddAttrs(attrsFrom(ctx)...)
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,101 @@
+package logging
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const redacted = "[REDACTED]"
+
+var (
+	// secretWords mark a field or attribute as secret wherever they appear
+	// in its name
+	secretWords = []string{"password", "secret", "token", "authorization", "private_key", "api_key", "saml_response"}
+	// secretNames are secret only as the whole name
+	secretNames = []string{"code"}
+	// notSecret are names that contain a secret word but hold no secret
+	notSecret = []string{"page_token", "next_page_token", "token_id", "token_type", "issued_token_type", "requested_token_type", "subject_token_type"}
+)
+
+// IsSecret reports whether a field or attribute with this name holds a
+// secret
+func IsSecret(name string) bool {
+	name = strings.ToLower(name)
+	if slices.Contains(notSecret, name) {
+		return false
+	}
+	if slices.Contains(secretNames, name) {
+		return true
+	}
+	return slices.ContainsFunc(secretWords, func(w string) bool {
+		return strings.Contains(name, w)
+	})
+}
+
+func redactAttr(_ []string, a slog.Attr) slog.Attr {
+	if a.Value.Kind() != slog.KindGroup && IsSecret(a.Key) {
+		return slog.String(a.Key, redacted)
+	}
+	return a
+}
+
+// Request returns a log value for a request message that renders it as JSON
+// with its secret fields masked. The work is only done if the line is
+// written.
+func Request(req any) slog.LogValuer {
+	return request{req}
+}
+
+type request struct {
+	req any
+}
+
+func (r request) LogValue() slog.Value {
+	msg, ok := r.req.(proto.Message)
+	if !ok {
+		return slog.StringValue(fmt.Sprintf("%T", r.req))
+	}
+	msg = proto.Clone(msg)
+	redactMessage(msg.ProtoReflect())
+	b, err := protojson.Marshal(msg)
+	if err != nil {
+		return slog.StringValue(fmt.Sprintf("%T", r.req))
+	}
+	return slog.StringValue(string(b))
+}
+
+// redactMessage masks secret fields in m and its nested messages
+func redactMessage(m protoreflect.Message) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case IsSecret(string(fd.Name())):
+			if fd.Kind() == protoreflect.StringKind && fd.Cardinality() != protoreflect.Repeated {
+				m.Set(fd, protoreflect.ValueOfString(redacted))
+			} else {
+				m.Clear(fd)
+			}
+		case fd.IsMap():
+			if fd.MapValue().Kind() == protoreflect.MessageKind {
+				v.Map().Range(func(_ protoreflect.MapKey, mv protoreflect.Value) bool {
+					redactMessage(mv.Message())
+					return true
+				})
+			}
+		case fd.Kind() == protoreflect.MessageKind || fd.Kind() == protoreflect.GroupKind:
+			if fd.IsList() {
+				for i := range v.List().Len() {
+					redactMessage(v.List().Get(i).Message())
+				}
+			} else {
+				redactMessage(v.Message())
+			}
+		}
+		return true
+	})
+}
@@ -0,0 +1,128 @@
+package metrics
+
+import (
+	"auth-haven/internal/domain/audit"
+	"database/sql"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const namespace = "authhaven"
+
+// Registry holds every metric the server exports
+var Registry = prometheus.NewRegistry()
+
+var (
+	logins = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "logins_total",
+		Help:      "Login attempts by method, result and failure reason.",
+	}, []string{"method", "result", "reason"})
+
+	tokens = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tokens_total",
+		Help:      "Token lifecycle events: issued, refreshed, exchanged and revoked.",
+	}, []string{"event"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		logins,
+		tokens,
+		rpcStarted,
+		rpcHandled,
+		rpcDuration,
+	)
+}
+
+// Handler serves the registry in the Prometheus exposition format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+// Login methods
+const (
+	LoginPassword = "password"
+	LoginOIDC     = "oidc"
+	LoginSAML     = "saml"
+)
+
+// ObserveLogin counts a login attempt that ended with err
+func ObserveLogin(method string, err error) {
+	if err == nil {
+		logins.WithLabelValues(method, "success", "").Inc()
+		return
+	}
+	logins.WithLabelValues(method, "failure", loginFailureReason(err)).Inc()
+}
+
+// loginFailureReason buckets a failed login by the status it returned
+func loginFailureReason(err error) string {
+	switch status.Code(err) {
+	case codes.Unauthenticated:
+		return "invalid_credentials"
+	case codes.PermissionDenied:
+		return "denied"
+	case codes.NotFound:
+		return "unknown_provider"
+	case codes.InvalidArgument:
+		return "invalid_request"
+	case codes.FailedPrecondition:
+		return "not_configured"
+	case codes.Unavailable:
+		return "upstream_unavailable"
+	}
+	return "error"
+}
+
+// Token events
+const (
+	TokenIssued    = "issued"
+	TokenRefreshed = "refreshed"
+	TokenExchanged = "exchanged"
+	TokenRevoked   = "revoked"
+)
+
+// CountToken counts a token lifecycle event
+func CountToken(event string) {
+	tokens.WithLabelValues(event).Inc()
+}
+
+// RegisterDB exports the connection pool statistics of db
+func RegisterDB(db *sql.DB) {
+	Registry.MustRegister(collectors.NewDBStatsCollector(db, "auth"))
+}
+
+// RegisterAudit exports the counters of the audit pipeline
+func RegisterAudit(p *audit.Pipeline) {
+	Registry.MustRegister(
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "audit_queued",
+			Help:      "Audit entries waiting to be written.",
+		}, func() float64 { return float64(p.Stats().Queued) }),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "audit_written_total",
+			Help:      "Audit entries written.",
+		}, func() float64 { return float64(p.Stats().Written) }),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "audit_dropped_total",
+			Help:      "Audit entries dropped because the queue was full.",
+		}, func() float64 { return float64(p.Stats().Dropped) }),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "audit_failed_total",
+			Help:      "Audit entries lost because writing them kept failing.",
+		}, func() float64 { return float64(p.Stats().Failed) }),
+	)
+}
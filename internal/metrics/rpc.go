@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	rpcStarted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_server_started_total",
+		Help: "RPCs started on the server.",
+	}, []string{"grpc_service", "grpc_method"})
+
+	rpcHandled = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_server_handled_total",
+		Help: "RPCs completed on the server, by status code.",
+	}, []string{"grpc_service", "grpc_method", "grpc_code"})
+
+	rpcDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_server_handling_seconds",
+		Help:    "Time taken to handle RPCs.",
+		Buckets: []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+	}, []string{"grpc_service", "grpc_method"})
+)
+
+// Unary counts and times unary RPCs. It runs before authentication so
+// rejected calls are counted too.
+func Unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	done := observe(info.FullMethod)
+	resp, err := handler(ctx, req)
+	done(err)
+	return resp, err
+}
+
+// Stream counts and times streaming RPCs
+func Stream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	done := observe(info.FullMethod)
+	err := handler(srv, ss)
+	done(err)
+	return err
+}
+
+func observe(fullMethod string) func(error) {
+	service, method := splitMethod(fullMethod)
+	rpcStarted.WithLabelValues(service, method).Inc()
+	start := time.Now()
+	return func(err error) {
+		rpcDuration.WithLabelValues(service, method).Observe(time.Since(start).Seconds())
+		rpcHandled.WithLabelValues(service, method, status.Code(err).String()).Inc()
+	}
+}
+
+// splitMethod splits "/pkg.Service/Method" into service and method
+func splitMethod(fullMethod string) (string, string) {
+	service, method, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !ok {
+		return "unknown", "unknown"
+	}
+	return service, method
+}
@@ -0,0 +1,54 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/smtp"
+	"strings"
+)
+
+// Sender delivers messages to users out of band
+type Sender interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+// LogSender writes messages to the server log instead of delivering them. It
+// is meant for local development only, as bodies may carry secrets.
+type LogSender struct{}
+
+// Send implements Sender.
+func (LogSender) Send(ctx context.Context, to, subject, body string) error {
+	slog.InfoContext(ctx, "notify", "to", to, "subject", subject, "body", body)
+	return nil
+}
+
+// SMTPSender delivers mail through an SMTP relay
+type SMTPSender struct {
+	Addr     string // host:port
+	From     string
+	Username string
+	Password string
+}
+
+// Send implements Sender.
+func (s *SMTPSender) Send(ctx context.Context, to, subject, body string) error {
+	if strings.ContainsAny(to+subject, "\r\n") {
+		return errors.New("notify: header contains a line break")
+	}
+	var auth smtp.Auth
+	if s.Username != "" {
+		host, _, err := net.SplitHostPort(s.Addr)
+		if err != nil {
+			return err
+		}
+		auth = smtp.PlainAuth("", s.Username, s.Password, host)
+	}
+	msg := "From: " + s.From + "\r\n" +
+		"To: " + to + "\r\n" +
+		"Subject: " + subject + "\r\n" +
+		"Content-Type: text/plain; charset=UTF-8\r\n" +
+		"\r\n" + body
+	return smtp.SendMail(s.Addr, auth, s.From, []string{to}, []byte(msg))
+}
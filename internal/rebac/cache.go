@@ -0,0 +1,94 @@
+package rebac
+
+import (
+	"sync"
+	"time"
+)
+
+// maxCachedChecks bounds the check cache; it is emptied when full
+const maxCachedChecks = 100_000
+
+// Cache keeps parsed schemas and recent check results. Results are keyed by
+// schema revision, so a new schema never serves stale answers; tuple changes
+// are only seen once an entry expires, unless the caller asks for a revision
+// newer than the one the entry was computed at.
+type Cache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	schemas map[string]cachedSchema
+	checks  map[checkKey]cachedCheck
+}
+
+type cachedSchema struct {
+	revision int64
+	schema   *Schema
+}
+
+type checkKey struct {
+	tenantID       string
+	schemaRevision int64
+	object         Object
+	relation       string
+	subject        Subject
+}
+
+type cachedCheck struct {
+	allowed  bool
+	revision int64
+	expires  time.Time
+}
+
+func NewCache(ttl time.Duration) *Cache {
+	return &Cache{
+		ttl:     ttl,
+		schemas: map[string]cachedSchema{},
+		checks:  map[checkKey]cachedCheck{},
+	}
+}
+
+// Schema returns the tenant's parsed schema at revision, parsing source the
+// first time it is seen
+func (c *Cache) Schema(tenantID string, revision int64, source string) (*Schema, error) {
+	c.mu.Lock()
+	cached, ok := c.schemas[tenantID]
+	c.mu.Unlock()
+	if ok && cached.revision == revision {
+		return cached.schema, nil
+	}
+
+	schema, err := ParseSchema(source)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cur, ok := c.schemas[tenantID]; !ok || cur.revision < revision {
+		c.schemas[tenantID] = cachedSchema{revision: revision, schema: schema}
+	}
+	return schema, nil
+}
+
+// Check returns a cached result computed at minRevision or later. With
+// minRevision 0 any unexpired result will do.
+func (c *Cache) Check(tenantID string, schemaRevision int64, object Object, rel string, subject Subject, minRevision int64) (allowed bool, revision int64, ok bool) {
+	key := checkKey{tenantID, schemaRevision, object, rel, subject}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, found := c.checks[key]
+	if !found || time.Now().After(e.expires) || e.revision < minRevision {
+		return false, 0, false
+	}
+	return e.allowed, e.revision, true
+}
+
+// PutCheck records a result computed at revision
+func (c *Cache) PutCheck(tenantID string, schemaRevision int64, object Object, rel string, subject Subject, revision int64, allowed bool) {
+	key := checkKey{tenantID, schemaRevision, object, rel, subject}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.checks) >= maxCachedChecks {
+		clear(c.checks)
+	}
+	c.checks[key] = cachedCheck{allowed: allowed, revision: revision, expires: time.Now().Add(c.ttl)}
+}
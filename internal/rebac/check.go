@@ -0,0 +1,247 @@
+package rebac
+
+import (
+	"auth-haven/internal/domain/relation"
+	"context"
+	"errors"
+	"fmt"
+)
+
+// maxDepth bounds how many rewrites and usersets a single check may follow
+const maxDepth = 32
+
+var ErrMaxDepth = errors.New("relationship graph is too deep to evaluate")
+
+// Object identifies an object, or a subject that is not a userset
+type Object struct {
+	Type string
+	ID   string
+}
+
+func (o Object) String() string { return o.Type + ":" + o.ID }
+
+// Subject is a single object, or with a Relation the userset of everyone
+// holding that relation to the object
+type Subject struct {
+	Object
+	Relation string
+}
+
+func (s Subject) String() string {
+	if s.Relation == "" {
+		return s.Object.String()
+	}
+	return s.Object.String() + "#" + s.Relation
+}
+
+// Reader reads one tenant's tuples at a fixed revision
+type Reader struct {
+	Repo     relation.RelationRepository
+	TenantID string
+	Revision int64
+
+	// loaded holds every tuple of the preloaded object types, by type and
+	// then by object#relation
+	loaded map[string]map[string][]Subject
+}
+
+// preload reads every tuple on objects of objectType in one query, so that
+// evaluating many objects of the type does not query each one. It returns
+// the IDs of those objects in order.
+func (r *Reader) preload(ctx context.Context, objectType string) ([]string, error) {
+	tuples, err := r.Repo.Read(ctx, r.TenantID, r.Revision, relation.Filter{ObjectType: objectType})
+	if err != nil {
+		return nil, err
+	}
+	byKey := map[string][]Subject{}
+	ids := []string{}
+	for _, t := range tuples {
+		if len(ids) == 0 || ids[len(ids)-1] != t.ObjectID {
+			ids = append(ids, t.ObjectID)
+		}
+		key := t.ObjectID + "#" + t.Relation
+		byKey[key] = append(byKey[key], Subject{Object: Object{Type: t.SubjectType, ID: t.SubjectID}, Relation: t.SubjectRelation})
+	}
+	if r.loaded == nil {
+		r.loaded = map[string]map[string][]Subject{}
+	}
+	r.loaded[objectType] = byKey
+	return ids, nil
+}
+
+func (r *Reader) subjects(ctx context.Context, obj Object, rel string) ([]Subject, error) {
+	if byKey, ok := r.loaded[obj.Type]; ok {
+		return byKey[obj.ID+"#"+rel], nil
+	}
+	tuples, err := r.Repo.Read(ctx, r.TenantID, r.Revision, relation.Filter{
+		ObjectType: obj.Type,
+		ObjectID:   obj.ID,
+		Relation:   rel,
+	})
+	if err != nil {
+		return nil, err
+	}
+	subjects := make([]Subject, len(tuples))
+	for i, t := range tuples {
+		subjects[i] = Subject{Object: Object{Type: t.SubjectType, ID: t.SubjectID}, Relation: t.SubjectRelation}
+	}
+	return subjects, nil
+}
+
+// Engine evaluates checks, expansions and object listings against a schema
+// and a snapshot of tuples
+type Engine struct {
+	Schema *Schema
+	Reader *Reader
+}
+
+// Check reports whether subject has relation to object. cacheable is false
+// when the answer depended on cutting a cycle in the tuples: it then depends
+// on where evaluation entered the cycle, and under an exclusion a cut can
+// turn into an allow, so it must not be reused.
+func (e *Engine) Check(ctx context.Context, object Object, rel string, subject Subject) (allowed, cacheable bool, err error) {
+	c := e.checker(subject)
+	allowed, err = c.check(ctx, object, rel, 0)
+	return allowed, c.cuts == 0, err
+}
+
+func (e *Engine) checker(subject Subject) *checker {
+	return &checker{Engine: e, subject: subject, visiting: map[string]bool{}, memo: map[string]bool{}}
+}
+
+type checker struct {
+	*Engine
+	subject Subject
+	// visiting breaks cycles in the data: a relation that is already being
+	// evaluated further up contributes nothing new
+	visiting map[string]bool
+	// memo holds results that did not depend on a cycle being cut
+	memo map[string]bool
+	cuts int
+}
+
+func (c *checker) check(ctx context.Context, object Object, rel string, depth int) (bool, error) {
+	if depth > maxDepth {
+		return false, ErrMaxDepth
+	}
+	// A userset subject holds itself
+	if c.subject.Relation == rel && c.subject.Object == object {
+		return true, nil
+	}
+	key := object.String() + "#" + rel
+	if v, ok := c.memo[key]; ok {
+		return v, nil
+	}
+	if c.visiting[key] {
+		c.cuts++
+		return false, nil
+	}
+	c.visiting[key] = true
+	defer delete(c.visiting, key)
+
+	r, err := c.Schema.Relation(object.Type, rel)
+	if err != nil {
+		return false, err
+	}
+	cuts := c.cuts
+	ok, err := c.eval(ctx, object, r, r.Rewrite, depth)
+	if err != nil {
+		return false, err
+	}
+	if c.cuts == cuts {
+		c.memo[key] = ok
+	}
+	return ok, nil
+}
+
+func (c *checker) eval(ctx context.Context, object Object, r *Relation, e Expr, depth int) (bool, error) {
+	switch e := e.(type) {
+	case This:
+		subjects, err := c.Reader.subjects(ctx, object, r.Name)
+		if err != nil {
+			return false, err
+		}
+		for _, s := range subjects {
+			if s == c.subject {
+				return true, nil
+			}
+		}
+		for _, s := range subjects {
+			if s.Relation == "" || !c.Schema.has(s.Type, s.Relation) {
+				continue
+			}
+			ok, err := c.check(ctx, s.Object, s.Relation, depth+1)
+			if err != nil || ok {
+				return ok, err
+			}
+		}
+		return false, nil
+	case ComputedUserset:
+		return c.check(ctx, object, e.Relation, depth+1)
+	case TupleToUserset:
+		subjects, err := c.Reader.subjects(ctx, object, e.Tupleset)
+		if err != nil {
+			return false, err
+		}
+		for _, s := range subjects {
+			if !c.Schema.has(s.Type, e.Computed) {
+				continue
+			}
+			ok, err := c.check(ctx, s.Object, e.Computed, depth+1)
+			if err != nil || ok {
+				return ok, err
+			}
+		}
+		return false, nil
+	case Union:
+		for _, child := range e.Children {
+			ok, err := c.eval(ctx, object, r, child, depth)
+			if err != nil || ok {
+				return ok, err
+			}
+		}
+		return false, nil
+	case Intersection:
+		for _, child := range e.Children {
+			ok, err := c.eval(ctx, object, r, child, depth)
+			if err != nil || !ok {
+				return false, err
+			}
+		}
+		return true, nil
+	case Exclusion:
+		ok, err := c.eval(ctx, object, r, e.Base, depth)
+		if err != nil || !ok {
+			return false, err
+		}
+		excluded, err := c.eval(ctx, object, r, e.Subtract, depth)
+		return !excluded, err
+	}
+	return false, fmt.Errorf("unsupported rewrite %T", e)
+}
+
+// ListObjects returns the IDs of the objects of objectType that subject has
+// relation to. The tuples of objectType are read in one query and the
+// candidates share a checker, so results for usersets they have in common
+// are evaluated once.
+func (e *Engine) ListObjects(ctx context.Context, objectType, rel string, subject Subject) ([]string, error) {
+	if _, err := e.Schema.Relation(objectType, rel); err != nil {
+		return nil, err
+	}
+	candidates, err := e.Reader.preload(ctx, objectType)
+	if err != nil {
+		return nil, err
+	}
+	c := e.checker(subject)
+	ids := []string{}
+	for _, id := range candidates {
+		ok, err := c.check(ctx, Object{Type: objectType, ID: id}, rel, 0)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,208 @@
+package rebac
+
+import (
+	"auth-haven/internal/domain/relation"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const testSchema = `
+namespace user {}
+namespace group {
+  relation member
+}
+namespace folder {
+  relation parent
+  relation viewer
+  relation can_view = viewer | parent->can_view
+}
+namespace doc {
+  relation parent
+  relation owner
+  relation editor = this | owner
+  relation viewer = this | editor | parent->can_view
+  relation banned
+  relation can_view = viewer - banned
+  relation approver
+  relation can_publish = editor & approver
+}
+namespace node {
+  relation parent
+  relation blocked = this | parent->allowed
+  relation allowed = this - blocked
+}`
+
+// fakeTuples serves tuples from memory, counting reads
+type fakeTuples struct {
+	relation.RelationRepository
+	tuples []relation.Tuple
+	reads  int
+}
+
+func (f *fakeTuples) Read(_ context.Context, _ string, _ int64, filter relation.Filter) ([]relation.Tuple, error) {
+	f.reads++
+	matched := []relation.Tuple{}
+	for _, t := range f.tuples {
+		if (filter.ObjectType == "" || t.ObjectType == filter.ObjectType) &&
+			(filter.ObjectID == "" || t.ObjectID == filter.ObjectID) &&
+			(filter.Relation == "" || t.Relation == filter.Relation) {
+			matched = append(matched, t)
+		}
+	}
+	slices.SortFunc(matched, func(a, b relation.Tuple) int { return strings.Compare(a.ObjectID, b.ObjectID) })
+	return matched, nil
+}
+
+// tuple parses "type:id#relation@type:id[#relation]"
+func tuple(s string) relation.Tuple {
+	object, subject, _ := strings.Cut(s, "@")
+	object, rel, _ := strings.Cut(object, "#")
+	objectType, objectID, _ := strings.Cut(object, ":")
+	subject, subjectRel, _ := strings.Cut(subject, "#")
+	subjectType, subjectID, _ := strings.Cut(subject, ":")
+	return relation.Tuple{
+		ObjectType:      objectType,
+		ObjectID:        objectID,
+		Relation:        rel,
+		SubjectType:     subjectType,
+		SubjectID:       subjectID,
+		SubjectRelation: subjectRel,
+	}
+}
+
+func newTestEngine(t *testing.T, tuples ...string) (*Engine, *fakeTuples) {
+	t.Helper()
+	schema, err := ParseSchema(testSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := &fakeTuples{}
+	for _, s := range tuples {
+		repo.tuples = append(repo.tuples, tuple(s))
+	}
+	return &Engine{Schema: schema, Reader: &Reader{Repo: repo, TenantID: "tenant-1", Revision: 1}}, repo
+}
+
+func user(id string) Subject { return Subject{Object: Object{Type: "user", ID: id}} }
+
+func TestCheck(t *testing.T) {
+	e, _ := newTestEngine(t,
+		"doc:d1#owner@user:alice",
+		"doc:d1#viewer@group:eng#member",
+		"group:eng#member@user:bob",
+		"group:eng#member@user:carol",
+		"doc:d1#banned@user:carol",
+		"doc:d1#approver@user:alice",
+		"doc:d1#approver@user:bob",
+		"doc:d2#parent@folder:f1",
+		"folder:f1#parent@folder:f2",
+		"folder:f2#viewer@user:dave",
+		"group:a#member@group:b#member",
+		"group:b#member@group:a#member",
+		"group:b#member@user:erin",
+	)
+	eng := Subject{Object: Object{Type: "group", ID: "eng"}, Relation: "member"}
+	tests := []struct {
+		name     string
+		object   Object
+		relation string
+		subject  Subject
+		want     bool
+	}{
+		{"stored tuple", Object{"doc", "d1"}, "owner", user("alice"), true},
+		{"computed userset", Object{"doc", "d1"}, "editor", user("alice"), true},
+		{"union through a userset", Object{"doc", "d1"}, "viewer", user("bob"), true},
+		{"userset subject holds itself", Object{"doc", "d1"}, "viewer", eng, true},
+		{"no grant", Object{"doc", "d1"}, "viewer", user("dave"), false},
+		{"intersection of both", Object{"doc", "d1"}, "can_publish", user("alice"), true},
+		{"intersection of one side", Object{"doc", "d1"}, "can_publish", user("bob"), false},
+		{"exclusion keeps the rest", Object{"doc", "d1"}, "can_view", user("bob"), true},
+		{"exclusion removes the banned", Object{"doc", "d1"}, "can_view", user("carol"), false},
+		{"banned still views", Object{"doc", "d1"}, "viewer", user("carol"), true},
+		{"tuple-to-userset through folders", Object{"doc", "d2"}, "can_view", user("dave"), true},
+		{"tuple-to-userset elsewhere", Object{"doc", "d2"}, "can_view", user("alice"), false},
+		{"cycle with a member", Object{"group", "a"}, "member", user("erin"), true},
+		{"cycle without a member", Object{"group", "a"}, "member", user("frank"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := e.Check(context.Background(), tt.object, tt.relation, tt.subject)
+			if err != nil {
+				t.Fatalf("Check() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Check(%s#%s@%s) = %v, want %v", tt.object, tt.relation, tt.subject, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckCutIsNotCacheable(t *testing.T) {
+	e, _ := newTestEngine(t,
+		"node:x#parent@node:x",
+		"node:x#allowed@user:alice",
+		"node:y#allowed@user:alice",
+	)
+	tests := []struct {
+		name          string
+		object        Object
+		wantCacheable bool
+	}{
+		// x#allowed subtracts x#blocked, which follows the parent back to
+		// x#allowed and has to be cut
+		{"exclusion through a cycle", Object{"node", "x"}, false},
+		{"no cycle", Object{"node", "y"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, cacheable, err := e.Check(context.Background(), tt.object, "allowed", user("alice"))
+			if err != nil {
+				t.Fatalf("Check() error = %v", err)
+			}
+			if cacheable != tt.wantCacheable {
+				t.Errorf("cacheable = %v, want %v", cacheable, tt.wantCacheable)
+			}
+		})
+	}
+}
+
+func TestCheckMaxDepth(t *testing.T) {
+	tuples := []string{}
+	for i := range maxDepth + 2 {
+		tuples = append(tuples, fmt.Sprintf("folder:f%d#parent@folder:f%d", i, i+1))
+	}
+	e, _ := newTestEngine(t, tuples...)
+	if _, _, err := e.Check(context.Background(), Object{"folder", "f0"}, "can_view", user("alice")); !errors.Is(err, ErrMaxDepth) {
+		t.Fatalf("Check() error = %v, want %v", err, ErrMaxDepth)
+	}
+}
+
+func TestListObjects(t *testing.T) {
+	tuples := []string{
+		"doc:d1#viewer@group:eng#member",
+		"group:eng#member@user:bob",
+		"doc:d2#banned@user:bob",
+		"doc:d2#viewer@user:bob",
+		"doc:d3#parent@folder:f1",
+		"folder:f1#viewer@user:bob",
+	}
+	for i := range 20 {
+		tuples = append(tuples, fmt.Sprintf("doc:other%02d#owner@user:alice", i))
+	}
+	e, repo := newTestEngine(t, tuples...)
+	got, err := e.ListObjects(context.Background(), "doc", "can_view", user("bob"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"d1", "d3"}; !slices.Equal(got, want) {
+		t.Errorf("ListObjects() = %v, want %v", got, want)
+	}
+	// One read for the documents, one for the group and one for the folder
+	if repo.reads != 3 {
+		t.Errorf("ListObjects() read %d times, want 3", repo.reads)
+	}
+}
@@ -0,0 +1,102 @@
+package rebac
+
+import (
+	"context"
+	"fmt"
+)
+
+// Node is one level of an expanded userset tree. Leaves list the subjects
+// stored for a relation; inner nodes combine their children with Operation.
+type Node struct {
+	Object    Object
+	Relation  string
+	Operation string // "union", "intersection", "exclusion" or "" for a leaf
+	Subjects  []Subject
+	Children  []*Node
+}
+
+// Expand returns the userset tree of relation on object. Usersets met as
+// subjects are expanded in turn, down to maxDepth.
+func (e *Engine) Expand(ctx context.Context, object Object, rel string) (*Node, error) {
+	return e.expand(ctx, object, rel, 0, map[string]bool{})
+}
+
+func (e *Engine) expand(ctx context.Context, object Object, rel string, depth int, visiting map[string]bool) (*Node, error) {
+	if depth > maxDepth {
+		return nil, ErrMaxDepth
+	}
+	r, err := e.Schema.Relation(object.Type, rel)
+	if err != nil {
+		return nil, err
+	}
+	key := object.String() + "#" + rel
+	if visiting[key] {
+		return &Node{Object: object, Relation: rel}, nil
+	}
+	visiting[key] = true
+	defer delete(visiting, key)
+	return e.expandExpr(ctx, object, r, r.Rewrite, depth, visiting)
+}
+
+func (e *Engine) expandExpr(ctx context.Context, object Object, r *Relation, x Expr, depth int, visiting map[string]bool) (*Node, error) {
+	switch x := x.(type) {
+	case This:
+		subjects, err := e.Reader.subjects(ctx, object, r.Name)
+		if err != nil {
+			return nil, err
+		}
+		n := &Node{Object: object, Relation: r.Name, Subjects: subjects}
+		for _, s := range subjects {
+			if s.Relation == "" || !e.Schema.has(s.Type, s.Relation) {
+				continue
+			}
+			child, err := e.expand(ctx, s.Object, s.Relation, depth+1, visiting)
+			if err != nil {
+				return nil, err
+			}
+			n.Children = append(n.Children, child)
+		}
+		if len(n.Children) > 0 {
+			n.Operation = "union"
+		}
+		return n, nil
+	case ComputedUserset:
+		return e.expand(ctx, object, x.Relation, depth+1, visiting)
+	case TupleToUserset:
+		subjects, err := e.Reader.subjects(ctx, object, x.Tupleset)
+		if err != nil {
+			return nil, err
+		}
+		n := &Node{Object: object, Relation: r.Name, Operation: "union"}
+		for _, s := range subjects {
+			if !e.Schema.has(s.Type, x.Computed) {
+				continue
+			}
+			child, err := e.expand(ctx, s.Object, x.Computed, depth+1, visiting)
+			if err != nil {
+				return nil, err
+			}
+			n.Children = append(n.Children, child)
+		}
+		return n, nil
+	case Union:
+		return e.expandChildren(ctx, object, r, "union", x.Children, depth, visiting)
+	case Intersection:
+		return e.expandChildren(ctx, object, r, "intersection", x.Children, depth, visiting)
+	case Exclusion:
+		return e.expandChildren(ctx, object, r, "exclusion", []Expr{x.Base, x.Subtract}, depth, visiting)
+	}
+	return nil, fmt.Errorf("unsupported rewrite %T", x)
+}
+
+func (e *Engine) expandChildren(ctx context.Context, object Object, r *Relation, op string, children []Expr, depth int, visiting map[string]bool) (*Node, error) {
+	n := &Node{Object: object, Relation: r.Name, Operation: op}
+	for _, c := range children {
+		child, err := e.expandExpr(ctx, object, r, c, depth, visiting)
+		if err != nil {
+			return nil, err
+		}
+		n.Children = append(n.Children, child)
+	}
+	return n, nil
+}
@@ -0,0 +1,421 @@
+package rebac
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Schema is a parsed namespace configuration. Each namespace declares the
+// relations its objects can have, and each relation a rewrite saying how it is
+// computed:
+//
+//	// a comment
+//	namespace folder {
+//	  relation owner
+//	  relation viewer = this | owner
+//	}
+//	namespace document {
+//	  relation parent
+//	  relation owner
+//	  relation editor = this | owner
+//	  relation viewer = this | editor | parent->viewer
+//	  relation banned
+//	  relation can_view = viewer - banned
+//	}
+//
+// A relation without a rewrite holds exactly its stored tuples ("this").
+// "owner" is a computed userset, the object's owner relation. "parent->viewer"
+// is a tuple-to-userset: the viewers of every object the object's parent
+// tuples point at. Terms combine with | (union), & (intersection) and -
+// (exclusion); mixing operators needs parentheses.
+type Schema struct {
+	Namespaces map[string]*Namespace
+}
+
+type Namespace struct {
+	Name      string
+	Relations map[string]*Relation
+}
+
+type Relation struct {
+	Name    string
+	Rewrite Expr
+}
+
+// Expr is a userset rewrite
+type Expr interface {
+	fmt.Stringer
+}
+
+type (
+	// This is the relation's own stored tuples
+	This struct{}
+	// ComputedUserset is another relation of the same object
+	ComputedUserset struct {
+		Relation string
+	}
+	// TupleToUserset follows the object's Tupleset tuples and takes Computed
+	// on each object they point at
+	TupleToUserset struct {
+		Tupleset string
+		Computed string
+	}
+	Union        struct{ Children []Expr }
+	Intersection struct{ Children []Expr }
+	Exclusion    struct{ Base, Subtract Expr }
+)
+
+func (This) String() string              { return "this" }
+func (e ComputedUserset) String() string { return e.Relation }
+func (e TupleToUserset) String() string  { return e.Tupleset + "->" + e.Computed }
+func (e Union) String() string           { return joinExprs(e.Children, " | ") }
+func (e Intersection) String() string    { return joinExprs(e.Children, " & ") }
+func (e Exclusion) String() string       { return "(" + e.Base.String() + " - " + e.Subtract.String() + ")" }
+
+func joinExprs(children []Expr, sep string) string {
+	parts := make([]string, len(children))
+	for i, c := range children {
+		parts[i] = c.String()
+	}
+	return "(" + strings.Join(parts, sep) + ")"
+}
+
+// Relation returns the named relation of a namespace
+func (s *Schema) Relation(namespace, relation string) (*Relation, error) {
+	ns, ok := s.Namespaces[namespace]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownNamespace, namespace)
+	}
+	rel, ok := ns.Relations[relation]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s#%s", ErrUnknownRelation, namespace, relation)
+	}
+	return rel, nil
+}
+
+// has reports whether a namespace declares a relation. Tuples written under an
+// earlier schema may point at relations that no longer exist; evaluation
+// skips them.
+func (s *Schema) has(namespace, relation string) bool {
+	ns, ok := s.Namespaces[namespace]
+	return ok && ns.Relations[relation] != nil
+}
+
+// Writable reports whether the relation stores tuples, that is whether its
+// rewrite reaches "this" outside the subtracted side of an exclusion
+func (r *Relation) Writable() bool {
+	var walk func(e Expr) bool
+	walk = func(e Expr) bool {
+		switch e := e.(type) {
+		case This:
+			return true
+		case Union:
+			return slices.ContainsFunc(e.Children, walk)
+		case Intersection:
+			return slices.ContainsFunc(e.Children, walk)
+		case Exclusion:
+			return walk(e.Base)
+		}
+		return false
+	}
+	return walk(r.Rewrite)
+}
+
+var (
+	ErrUnknownNamespace = errors.New("unknown namespace")
+	ErrUnknownRelation  = errors.New("unknown relation")
+)
+
+// ParseSchema parses and validates a namespace configuration
+func ParseSchema(src string) (*Schema, error) {
+	p := &parser{tokens: tokenize(src)}
+	s := &Schema{Namespaces: map[string]*Namespace{}}
+	for !p.done() {
+		ns, err := p.namespace()
+		if err != nil {
+			return nil, err
+		}
+		if _, dup := s.Namespaces[ns.Name]; dup {
+			return nil, fmt.Errorf("line %d: namespace %q declared twice", p.line(), ns.Name)
+		}
+		s.Namespaces[ns.Name] = ns
+	}
+	if len(s.Namespaces) == 0 {
+		return nil, errors.New("schema declares no namespaces")
+	}
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// validate checks that every rewrite refers to declared relations
+func (s *Schema) validate() error {
+	declared := map[string]bool{}
+	for _, ns := range s.Namespaces {
+		for name := range ns.Relations {
+			declared[name] = true
+		}
+	}
+	for _, ns := range s.Namespaces {
+		for _, rel := range ns.Relations {
+			var check func(e Expr) error
+			check = func(e Expr) error {
+				switch e := e.(type) {
+				case ComputedUserset:
+					if _, ok := ns.Relations[e.Relation]; !ok {
+						return fmt.Errorf("%s#%s: unknown relation %q", ns.Name, rel.Name, e.Relation)
+					}
+				case TupleToUserset:
+					ts, ok := ns.Relations[e.Tupleset]
+					if !ok {
+						return fmt.Errorf("%s#%s: unknown relation %q", ns.Name, rel.Name, e.Tupleset)
+					}
+					if _, direct := ts.Rewrite.(This); !direct {
+						return fmt.Errorf("%s#%s: %q must be a plain relation to be followed", ns.Name, rel.Name, e.Tupleset)
+					}
+					if !declared[e.Computed] {
+						return fmt.Errorf("%s#%s: no namespace declares relation %q", ns.Name, rel.Name, e.Computed)
+					}
+				case Union:
+					for _, c := range e.Children {
+						if err := check(c); err != nil {
+							return err
+						}
+					}
+				case Intersection:
+					for _, c := range e.Children {
+						if err := check(c); err != nil {
+							return err
+						}
+					}
+				case Exclusion:
+					if err := check(e.Base); err != nil {
+						return err
+					}
+					return check(e.Subtract)
+				}
+				return nil
+			}
+			if err := check(rel.Rewrite); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type token struct {
+	text string
+	line int
+}
+
+func tokenize(src string) []token {
+	var tokens []token
+	for n, line := range strings.Split(src, "\n") {
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = line[:i]
+		}
+		for i := 0; i < len(line); {
+			c := rune(line[i])
+			switch {
+			case unicode.IsSpace(c):
+				i++
+			case strings.HasPrefix(line[i:], "->"):
+				tokens = append(tokens, token{"->", n + 1})
+				i += 2
+			case isIdentChar(c):
+				j := i
+				for j < len(line) && isIdentChar(rune(line[j])) {
+					j++
+				}
+				tokens = append(tokens, token{line[i:j], n + 1})
+				i = j
+			default:
+				tokens = append(tokens, token{string(c), n + 1})
+				i++
+			}
+		}
+	}
+	return tokens
+}
+
+func isIdentChar(c rune) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+func isIdent(s string) bool {
+	if s == "" || (s[0] >= '0' && s[0] <= '9') {
+		return false
+	}
+	for _, c := range s {
+		if !isIdentChar(c) {
+			return false
+		}
+	}
+	return true
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) done() bool { return p.pos >= len(p.tokens) }
+
+func (p *parser) peek() string {
+	if p.done() {
+		return ""
+	}
+	return p.tokens[p.pos].text
+}
+
+func (p *parser) line() int {
+	if p.done() {
+		if len(p.tokens) == 0 {
+			return 1
+		}
+		return p.tokens[len(p.tokens)-1].line
+	}
+	return p.tokens[p.pos].line
+}
+
+func (p *parser) next() string {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+func (p *parser) expect(want string) error {
+	if got := p.peek(); got != want {
+		return fmt.Errorf("line %d: expected %q, found %q", p.line(), want, got)
+	}
+	p.pos++
+	return nil
+}
+
+func (p *parser) ident() (string, error) {
+	t := p.peek()
+	if !isIdent(t) || t == "this" || t == "namespace" || t == "relation" {
+		return "", fmt.Errorf("line %d: expected a name, found %q", p.line(), t)
+	}
+	p.pos++
+	return t, nil
+}
+
+func (p *parser) namespace() (*Namespace, error) {
+	if err := p.expect("namespace"); err != nil {
+		return nil, err
+	}
+	name, err := p.ident()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	ns := &Namespace{Name: name, Relations: map[string]*Relation{}}
+	for p.peek() != "}" {
+		if p.done() {
+			return nil, fmt.Errorf("line %d: namespace %q is not closed", p.line(), name)
+		}
+		rel, err := p.relation()
+		if err != nil {
+			return nil, err
+		}
+		if _, dup := ns.Relations[rel.Name]; dup {
+			return nil, fmt.Errorf("line %d: relation %s#%s declared twice", p.line(), name, rel.Name)
+		}
+		ns.Relations[rel.Name] = rel
+	}
+	p.pos++
+	return ns, nil
+}
+
+func (p *parser) relation() (*Relation, error) {
+	if err := p.expect("relation"); err != nil {
+		return nil, err
+	}
+	name, err := p.ident()
+	if err != nil {
+		return nil, err
+	}
+	rel := &Relation{Name: name, Rewrite: This{}}
+	if p.peek() == "=" {
+		p.pos++
+		if rel.Rewrite, err = p.expr(); err != nil {
+			return nil, err
+		}
+	}
+	return rel, nil
+}
+
+// expr parses terms joined by a single kind of operator
+func (p *parser) expr() (Expr, error) {
+	first, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+	op := p.peek()
+	if op != "|" && op != "&" && op != "-" {
+		return first, nil
+	}
+	terms := []Expr{first}
+	for p.peek() == op {
+		p.pos++
+		t, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, t)
+	}
+	if next := p.peek(); next == "|" || next == "&" || next == "-" {
+		return nil, fmt.Errorf("line %d: use parentheses to combine %q and %q", p.line(), op, next)
+	}
+	switch op {
+	case "|":
+		return Union{Children: terms}, nil
+	case "&":
+		return Intersection{Children: terms}, nil
+	}
+	// a - b - c subtracts both b and c from a
+	e := terms[0]
+	for _, t := range terms[1:] {
+		e = Exclusion{Base: e, Subtract: t}
+	}
+	return e, nil
+}
+
+func (p *parser) term() (Expr, error) {
+	switch p.peek() {
+	case "(":
+		p.pos++
+		e, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return e, nil
+	case "this":
+		p.pos++
+		return This{}, nil
+	}
+	name, err := p.ident()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek() != "->" {
+		return ComputedUserset{Relation: name}, nil
+	}
+	p.pos++
+	computed, err := p.ident()
+	if err != nil {
+		return nil, err
+	}
+	return TupleToUserset{Tupleset: name, Computed: computed}, nil
+}
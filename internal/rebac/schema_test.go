@@ -0,0 +1,139 @@
+package rebac
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseSchema(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		// want maps namespace#relation to its parsed rewrite
+		want    map[string]string
+		wantErr string
+	}{
+		{
+			name: "plain relation",
+			src:  "namespace doc { relation owner }",
+			want: map[string]string{"doc#owner": "this"},
+		},
+		{
+			name: "union with computed userset and tuple-to-userset",
+			src: `// folders hold documents
+				namespace folder { relation viewer }
+				namespace doc {
+				  relation parent
+				  relation owner
+				  relation viewer = this | owner | parent->viewer
+				}`,
+			want: map[string]string{
+				"folder#viewer": "this",
+				"doc#viewer":    "(this | owner | parent->viewer)",
+			},
+		},
+		{
+			name: "intersection",
+			src:  "namespace doc { relation editor relation approver relation publisher = editor & approver }",
+			want: map[string]string{"doc#publisher": "(editor & approver)"},
+		},
+		{
+			name: "exclusion chains left to right",
+			src:  "namespace doc { relation viewer relation banned relation muted relation reader = viewer - banned - muted }",
+			want: map[string]string{"doc#reader": "((viewer - banned) - muted)"},
+		},
+		{
+			name: "parentheses mix operators",
+			src:  "namespace doc { relation owner relation viewer relation banned relation reader = (this | viewer | owner) - banned }",
+			want: map[string]string{"doc#reader": "((this | viewer | owner) - banned)"},
+		},
+		{
+			name:    "mixed operators without parentheses",
+			src:     "namespace doc { relation a relation b relation c = a | b & a }",
+			wantErr: `use parentheses to combine "|" and "&"`,
+		},
+		{
+			name:    "unknown computed relation",
+			src:     "namespace doc { relation viewer = owner }",
+			wantErr: `doc#viewer: unknown relation "owner"`,
+		},
+		{
+			name:    "tupleset must store tuples",
+			src:     "namespace doc { relation owner relation parent = owner relation viewer = parent->owner }",
+			wantErr: `"parent" must be a plain relation to be followed`,
+		},
+		{
+			name:    "tuple-to-userset to an undeclared relation",
+			src:     "namespace doc { relation parent relation viewer = parent->reader }",
+			wantErr: `no namespace declares relation "reader"`,
+		},
+		{
+			name:    "duplicate relation",
+			src:     "namespace doc { relation owner relation owner }",
+			wantErr: "relation doc#owner declared twice",
+		},
+		{
+			name:    "duplicate namespace",
+			src:     "namespace doc {}\nnamespace doc {}",
+			wantErr: `line 2: namespace "doc" declared twice`,
+		},
+		{
+			name:    "unclosed namespace",
+			src:     "namespace doc {\n  relation owner",
+			wantErr: `line 2: namespace "doc" is not closed`,
+		},
+		{
+			name:    "keyword as a name",
+			src:     "namespace doc { relation this }",
+			wantErr: `expected a name, found "this"`,
+		},
+		{
+			name:    "empty",
+			src:     "// nothing here",
+			wantErr: "schema declares no namespaces",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := ParseSchema(tt.src)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseSchema() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSchema() error = %v", err)
+			}
+			for key, want := range tt.want {
+				ns, rel, _ := strings.Cut(key, "#")
+				r, err := s.Relation(ns, rel)
+				if err != nil {
+					t.Fatalf("Relation(%s): %v", key, err)
+				}
+				if got := r.Rewrite.String(); got != want {
+					t.Errorf("%s = %s, want %s", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestWritable(t *testing.T) {
+	s, err := ParseSchema(`namespace doc {
+		relation owner
+		relation editor = this | owner
+		relation viewer = editor
+		relation banned
+		relation reader = viewer - this
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]bool{"owner": true, "editor": true, "viewer": false, "reader": false}
+	for name, writable := range want {
+		if got := s.Namespaces["doc"].Relations[name].Writable(); got != writable {
+			t.Errorf("%s.Writable() = %v, want %v", name, got, writable)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package rebac
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidToken = errors.New("invalid consistency token")
+
+const tokenPrefix = "v1:"
+
+// EncodeToken returns the opaque consistency token for a revision. Clients
+// hand it back to read at, or after, the point of a write.
+func EncodeToken(rev int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(tokenPrefix + strconv.FormatInt(rev, 10)))
+}
+
+// DecodeToken returns the revision a token was issued for
+func DecodeToken(token string) (int64, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, ErrInvalidToken
+	}
+	s, ok := strings.CutPrefix(string(b), tokenPrefix)
+	if !ok {
+		return 0, ErrInvalidToken
+	}
+	rev, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || rev < 0 {
+		return 0, ErrInvalidToken
+	}
+	return rev, nil
+}
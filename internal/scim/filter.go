@@ -0,0 +1,46 @@
+package scim
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var errInvalidFilter = errors.New("only filters of the form `attribute eq \"value\"` are supported")
+
+var filterPattern = regexp.MustCompile(`^\s*([A-Za-z][\w.:]*)\s+(?i:eq)\s+(.+?)\s*$`)
+
+// filter is a single equality comparison, the subset of RFC 7644 filtering
+// identity providers use to look up resources before provisioning them
+type filter struct {
+	Attr  string
+	Value string
+}
+
+// parseFilter parses a SCIM filter expression; an empty expression yields nil
+func parseFilter(expr string) (*filter, error) {
+	if strings.TrimSpace(expr) == "" {
+		return nil, nil
+	}
+	m := filterPattern.FindStringSubmatch(expr)
+	if m == nil {
+		return nil, errInvalidFilter
+	}
+
+	value := m[2]
+	if strings.HasPrefix(value, `"`) {
+		unquoted, err := strconv.Unquote(value)
+		if err != nil {
+			return nil, errInvalidFilter
+		}
+		value = unquoted
+	} else if value != "true" && value != "false" {
+		return nil, errInvalidFilter
+	}
+	return &filter{Attr: strings.ToLower(m[1]), Value: value}, nil
+}
+
+// memberPathPattern matches the path Azure AD and Okta use to remove a single
+// member: members[value eq "<id>"]
+var memberPathPattern = regexp.MustCompile(`^(?i:members)\[\s*(?i:value)\s+(?i:eq)\s+"([^"]*)"\s*\]$`)
@@ -0,0 +1,402 @@
+package scim
+
+import (
+	"auth-haven/internal/auth"
+	"auth-haven/internal/domain/role"
+	"auth-haven/internal/domain/user"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+var errInvalidMember = errors.New("members must reference users of this tenant")
+
+func (h *Handler) toGroup(rl *role.Role, members []string) *Group {
+	id := strconv.FormatInt(rl.ID, 10)
+	g := &Group{
+		Schemas:     []string{SchemaGroup},
+		ID:          id,
+		DisplayName: rl.Name,
+		Members:     []Member{},
+		Meta: &Meta{
+			ResourceType: "Group",
+			Created:      &rl.CreatedAt,
+			Location:     h.BaseURL + "/Groups/" + id,
+		},
+	}
+	if rl.ExternalID != nil {
+		g.ExternalID = *rl.ExternalID
+	}
+	for _, m := range members {
+		g.Members = append(g.Members, Member{Value: m, Ref: h.BaseURL + "/Users/" + m})
+	}
+	return g
+}
+
+// findRole loads a role, hiding roles of other tenants
+func (h *Handler) findRole(r *http.Request, tenantID string) (*role.Role, error) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		return nil, role.ErrRoleNotFound
+	}
+	rl, err := h.RoleRepo.FindById(r.Context(), id)
+	if err != nil {
+		return nil, err
+	}
+	if rl.TenantID != tenantID {
+		return nil, role.ErrRoleNotFound
+	}
+	return rl, nil
+}
+
+// findWritableRole loads a role the request may change. Roles granting admin
+// or a restricted permission are read-only to provisioning clients, as the
+// token's issuer may not hold every permission they grant. It writes the
+// error response and returns nil on failure.
+func (h *Handler) findWritableRole(w http.ResponseWriter, r *http.Request, tenantID string) *role.Role {
+	rl, err := h.findRole(r, tenantID)
+	if errors.Is(err, role.ErrRoleNotFound) {
+		writeError(w, http.StatusNotFound, "", "group not found")
+		return nil
+	}
+	if err != nil {
+		internalError(w, r, err)
+		return nil
+	}
+	if auth.Privileged(rl.Permissions) {
+		writeError(w, http.StatusForbidden, "", "groups granting administrative permissions cannot be changed through SCIM")
+		return nil
+	}
+	return rl
+}
+
+// checkMembers verifies every member ID names a user of the tenant
+func (h *Handler) checkMembers(r *http.Request, tenantID string, ids []string) error {
+	for _, id := range ids {
+		if _, err := uuid.Parse(id); err != nil {
+			return errInvalidMember
+		}
+		u, err := h.UserRepo.FindById(r.Context(), id)
+		if errors.Is(err, user.ErrUserNotFound) {
+			return errInvalidMember
+		}
+		if err != nil {
+			return err
+		}
+		if u.TenantID != tenantID {
+			return errInvalidMember
+		}
+	}
+	return nil
+}
+
+// memberIDs reads the value of a members attribute
+func memberIDs(v any) []string {
+	list, _ := v.([]any)
+	ids := []string{}
+	for _, item := range list {
+		m, _ := item.(map[string]any)
+		if id := stringValue(m["value"]); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (h *Handler) writeGroup(w http.ResponseWriter, r *http.Request, code int, rl *role.Role) {
+	members, err := h.RoleRepo.ListMembers(r.Context(), rl.ID)
+	if err != nil {
+		internalError(w, r, err)
+		return
+	}
+	writeJSON(w, code, h.toGroup(rl, members))
+}
+
+// writeMemberError reports a failure to validate or store members
+func writeMemberError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, errInvalidMember) {
+		writeError(w, http.StatusBadRequest, "invalidValue", err.Error())
+		return
+	}
+	internalError(w, r, err)
+}
+
+func (h *Handler) listGroups(w http.ResponseWriter, r *http.Request, tenantID string) {
+	startIndex, count, err := page(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalidValue", err.Error())
+		return
+	}
+	f, err := parseFilter(r.URL.Query().Get("filter"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalidFilter", err.Error())
+		return
+	}
+
+	lf := role.ListFilter{Offset: startIndex - 1, Limit: count}
+	if f != nil {
+		switch f.Attr {
+		case "displayname":
+			lf.Name = f.Value
+		case "externalid":
+			lf.ExternalID = f.Value
+		default:
+			writeError(w, http.StatusBadRequest, "invalidFilter", "unsupported filter attribute "+f.Attr)
+			return
+		}
+	}
+
+	roles, total, err := h.RoleRepo.ListByTenant(r.Context(), tenantID, lf)
+	if err != nil {
+		internalError(w, r, err)
+		return
+	}
+
+	// Providers ask for excludedAttributes=members to avoid loading large groups
+	withMembers := !slices.Contains(strings.Split(strings.ToLower(r.URL.Query().Get("excludedAttributes")), ","), "members")
+	resources := []any{}
+	for _, rl := range roles[:min(len(roles), count)] {
+		var members []string
+		if withMembers {
+			if members, err = h.RoleRepo.ListMembers(r.Context(), rl.ID); err != nil {
+				internalError(w, r, err)
+				return
+			}
+		}
+		resources = append(resources, h.toGroup(rl, members))
+	}
+	writeJSON(w, http.StatusOK, listResponse(resources, total, startIndex))
+}
+
+func (h *Handler) getGroup(w http.ResponseWriter, r *http.Request, tenantID string) {
+	rl, err := h.findRole(r, tenantID)
+	if errors.Is(err, role.ErrRoleNotFound) {
+		writeError(w, http.StatusNotFound, "", "group not found")
+		return
+	}
+	if err != nil {
+		internalError(w, r, err)
+		return
+	}
+	h.writeGroup(w, r, http.StatusOK, rl)
+}
+
+func (h *Handler) createGroup(w http.ResponseWriter, r *http.Request, tenantID string) {
+	var g Group
+	if err := decode(r, &g); err != nil {
+		writeError(w, http.StatusBadRequest, "invalidSyntax", err.Error())
+		return
+	}
+	if g.DisplayName == "" {
+		writeError(w, http.StatusBadRequest, "invalidValue", "displayName is required")
+		return
+	}
+	ids := []string{}
+	for _, m := range g.Members {
+		ids = append(ids, m.Value)
+	}
+	if err := h.checkMembers(r, tenantID, ids); err != nil {
+		writeMemberError(w, r, err)
+		return
+	}
+
+	rl := &role.Role{TenantID: tenantID, Name: g.DisplayName}
+	if g.ExternalID != "" {
+		rl.ExternalID = &g.ExternalID
+	}
+	created, err := h.RoleRepo.Create(r.Context(), rl)
+	if errors.Is(err, role.ErrRoleAlreadyExists) {
+		writeError(w, http.StatusConflict, "uniqueness", "displayName is already in use")
+		return
+	}
+	if err != nil {
+		internalError(w, r, err)
+		return
+	}
+	if err := h.RoleRepo.SetMembers(r.Context(), created.ID, ids); err != nil {
+		internalError(w, r, err)
+		return
+	}
+	h.writeGroup(w, r, http.StatusCreated, created)
+}
+
+func (h *Handler) replaceGroup(w http.ResponseWriter, r *http.Request, tenantID string) {
+	rl := h.findWritableRole(w, r, tenantID)
+	if rl == nil {
+		return
+	}
+
+	var g Group
+	if err := decode(r, &g); err != nil {
+		writeError(w, http.StatusBadRequest, "invalidSyntax", err.Error())
+		return
+	}
+	if g.DisplayName == "" {
+		writeError(w, http.StatusBadRequest, "invalidValue", "displayName is required")
+		return
+	}
+	ids := []string{}
+	for _, m := range g.Members {
+		ids = append(ids, m.Value)
+	}
+
+	if err := h.checkMembers(r, tenantID, ids); err != nil {
+		writeMemberError(w, r, err)
+		return
+	}
+	err := h.RoleRepo.Replace(r.Context(), rl.ID, g.DisplayName, g.ExternalID, ids)
+	if errors.Is(err, role.ErrRoleAlreadyExists) {
+		writeError(w, http.StatusConflict, "uniqueness", "displayName is already in use")
+		return
+	}
+	if err != nil {
+		internalError(w, r, err)
+		return
+	}
+	h.reloadGroup(w, r, rl.ID)
+}
+
+func (h *Handler) patchGroup(w http.ResponseWriter, r *http.Request, tenantID string) {
+	rl := h.findWritableRole(w, r, tenantID)
+	if rl == nil {
+		return
+	}
+
+	var req PatchRequest
+	if err := decode(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalidSyntax", err.Error())
+		return
+	}
+
+	update := &role.UpdateRole{}
+	for _, op := range req.Operations {
+		opName := strings.ToLower(op.Op)
+		attrs := map[string]any{}
+		switch {
+		case opName == "remove":
+			if err := h.removeMembers(r, rl, op); err != nil {
+				writeError(w, http.StatusBadRequest, "noTarget", err.Error())
+				return
+			}
+			continue
+		case opName != "add" && opName != "replace":
+			writeError(w, http.StatusBadRequest, "invalidSyntax", "unsupported op "+op.Op)
+			return
+		case op.Path == "":
+			m, ok := op.Value.(map[string]any)
+			if !ok {
+				writeError(w, http.StatusBadRequest, "invalidValue", "value must be an object when path is omitted")
+				return
+			}
+			attrs = m
+		default:
+			attrs[op.Path] = op.Value
+		}
+
+		for attr, v := range attrs {
+			switch strings.ToLower(attr) {
+			case "displayname":
+				s := stringValue(v)
+				if s == "" {
+					writeError(w, http.StatusBadRequest, "invalidValue", "displayName must be a non-empty string")
+					return
+				}
+				update.Name = &s
+			case "externalid":
+				s := stringValue(v)
+				update.ExternalID = &s
+			case "members":
+				ids := memberIDs(v)
+				if err := h.checkMembers(r, tenantID, ids); err != nil {
+					writeMemberError(w, r, err)
+					return
+				}
+				if err := h.addMembers(r, rl, ids, opName == "replace"); err != nil {
+					writeMemberError(w, r, err)
+					return
+				}
+			}
+		}
+	}
+
+	if (update.Name != nil || update.ExternalID != nil) && !h.updateRole(w, r, rl, update) {
+		return
+	}
+	h.reloadGroup(w, r, rl.ID)
+}
+
+func (h *Handler) addMembers(r *http.Request, rl *role.Role, ids []string, replace bool) error {
+	if replace {
+		return h.RoleRepo.SetMembers(r.Context(), rl.ID, ids)
+	}
+	for _, id := range ids {
+		if err := h.RoleRepo.AddMember(r.Context(), rl.ID, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeMembers handles the three remove forms providers send: a filtered
+// path naming one member, a members path with a value list, and a bare
+// members path clearing the group
+func (h *Handler) removeMembers(r *http.Request, rl *role.Role, op PatchOperation) error {
+	var ids []string
+	switch m := memberPathPattern.FindStringSubmatch(op.Path); {
+	case m != nil:
+		ids = []string{m[1]}
+	case !strings.EqualFold(op.Path, "members"):
+		return fmt.Errorf("cannot remove %q", op.Path)
+	case op.Value == nil:
+		return h.RoleRepo.SetMembers(r.Context(), rl.ID, []string{})
+	default:
+		ids = memberIDs(op.Value)
+	}
+	for _, id := range ids {
+		if err := h.RoleRepo.RemoveMember(r.Context(), rl.ID, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateRole writes name and externalId changes. It reports whether the
+// caller should continue.
+func (h *Handler) updateRole(w http.ResponseWriter, r *http.Request, rl *role.Role, update *role.UpdateRole) bool {
+	err := h.RoleRepo.Update(r.Context(), rl.ID, update)
+	if errors.Is(err, role.ErrRoleAlreadyExists) {
+		writeError(w, http.StatusConflict, "uniqueness", "displayName is already in use")
+		return false
+	}
+	if err != nil {
+		internalError(w, r, err)
+		return false
+	}
+	return true
+}
+
+func (h *Handler) reloadGroup(w http.ResponseWriter, r *http.Request, roleID int64) {
+	rl, err := h.RoleRepo.FindById(r.Context(), roleID)
+	if err != nil {
+		internalError(w, r, err)
+		return
+	}
+	h.writeGroup(w, r, http.StatusOK, rl)
+}
+
+func (h *Handler) deleteGroup(w http.ResponseWriter, r *http.Request, tenantID string) {
+	rl := h.findWritableRole(w, r, tenantID)
+	if rl == nil {
+		return
+	}
+	if err := h.RoleRepo.Delete(r.Context(), rl.ID); err != nil {
+		internalError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
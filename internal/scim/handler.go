@@ -0,0 +1,150 @@
+package scim
+
+import (
+	"auth-haven/internal/domain/provisioning"
+	refreshtoken "auth-haven/internal/domain/refresh_token"
+	"auth-haven/internal/domain/role"
+	"auth-haven/internal/domain/user"
+	"auth-haven/internal/utils"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultCount = 100
+	maxCount     = 200
+)
+
+// Handler serves the SCIM 2.0 /Users and /Groups endpoints. Every request is
+// authenticated with a tenant's provisioning token and confined to that tenant.
+type Handler struct {
+	UserRepo user.UserRepository
+	// RoleRepo's ListByUser must include roles held through groups, so that
+	// privileged users are recognised however they hold their role
+	RoleRepo         role.RoleRepository
+	ProvisioningRepo provisioning.ProvisioningRepository
+	RefreshTokenRepo refreshtoken.RefreshTokenRepository
+
+	// BaseURL is the public URL the endpoints are mounted under, used in
+	// resource locations
+	BaseURL string
+}
+
+type tenantHandler func(w http.ResponseWriter, r *http.Request, tenantID string)
+
+// Register mounts the endpoints on mux under /scim/v2
+func (h *Handler) Register(mux *http.ServeMux) {
+	mux.HandleFunc("GET /scim/v2/Users", h.authenticate(h.listUsers))
+	mux.HandleFunc("POST /scim/v2/Users", h.authenticate(h.createUser))
+	mux.HandleFunc("GET /scim/v2/Users/{id}", h.authenticate(h.getUser))
+	mux.HandleFunc("PUT /scim/v2/Users/{id}", h.authenticate(h.replaceUser))
+	mux.HandleFunc("PATCH /scim/v2/Users/{id}", h.authenticate(h.patchUser))
+	mux.HandleFunc("DELETE /scim/v2/Users/{id}", h.authenticate(h.deleteUser))
+
+	mux.HandleFunc("GET /scim/v2/Groups", h.authenticate(h.listGroups))
+	mux.HandleFunc("POST /scim/v2/Groups", h.authenticate(h.createGroup))
+	mux.HandleFunc("GET /scim/v2/Groups/{id}", h.authenticate(h.getGroup))
+	mux.HandleFunc("PUT /scim/v2/Groups/{id}", h.authenticate(h.replaceGroup))
+	mux.HandleFunc("PATCH /scim/v2/Groups/{id}", h.authenticate(h.patchGroup))
+	mux.HandleFunc("DELETE /scim/v2/Groups/{id}", h.authenticate(h.deleteGroup))
+}
+
+// authenticate resolves the bearer token to its tenant
+func (h *Handler) authenticate(next tenantHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		secret, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || secret == "" {
+			writeError(w, http.StatusUnauthorized, "", "bearer token required")
+			return
+		}
+		t, err := h.ProvisioningRepo.FindByHash(r.Context(), utils.HashToken(secret))
+		if errors.Is(err, provisioning.ErrTokenNotFound) || (err == nil && t.Revoked) {
+			writeError(w, http.StatusUnauthorized, "", "invalid token")
+			return
+		}
+		if err != nil {
+			internalError(w, r, err)
+			return
+		}
+		if err := h.ProvisioningRepo.TouchUsed(r.Context(), t.ID); err != nil {
+			slog.ErrorContext(r.Context(), "scim: failed to record token use", "token_id", t.ID, "error", err)
+		}
+		next(w, r, t.TenantID)
+	}
+}
+
+// page reads the 1-based startIndex and count query parameters
+func page(r *http.Request) (startIndex, count int, err error) {
+	startIndex, count = 1, defaultCount
+	q := r.URL.Query()
+	if v := q.Get("startIndex"); v != "" {
+		if startIndex, err = strconv.Atoi(v); err != nil {
+			return 0, 0, errors.New("startIndex must be an integer")
+		}
+		startIndex = max(startIndex, 1)
+	}
+	if v := q.Get("count"); v != "" {
+		if count, err = strconv.Atoi(v); err != nil {
+			return 0, 0, errors.New("count must be an integer")
+		}
+		count = min(max(count, 0), maxCount)
+	}
+	return startIndex, count, nil
+}
+
+func listResponse(resources []any, total, startIndex int) *ListResponse {
+	return &ListResponse{
+		Schemas:      []string{SchemaListResponse},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}
+}
+
+func decode(r *http.Request, v any) error {
+	return json.NewDecoder(http.MaxBytesReader(nil, r.Body, 1<<20)).Decode(v)
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, code int, scimType, detail string) {
+	writeJSON(w, code, &Error{
+		Schemas:  []string{SchemaError},
+		Status:   strconv.Itoa(code),
+		ScimType: scimType,
+		Detail:   detail,
+	})
+}
+
+func internalError(w http.ResponseWriter, r *http.Request, err error) {
+	slog.ErrorContext(r.Context(), "scim request failed", "http_method", r.Method, "path", r.URL.Path, "error", err)
+	writeError(w, http.StatusInternalServerError, "", "internal error")
+}
+
+// stringValue accepts a JSON string, treating anything else as empty
+func stringValue(v any) string {
+	s, _ := v.(string)
+	return s
+}
+
+// boolValue accepts a JSON boolean or the "True"/"False" strings some
+// providers send
+func boolValue(v any) (bool, bool) {
+	switch b := v.(type) {
+	case bool:
+		return b, true
+	case string:
+		parsed, err := strconv.ParseBool(strings.ToLower(b))
+		return parsed, err == nil
+	}
+	return false, false
+}
@@ -0,0 +1,268 @@
+package scim
+
+import (
+	"auth-haven/internal/domain/provisioning"
+	"auth-haven/internal/domain/role"
+	"auth-haven/internal/domain/user"
+	"auth-haven/internal/utils"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+const (
+	testTenant = "tenant-1"
+	testSecret = "scim-secret"
+
+	ownerID   = "00000000-0000-4000-8000-000000000001"
+	supportID = "00000000-0000-4000-8000-000000000002"
+	staffID   = "00000000-0000-4000-8000-000000000003"
+)
+
+// Roles of the test tenant: an owner role, a role carrying a restricted
+// permission and an ordinary one
+const (
+	ownerRoleID int64 = iota + 1
+	supportRoleID
+	staffRoleID
+)
+
+type fakeTokens struct {
+	provisioning.ProvisioningRepository
+}
+
+func (fakeTokens) FindByHash(_ context.Context, hash string) (*provisioning.Token, error) {
+	if hash != utils.HashToken(testSecret) {
+		return nil, provisioning.ErrTokenNotFound
+	}
+	return &provisioning.Token{ID: "token-1", TenantID: testTenant}, nil
+}
+
+func (fakeTokens) TouchUsed(context.Context, string) error { return nil }
+
+type fakeUsers struct {
+	user.UserRepository
+	users map[string]*user.User
+}
+
+// FindById fails on malformed IDs the way the uuid cast in Postgres does
+func (f *fakeUsers) FindById(_ context.Context, userID string) (*user.User, error) {
+	if _, err := uuid.Parse(userID); err != nil {
+		return nil, fmt.Errorf("UserRepo.FindById: %w", err)
+	}
+	u, ok := f.users[userID]
+	if !ok {
+		return nil, user.ErrUserNotFound
+	}
+	copied := *u
+	return &copied, nil
+}
+
+func (f *fakeUsers) Update(_ context.Context, userID string, update *user.UpdateUser) error {
+	u := f.users[userID]
+	if update.Email != nil {
+		u.Email = *update.Email
+	}
+	if update.FullName != nil {
+		u.FullName = *update.FullName
+	}
+	if update.Status != nil {
+		u.Status = *update.Status
+	}
+	return nil
+}
+
+func (f *fakeUsers) Delete(_ context.Context, userID string) error {
+	delete(f.users, userID)
+	return nil
+}
+
+type fakeRoles struct {
+	role.RoleRepository
+	roles   map[int64]*role.Role
+	members map[int64][]string
+}
+
+func (f *fakeRoles) FindById(_ context.Context, roleID int64) (*role.Role, error) {
+	rl, ok := f.roles[roleID]
+	if !ok {
+		return nil, role.ErrRoleNotFound
+	}
+	return rl, nil
+}
+
+func (f *fakeRoles) ListByUser(_ context.Context, userID string) ([]*role.Role, error) {
+	held := []*role.Role{}
+	for id, members := range f.members {
+		if slices.Contains(members, userID) {
+			held = append(held, f.roles[id])
+		}
+	}
+	return held, nil
+}
+
+func (f *fakeRoles) ListMembers(_ context.Context, roleID int64) ([]string, error) {
+	return f.members[roleID], nil
+}
+
+func (f *fakeRoles) SetMembers(_ context.Context, roleID int64, userIDs []string) error {
+	f.members[roleID] = slices.Clone(userIDs)
+	return nil
+}
+
+func (f *fakeRoles) Replace(_ context.Context, roleID int64, name, _ string, userIDs []string) error {
+	f.roles[roleID].Name = name
+	f.members[roleID] = slices.Clone(userIDs)
+	return nil
+}
+
+func (f *fakeRoles) AddMember(_ context.Context, roleID int64, userID string) error {
+	if !slices.Contains(f.members[roleID], userID) {
+		f.members[roleID] = append(f.members[roleID], userID)
+	}
+	return nil
+}
+
+func (f *fakeRoles) Update(_ context.Context, roleID int64, update *role.UpdateRole) error {
+	if update.Name != nil {
+		f.roles[roleID].Name = *update.Name
+	}
+	return nil
+}
+
+func (f *fakeRoles) Delete(_ context.Context, roleID int64) error {
+	delete(f.roles, roleID)
+	delete(f.members, roleID)
+	return nil
+}
+
+func newTestHandler(t *testing.T) (*httptest.Server, *fakeUsers, *fakeRoles) {
+	t.Helper()
+	users := &fakeUsers{users: map[string]*user.User{}}
+	for _, id := range []string{ownerID, supportID, staffID} {
+		users.users[id] = &user.User{ID: id, TenantID: testTenant, Email: id + "@example.com", Status: user.StatusActive}
+	}
+	roles := &fakeRoles{
+		roles: map[int64]*role.Role{
+			ownerRoleID:   {ID: ownerRoleID, TenantID: testTenant, Name: "owner", Permissions: []byte(`{"admin":true}`)},
+			supportRoleID: {ID: supportRoleID, TenantID: testTenant, Name: "support", Permissions: []byte(`{"users.impersonate":true}`)},
+			staffRoleID:   {ID: staffRoleID, TenantID: testTenant, Name: "staff", Permissions: []byte(`{"users.read":true}`)},
+		},
+		members: map[int64][]string{
+			ownerRoleID:   {ownerID},
+			supportRoleID: {supportID},
+			staffRoleID:   {staffID},
+		},
+	}
+	h := &Handler{UserRepo: users, RoleRepo: roles, ProvisioningRepo: fakeTokens{}, BaseURL: "https://id.example.com/scim/v2"}
+	mux := http.NewServeMux()
+	h.Register(mux)
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv, users, roles
+}
+
+func scimRequest(t *testing.T, srv *httptest.Server, method, path, body string) int {
+	t.Helper()
+	req, err := http.NewRequest(method, srv.URL+"/scim/v2"+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+testSecret)
+	req.Header.Set("Content-Type", contentType)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func groupPath(id int64) string { return "/Groups/" + strconv.FormatInt(id, 10) }
+
+func TestPrivilegedResourcesAreReadOnly(t *testing.T) {
+	const (
+		rename     = `{"displayName":"renamed","members":[]}`
+		addStaff   = `{"Operations":[{"op":"add","path":"members","value":[{"value":"` + staffID + `"}]}]}`
+		takeOver   = `{"userName":"attacker@example.com"}`
+		setEmail   = `{"Operations":[{"op":"replace","path":"userName","value":"attacker@example.com"}]}`
+		setName    = `{"Operations":[{"op":"replace","path":"displayName","value":"Renamed"}]}`
+		deactivate = `{"Operations":[{"op":"replace","path":"active","value":false}]}`
+	)
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		want   int
+	}{
+		{name: "add to owner group", method: http.MethodPatch, path: groupPath(ownerRoleID), body: addStaff, want: http.StatusForbidden},
+		{name: "add to restricted group", method: http.MethodPatch, path: groupPath(supportRoleID), body: addStaff, want: http.StatusForbidden},
+		{name: "replace owner group", method: http.MethodPut, path: groupPath(ownerRoleID), body: rename, want: http.StatusForbidden},
+		{name: "delete owner group", method: http.MethodDelete, path: groupPath(ownerRoleID), want: http.StatusForbidden},
+		{name: "replace owner", method: http.MethodPut, path: "/Users/" + ownerID, body: takeOver, want: http.StatusForbidden},
+		{name: "patch owner email", method: http.MethodPatch, path: "/Users/" + ownerID, body: setEmail, want: http.StatusForbidden},
+		{name: "deactivate owner", method: http.MethodPatch, path: "/Users/" + ownerID, body: deactivate, want: http.StatusForbidden},
+		{name: "delete owner", method: http.MethodDelete, path: "/Users/" + ownerID, want: http.StatusForbidden},
+		{name: "patch restricted user", method: http.MethodPatch, path: "/Users/" + supportID, body: setEmail, want: http.StatusForbidden},
+		{name: "add to ordinary group", method: http.MethodPatch, path: groupPath(staffRoleID), body: addStaff, want: http.StatusOK},
+		{name: "patch ordinary user", method: http.MethodPatch, path: "/Users/" + staffID, body: setName, want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, users, roles := newTestHandler(t)
+			if got := scimRequest(t, srv, tt.method, tt.path, tt.body); got != tt.want {
+				t.Fatalf("%s %s = %d, want %d", tt.method, tt.path, got, tt.want)
+			}
+			if tt.want != http.StatusForbidden {
+				return
+			}
+			if u := users.users[ownerID]; u == nil || u.Email != ownerID+"@example.com" || u.Status != user.StatusActive {
+				t.Errorf("owner account changed: %+v", u)
+			}
+			if got := roles.members[ownerRoleID]; !slices.Equal(got, []string{ownerID}) {
+				t.Errorf("owner group members = %v", got)
+			}
+			if got := roles.members[supportRoleID]; !slices.Equal(got, []string{supportID}) {
+				t.Errorf("support group members = %v", got)
+			}
+			if roles.roles[ownerRoleID] == nil || roles.roles[ownerRoleID].Name != "owner" {
+				t.Errorf("owner group changed: %+v", roles.roles[ownerRoleID])
+			}
+		})
+	}
+}
+
+func TestMalformedMemberIsInvalidValue(t *testing.T) {
+	const member = `[{"value":"not-a-uuid"}]`
+	tests := []struct {
+		name   string
+		method string
+		body   string
+	}{
+		{name: "replace", method: http.MethodPut, body: `{"displayName":"renamed","members":` + member + `}`},
+		{name: "patch add", method: http.MethodPatch, body: `{"Operations":[{"op":"add","path":"members","value":` + member + `}]}`},
+		{name: "patch replace", method: http.MethodPatch, body: `{"Operations":[{"op":"replace","path":"members","value":` + member + `}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, _, roles := newTestHandler(t)
+			if got := scimRequest(t, srv, tt.method, groupPath(staffRoleID), tt.body); got != http.StatusBadRequest {
+				t.Fatalf("%s = %d, want %d", tt.method, got, http.StatusBadRequest)
+			}
+			if got := roles.members[staffRoleID]; !slices.Equal(got, []string{staffID}) {
+				t.Errorf("staff group members = %v", got)
+			}
+			if name := roles.roles[staffRoleID].Name; name != "staff" {
+				t.Errorf("staff group renamed to %q", name)
+			}
+		})
+	}
+}
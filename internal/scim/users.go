@@ -0,0 +1,424 @@
+package scim
+
+import (
+	"auth-haven/internal/auth"
+	"auth-haven/internal/domain/user"
+	"auth-haven/internal/metrics"
+	"auth-haven/internal/utils"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// userChanges accumulates the effect of a PUT or PATCH on a user
+type userChanges struct {
+	update user.UpdateUser
+	active *bool
+}
+
+func (h *Handler) toUser(u *user.User) *User {
+	active := u.Status == user.StatusActive
+	su := &User{
+		Schemas:     []string{SchemaUser},
+		ID:          u.ID,
+		UserName:    u.Email,
+		DisplayName: u.FullName,
+		Emails:      []Email{{Value: u.Email, Type: "work", Primary: true}},
+		Active:      &active,
+		Meta: &Meta{
+			ResourceType: "User",
+			Created:      &u.CreatedAt,
+			LastModified: &u.UpdatedAt,
+			Location:     h.BaseURL + "/Users/" + u.ID,
+		},
+	}
+	if u.FullName != "" {
+		su.Name = &Name{Formatted: u.FullName}
+	}
+	if u.ExternalID != nil {
+		su.ExternalID = *u.ExternalID
+	}
+	return su
+}
+
+// fullName picks the best display name a provider supplied
+func (su *User) fullName() string {
+	if su.DisplayName != "" {
+		return su.DisplayName
+	}
+	if su.Name == nil {
+		return ""
+	}
+	if su.Name.Formatted != "" {
+		return su.Name.Formatted
+	}
+	return strings.TrimSpace(su.Name.GivenName + " " + su.Name.FamilyName)
+}
+
+// findUser loads a user, hiding users of other tenants
+func (h *Handler) findUser(r *http.Request, tenantID string) (*user.User, error) {
+	id := r.PathValue("id")
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, user.ErrUserNotFound
+	}
+	u, err := h.UserRepo.FindById(r.Context(), id)
+	if err != nil {
+		return nil, err
+	}
+	if u.TenantID != tenantID {
+		return nil, user.ErrUserNotFound
+	}
+	return u, nil
+}
+
+// findWritableUser loads a user the request may change. Users holding a role
+// that grants admin or a restricted permission, owners among them, are
+// read-only to provisioning clients: resetting their email or password would
+// hand over the account. It writes the error response and returns nil on
+// failure.
+func (h *Handler) findWritableUser(w http.ResponseWriter, r *http.Request, tenantID string) *user.User {
+	u, err := h.findUser(r, tenantID)
+	if errors.Is(err, user.ErrUserNotFound) {
+		writeError(w, http.StatusNotFound, "", "user not found")
+		return nil
+	}
+	if err != nil {
+		internalError(w, r, err)
+		return nil
+	}
+	held, err := h.RoleRepo.ListByUser(r.Context(), u.ID)
+	if err != nil {
+		internalError(w, r, err)
+		return nil
+	}
+	for _, rl := range held {
+		if auth.Privileged(rl.Permissions) {
+			writeError(w, http.StatusForbidden, "", "users holding administrative permissions cannot be changed through SCIM")
+			return nil
+		}
+	}
+	return u
+}
+
+func (h *Handler) listUsers(w http.ResponseWriter, r *http.Request, tenantID string) {
+	startIndex, count, err := page(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalidValue", err.Error())
+		return
+	}
+	f, err := parseFilter(r.URL.Query().Get("filter"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalidFilter", err.Error())
+		return
+	}
+
+	lf := user.ListFilter{Offset: startIndex - 1, Limit: count}
+	if f != nil {
+		switch f.Attr {
+		case "username", "emails", "emails.value":
+			lf.Email = f.Value
+		case "externalid":
+			lf.ExternalID = f.Value
+		case "active":
+			lf.Status = user.StatusDeactivated
+			if f.Value == "true" {
+				lf.Status = user.StatusActive
+			}
+		default:
+			writeError(w, http.StatusBadRequest, "invalidFilter", "unsupported filter attribute "+f.Attr)
+			return
+		}
+	}
+
+	users, total, err := h.UserRepo.List(r.Context(), tenantID, lf)
+	if err != nil {
+		internalError(w, r, err)
+		return
+	}
+	resources := []any{}
+	for _, u := range users[:min(len(users), count)] {
+		resources = append(resources, h.toUser(u))
+	}
+	writeJSON(w, http.StatusOK, listResponse(resources, total, startIndex))
+}
+
+func (h *Handler) getUser(w http.ResponseWriter, r *http.Request, tenantID string) {
+	u, err := h.findUser(r, tenantID)
+	if errors.Is(err, user.ErrUserNotFound) {
+		writeError(w, http.StatusNotFound, "", "user not found")
+		return
+	}
+	if err != nil {
+		internalError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, h.toUser(u))
+}
+
+func (h *Handler) createUser(w http.ResponseWriter, r *http.Request, tenantID string) {
+	var su User
+	if err := decode(r, &su); err != nil {
+		writeError(w, http.StatusBadRequest, "invalidSyntax", err.Error())
+		return
+	}
+	if su.UserName == "" {
+		writeError(w, http.StatusBadRequest, "invalidValue", "userName is required")
+		return
+	}
+
+	u := &user.User{
+		TenantID: tenantID,
+		Email:    su.UserName,
+		FullName: su.fullName(),
+		Status:   user.StatusActive,
+	}
+	if su.Active != nil && !*su.Active {
+		u.Status = user.StatusDeactivated
+	}
+	if su.ExternalID != "" {
+		u.ExternalID = &su.ExternalID
+	}
+	if su.Password != "" {
+		hashed, err := utils.Hash(r.Context(), su.Password)
+		if err != nil {
+			internalError(w, r, err)
+			return
+		}
+		u.PasswordHash = hashed
+	}
+
+	created, err := h.UserRepo.Create(r.Context(), u)
+	if errors.Is(err, user.ErrEmailAlreadyExists) {
+		writeError(w, http.StatusConflict, "uniqueness", "userName is already in use")
+		return
+	}
+	if err != nil {
+		internalError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, h.toUser(created))
+}
+
+func (h *Handler) replaceUser(w http.ResponseWriter, r *http.Request, tenantID string) {
+	u := h.findWritableUser(w, r, tenantID)
+	if u == nil {
+		return
+	}
+
+	var su User
+	if err := decode(r, &su); err != nil {
+		writeError(w, http.StatusBadRequest, "invalidSyntax", err.Error())
+		return
+	}
+	if su.UserName == "" {
+		writeError(w, http.StatusBadRequest, "invalidValue", "userName is required")
+		return
+	}
+
+	fullName := su.fullName()
+	c := &userChanges{
+		update: user.UpdateUser{
+			Email:      &su.UserName,
+			FullName:   &fullName,
+			ExternalID: &su.ExternalID,
+		},
+		active: su.Active,
+	}
+	if su.Password != "" {
+		if err := c.set(r.Context(), "password", su.Password); err != nil {
+			internalError(w, r, err)
+			return
+		}
+	}
+	h.applyUserChanges(w, r, u, c)
+}
+
+func (h *Handler) patchUser(w http.ResponseWriter, r *http.Request, tenantID string) {
+	u := h.findWritableUser(w, r, tenantID)
+	if u == nil {
+		return
+	}
+
+	var req PatchRequest
+	if err := decode(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalidSyntax", err.Error())
+		return
+	}
+
+	c := &userChanges{}
+	for _, op := range req.Operations {
+		switch strings.ToLower(op.Op) {
+		case "add", "replace":
+			if op.Path == "" {
+				attrs, ok := op.Value.(map[string]any)
+				if !ok {
+					writeError(w, http.StatusBadRequest, "invalidValue", "value must be an object when path is omitted")
+					return
+				}
+				for attr, v := range attrs {
+					if err := c.set(r.Context(), attr, v); err != nil {
+						writeError(w, http.StatusBadRequest, "invalidValue", err.Error())
+						return
+					}
+				}
+				continue
+			}
+			if err := c.set(r.Context(), op.Path, op.Value); err != nil {
+				writeError(w, http.StatusBadRequest, "invalidValue", err.Error())
+				return
+			}
+		case "remove":
+			if err := c.remove(op.Path); err != nil {
+				writeError(w, http.StatusBadRequest, "mutability", err.Error())
+				return
+			}
+		default:
+			writeError(w, http.StatusBadRequest, "invalidSyntax", "unsupported op "+op.Op)
+			return
+		}
+	}
+	h.applyUserChanges(w, r, u, c)
+}
+
+// set applies one attribute from a PUT body or PATCH operation. Attributes the
+// user model has no place for are ignored, as providers routinely send
+// enterprise attributes this service does not store.
+func (c *userChanges) set(ctx context.Context, attr string, v any) error {
+	a := strings.ToLower(attr)
+	switch {
+	case a == "active":
+		b, ok := boolValue(v)
+		if !ok {
+			return errors.New("active must be a boolean")
+		}
+		c.active = &b
+	case a == "username":
+		s := stringValue(v)
+		if s == "" {
+			return errors.New("userName must be a non-empty string")
+		}
+		c.update.Email = &s
+	case a == "displayname", a == "name.formatted":
+		s := stringValue(v)
+		c.update.FullName = &s
+	case a == "name":
+		m, _ := v.(map[string]any)
+		n := &User{Name: &Name{
+			Formatted:  stringValue(m["formatted"]),
+			GivenName:  stringValue(m["givenName"]),
+			FamilyName: stringValue(m["familyName"]),
+		}}
+		s := n.fullName()
+		c.update.FullName = &s
+	case a == "externalid":
+		s := stringValue(v)
+		c.update.ExternalID = &s
+	case a == "password":
+		s := stringValue(v)
+		if s == "" {
+			return errors.New("password must be a non-empty string")
+		}
+		hashed, err := utils.Hash(ctx, s)
+		if err != nil {
+			return err
+		}
+		c.update.PasswordHash = &hashed
+	case strings.HasPrefix(a, "emails"):
+		if s := primaryEmail(v); s != "" {
+			c.update.Email = &s
+		}
+	}
+	return nil
+}
+
+// remove clears an optional attribute
+func (c *userChanges) remove(attr string) error {
+	empty := ""
+	switch strings.ToLower(attr) {
+	case "externalid":
+		c.update.ExternalID = &empty
+	case "displayname", "name", "name.formatted":
+		c.update.FullName = &empty
+	case "username", "active", "emails":
+		return fmt.Errorf("%s cannot be removed", attr)
+	}
+	return nil
+}
+
+// primaryEmail extracts an address from either a bare value or a multi-valued
+// emails attribute, preferring the entry marked primary
+func primaryEmail(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	list, _ := v.([]any)
+	first := ""
+	for _, item := range list {
+		m, _ := item.(map[string]any)
+		value := stringValue(m["value"])
+		if primary, _ := boolValue(m["primary"]); primary && value != "" {
+			return value
+		}
+		if first == "" {
+			first = value
+		}
+	}
+	return first
+}
+
+// applyUserChanges persists c. Deactivating a user also revokes their refresh
+// tokens so existing sessions cannot be renewed.
+func (h *Handler) applyUserChanges(w http.ResponseWriter, r *http.Request, u *user.User, c *userChanges) {
+	if c.active != nil {
+		status := user.StatusActive
+		if !*c.active {
+			status = user.StatusDeactivated
+		}
+		c.update.Status = &status
+	}
+
+	err := h.UserRepo.Update(r.Context(), u.ID, &c.update)
+	if errors.Is(err, user.ErrEmailAlreadyExists) {
+		writeError(w, http.StatusConflict, "uniqueness", "userName is already in use")
+		return
+	}
+	if err != nil && !errors.Is(err, user.ErrNothingToUpdate) {
+		internalError(w, r, err)
+		return
+	}
+	if c.active != nil && !*c.active {
+		if err := h.RefreshTokenRepo.RevokeAllForUser(r.Context(), u.ID); err != nil {
+			internalError(w, r, err)
+			return
+		}
+		metrics.CountToken(metrics.TokenRevoked)
+	}
+
+	updated, err := h.UserRepo.FindById(r.Context(), u.ID)
+	if err != nil {
+		internalError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, h.toUser(updated))
+}
+
+func (h *Handler) deleteUser(w http.ResponseWriter, r *http.Request, tenantID string) {
+	u := h.findWritableUser(w, r, tenantID)
+	if u == nil {
+		return
+	}
+	err := h.UserRepo.Delete(r.Context(), u.ID)
+	if errors.Is(err, user.ErrUserNotFound) {
+		writeError(w, http.StatusNotFound, "", "user not found")
+		return
+	}
+	if err != nil {
+		internalError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
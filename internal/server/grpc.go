@@ -1,32 +1,375 @@
 package server
 
 import (
-	"database/sql"
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
+	"maps"
 	"net"
+	"slices"
+	"time"
 
+	"auth-haven/internal/abac"
 	"auth-haven/internal/config"
+	"auth-haven/internal/db"
+	"auth-haven/internal/domain/audit"
+	"auth-haven/internal/domain/client"
+	"auth-haven/internal/domain/federation"
+	"auth-haven/internal/domain/group"
+	"auth-haven/internal/domain/identity"
+	"auth-haven/internal/domain/impersonation"
+	"auth-haven/internal/domain/orgunit"
+	"auth-haven/internal/domain/policy"
+	"auth-haven/internal/domain/provisioning"
+	refreshtoken "auth-haven/internal/domain/refresh_token"
+	"auth-haven/internal/domain/relation"
+	"auth-haven/internal/domain/role"
+	"auth-haven/internal/domain/tenant"
+	"auth-haven/internal/domain/user"
+	"auth-haven/internal/health"
+	"auth-haven/internal/lifecycle"
+	"auth-haven/internal/metrics"
+	"auth-haven/internal/notify"
+	"auth-haven/internal/rebac"
+	"auth-haven/internal/service"
+	"auth-haven/internal/siem"
+	proto "auth-haven/pkg/proto"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 )
 
-func StartGRPC(cfg *config.Config, db *sql.DB) error {
+// StartGRPC serves the gRPC API and starts the background work behind it.
+// Everything started is registered with lc, so that at shutdown the server
+// drains first, then the workers stop, then the audit trail is flushed.
+func StartGRPC(lc *lifecycle.Lifecycle, cfg *config.Config, db db.DBTX, probe *health.Probe) error {
 	lis, err := net.Listen("tcp", cfg.GRPCPort)
 	if err != nil {
 		return fmt.Errorf("failed to listen: %w", err)
 	}
 
+	// Stored audit entries are forwarded to the configured SIEM sinks
+	var onWrite func([]*audit.AuditLog)
+	if cfg.AuditSinksFile != "" {
+		sinks, err := siem.LoadConfig(cfg.AuditSinksFile)
+		if err != nil {
+			return fmt.Errorf("failed to load audit sinks: %w", err)
+		}
+		forwarder, err := siem.NewForwarder(sinks, cfg.AuditDeadLetterFile)
+		if err != nil {
+			return fmt.Errorf("failed to open audit sinks: %w", err)
+		}
+		lc.OnStop("audit sinks", auditFlushTimeout, func(ctx context.Context) error {
+			return closeForwarder(ctx, forwarder)
+		})
+		onWrite = forwarder.Publish
+	}
+
+	// Audit entries from the interceptor and services are queued and written
+	// in batches; whatever is still queued at shutdown is flushed
+	audits := audit.NewPipeline(audit.AuditRepoImpl(db), audit.PipelineOptions{
+		QueueSize:      auditQueueSize,
+		BatchSize:      auditBatchSize,
+		FlushInterval:  auditFlushInterval,
+		EnqueueTimeout: auditEnqueueTimeout,
+		OnWrite:        onWrite,
+	})
+	lc.OnStop("audit pipeline", auditFlushTimeout, func(ctx context.Context) error {
+		return flushAudits(ctx, audits)
+	})
+	metrics.RegisterAudit(audits)
+	signer := audit.NewSigner(cfg.JWTSecret)
+	lc.Go("audit checkpoints", func(ctx context.Context) {
+		checkpointAudits(ctx, audits, signer, cfg.AuditCheckpointInterval)
+	})
+
+	interceptor := &Interceptor{
+		JWTSecret:         cfg.JWTSecret,
+		ImpersonationRepo: impersonation.ImpersonationRepoImpl(db),
+		AuditRepo:         audits,
+	}
 	opts := []grpc.ServerOption{
-		grpc.UnaryInterceptor(Unary),
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(metrics.Unary, interceptor.Unary),
+		grpc.ChainStreamInterceptor(metrics.Stream, interceptor.Stream),
 	}
 
 	s := grpc.NewServer(opts...)
 
-	// TODO: Register services
-	// proto.RegisterAuthServiceServer(s, auth.NewService(db, cfg.JWTSecret))
-	// proto.RegisterTenantServiceServer(s, tenant.NewService(db))
+	samlKeys, err := loadSAMLKeys(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load SAML keys: %w", err)
+	}
+
+	// Roles reach users through groups too; the expansion of nested groups is
+	// cached and dropped whenever GroupService changes a tenant's groups
+	groups := group.NewExpander(group.GroupRepoImpl(db), cfg.GroupCacheTTL)
+	conditions, err := abac.NewEngine()
+	if err != nil {
+		return fmt.Errorf("failed to set up policy engine: %w", err)
+	}
+	// Permission checks made through roles also evaluate the tenant's
+	// attribute-based policies
+	authorizer := &abac.Authorizer{Policies: policy.PolicyRepoImpl(db), Engine: conditions}
+	roles := abac.WithPolicies(group.WithGroupRoles(role.RoleRepoImpl(db), groups), authorizer)
+
+	proto.RegisterAuthServiceServer(s, &service.AuthService{
+		ClientRepo:        client.ClientRepoImpl(db),
+		FederationRepo:    federation.FederationRepoImpl(db),
+		SAMLRepo:          federation.SAMLRepoImpl(db),
+		LDAPRepo:          federation.LDAPRepoImpl(db),
+		IdentityRepo:      identity.IdentityRepoImpl(db),
+		ProvisioningRepo:  provisioning.ProvisioningRepoImpl(db),
+		RefreshTokenRepo:  refreshtoken.RefreshTokenRepoImpl(db),
+		UserRepo:          user.UserRepoImpl(db),
+		TenantRepo:        tenant.TenantRepoImpl(db),
+		RoleRepo:          roles,
+		ImpersonationRepo: impersonation.ImpersonationRepoImpl(db),
+		AuditRepo:         audits,
+		JWTSecret:         cfg.JWTSecret,
+		TokenTTLs:         tokenTTLs(cfg),
+		SupportTenantID:   cfg.SupportTenantID,
+	})
+	proto.RegisterUserServiceServer(s, &service.UserService{
+		UserRepo:         user.UserRepoImpl(db),
+		TenantRepo:       tenant.TenantRepoImpl(db),
+		RoleRepo:         roles,
+		EmailChangeRepo:  user.EmailChangeRepoImpl(db),
+		RefreshTokenRepo: refreshtoken.RefreshTokenRepoImpl(db),
+		Notifier:         newNotifier(cfg),
+	})
+	proto.RegisterFederationServiceServer(s, &service.FederationService{
+		FederationRepo:   federation.FederationRepoImpl(db),
+		SAMLRepo:         federation.SAMLRepoImpl(db),
+		LDAPRepo:         federation.LDAPRepoImpl(db),
+		IdentityRepo:     identity.IdentityRepoImpl(db),
+		ProvisioningRepo: provisioning.ProvisioningRepoImpl(db),
+		UserRepo:         user.UserRepoImpl(db),
+		TenantRepo:       tenant.TenantRepoImpl(db),
+		RoleRepo:         roles,
+		RefreshTokenRepo: refreshtoken.RefreshTokenRepoImpl(db),
+		JWTSecret:        cfg.JWTSecret,
+		TokenTTLs:        tokenTTLs(cfg),
+		PublicURL:        cfg.PublicURL,
+		SAMLKeys:         samlKeys,
+	})
+	proto.RegisterProvisioningServiceServer(s, &service.ProvisioningService{
+		ProvisioningRepo: provisioning.ProvisioningRepoImpl(db),
+		UserRepo:         user.UserRepoImpl(db),
+		RoleRepo:         roles,
+		PublicURL:        cfg.PublicURL,
+	})
+	proto.RegisterRoleServiceServer(s, &service.RoleService{
+		RoleRepo: roles,
+		UserRepo: user.UserRepoImpl(db),
+	})
+	proto.RegisterOrgUnitServiceServer(s, &service.OrgUnitService{
+		OrgUnitRepo: orgunit.OrgUnitRepoImpl(db),
+		RoleRepo:    roles,
+		UserRepo:    user.UserRepoImpl(db),
+	})
+	proto.RegisterGroupServiceServer(s, &service.GroupService{
+		GroupRepo: group.GroupRepoImpl(db),
+		RoleRepo:  roles,
+		UserRepo:  user.UserRepoImpl(db),
+		Groups:    groups,
+	})
+	proto.RegisterPolicyServiceServer(s, &service.PolicyService{
+		PolicyRepo: policy.PolicyRepoImpl(db),
+		RoleRepo:   roles,
+		UserRepo:   user.UserRepoImpl(db),
+		Authorizer: authorizer,
+	})
+	proto.RegisterRelationshipServiceServer(s, &service.RelationshipService{
+		RelationRepo: relation.RelationRepoImpl(db),
+		RoleRepo:     roles,
+		UserRepo:     user.UserRepoImpl(db),
+		Cache:        rebac.NewCache(cfg.RelationCacheTTL),
+	})
+	proto.RegisterAuditServiceServer(s, &service.AuditService{
+		AuditRepo: audits,
+		RoleRepo:  roles,
+		UserRepo:  user.UserRepoImpl(db),
+	})
+	tenants := &service.TenantService{
+		TenantRepo:      tenant.TenantRepoImpl(db),
+		UserRepo:        user.UserRepoImpl(db),
+		RoleRepo:        roles,
+		AuditRepo:       audits,
+		SupportTenantID: cfg.SupportTenantID,
+
+		DeletionCoolingOff:    cfg.TenantDeletionCoolingOff,
+		DomainChallengeSecret: cfg.JWTSecret,
+	}
+	proto.RegisterTenantServiceServer(s, tenants)
+	lc.Go("tenant purge", func(ctx context.Context) {
+		purgeTenants(ctx, tenants, cfg.TenantPurgeInterval)
+	})
+
+	// Standard services: health for load balancers and orchestrators, and
+	// reflection for tools such as grpcurl when enabled
+	healthServer := grpchealth.NewServer()
+	healthpb.RegisterHealthServer(s, healthServer)
+	if cfg.GRPCReflection {
+		reflection.Register(s)
+	}
+	services := slices.Collect(maps.Keys(s.GetServiceInfo()))
+	lc.Go("health reporter", func(ctx context.Context) {
+		reportHealth(ctx, healthServer, probe, services, healthCheckInterval)
+	})
+	probe.OnShutdown(healthServer.Shutdown)
+
+	go func() {
+		slog.Info("gRPC server running", "addr", cfg.GRPCPort)
+		if err := s.Serve(lis); err != nil {
+			lc.Fail(fmt.Errorf("grpc server failed: %w", err))
+		}
+	}()
+	lc.OnStop("grpc server", grpcDrainTimeout, func(ctx context.Context) error {
+		return gracefulStop(ctx, s)
+	})
+	return nil
+}
+
+// grpcDrainTimeout bounds how long in-flight calls get to finish at shutdown
+// before they are cut
+const grpcDrainTimeout = 20 * time.Second
+
+// gracefulStop waits for in-flight calls until ctx ends, then closes the
+// remaining connections
+func gracefulStop(ctx context.Context, s *grpc.Server) error {
+	done := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.Stop()
+		return fmt.Errorf("in-flight calls cut: %w", ctx.Err())
+	}
+}
+
+// Audit queue sizing: a full queue holds callers up for auditEnqueueTimeout
+// before their entry is dropped
+const (
+	auditQueueSize      = 10_000
+	auditBatchSize      = 500
+	auditFlushInterval  = time.Second
+	auditEnqueueTimeout = 50 * time.Millisecond
+	auditFlushTimeout   = 15 * time.Second
+)
 
-	log.Printf("gRPC server running on %s", cfg.GRPCPort)
-	return s.Serve(lis)
+// checkpointAudits periodically signs the audit chains that have grown
+func checkpointAudits(ctx context.Context, audits audit.AuditRepository, signer *audit.Signer, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if _, err := audit.SignCheckpoints(ctx, audits, signer); err != nil && ctx.Err() == nil {
+			slog.Error("audit checkpoint failed", "error", err)
+		}
+	}
+}
+
+// flushAudits writes out the queued audit entries before the server exits
+func flushAudits(ctx context.Context, audits *audit.Pipeline) error {
+	err := audits.Close(ctx)
+	st := audits.Stats()
+	slog.Info("audit pipeline closed", "written", st.Written, "dropped", st.Dropped, "failed", st.Failed, "unflushed", st.Queued)
+	return err
+}
+
+// closeForwarder delivers the audit events still queued for SIEM sinks
+func closeForwarder(ctx context.Context, f *siem.Forwarder) error {
+	err := f.Close(ctx)
+	for _, st := range f.Stats() {
+		slog.Info("audit sink closed", "sink", st.Name, "sent", st.Sent, "dead_lettered", st.DeadLettered, "unsent", st.Queued)
+	}
+	return err
+}
+
+// purgeTenants periodically deletes tenants whose scheduled deletion is due
+func purgeTenants(ctx context.Context, tenants *service.TenantService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		n, err := tenants.PurgeDueTenants(ctx)
+		if err != nil {
+			slog.Error("tenant purge failed", "error", err)
+			continue
+		}
+		if n > 0 {
+			slog.Info("purged tenants", "count", n)
+		}
+	}
+}
+
+// tokenTTLs are the configured lifetimes of issued tokens
+func tokenTTLs(cfg *config.Config) service.TokenTTLs {
+	return service.TokenTTLs{Access: cfg.AccessTokenTTL, Refresh: cfg.RefreshTokenTTL}
+}
+
+// healthCheckInterval is how often readiness is re-evaluated for the gRPC
+// health service
+const healthCheckInterval = 5 * time.Second
+
+// reportHealth keeps the health status of the server and each of its
+// services in step with the readiness probe
+func reportHealth(ctx context.Context, hs *grpchealth.Server, probe *health.Probe, services []string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	var last error
+	for {
+		err := probe.Ready(ctx)
+		st := healthpb.HealthCheckResponse_SERVING
+		if err != nil {
+			st = healthpb.HealthCheckResponse_NOT_SERVING
+			if last == nil {
+				slog.Warn("server not ready", "error", err)
+			}
+		} else if last != nil {
+			slog.Info("server ready")
+		}
+		last = err
+
+		hs.SetServingStatus("", st)
+		for _, name := range services {
+			hs.SetServingStatus(name, st)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// newNotifier delivers user mail through the configured relay, or only logs
+// it when none is set
+func newNotifier(cfg *config.Config) notify.Sender {
+	if cfg.SMTPAddr == "" {
+		return notify.LogSender{}
+	}
+	return &notify.SMTPSender{
+		Addr:     cfg.SMTPAddr,
+		From:     cfg.SMTPFrom,
+		Username: cfg.SMTPUsername,
+		Password: cfg.SMTPPassword,
+	}
 }
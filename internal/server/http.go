@@ -0,0 +1,112 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"auth-haven/internal/config"
+	"auth-haven/internal/db"
+	"auth-haven/internal/domain/federation"
+	"auth-haven/internal/domain/group"
+	"auth-haven/internal/domain/provisioning"
+	refreshtoken "auth-haven/internal/domain/refresh_token"
+	"auth-haven/internal/domain/role"
+	"auth-haven/internal/domain/user"
+	"auth-haven/internal/health"
+	"auth-haven/internal/lifecycle"
+	"auth-haven/internal/metrics"
+	"auth-haven/internal/scim"
+	"auth-haven/internal/service"
+	"auth-haven/internal/sso"
+)
+
+// StartHTTP serves the endpoints that cannot be gRPC, such as SAML metadata,
+// SCIM provisioning, Prometheus metrics and health probes. The server is
+// shut down through lc.
+func StartHTTP(lc *lifecycle.Lifecycle, cfg *config.Config, db db.DBTX, probe *health.Probe) error {
+	keys, err := loadSAMLKeys(cfg)
+	if err != nil {
+		return err
+	}
+	fed := &service.FederationService{
+		SAMLRepo:  federation.SAMLRepoImpl(db),
+		PublicURL: cfg.PublicURL,
+		SAMLKeys:  keys,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /saml/{provider}/metadata", func(w http.ResponseWriter, r *http.Request) {
+		doc, err := fed.SAMLMetadata(r.Context(), r.PathValue("provider"))
+		if errors.Is(err, federation.ErrProviderNotFound) {
+			http.NotFound(w, r)
+			return
+		}
+		if err != nil {
+			slog.ErrorContext(r.Context(), "saml metadata failed", "provider", r.PathValue("provider"), "error", err)
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/samlmetadata+xml")
+		w.Write(doc)
+	})
+
+	// GroupService cannot invalidate a cache held here, so group nesting is
+	// read afresh on every request
+	groups := group.NewExpander(group.GroupRepoImpl(db), 0)
+	scimHandler := &scim.Handler{
+		UserRepo:         user.UserRepoImpl(db),
+		RoleRepo:         group.WithGroupRoles(role.RoleRepoImpl(db), groups),
+		ProvisioningRepo: provisioning.ProvisioningRepoImpl(db),
+		RefreshTokenRepo: refreshtoken.RefreshTokenRepoImpl(db),
+		BaseURL:          cfg.PublicURL + "/scim/v2",
+	}
+	scimHandler.Register(mux)
+
+	mux.Handle("GET /metrics", metrics.Handler())
+
+	// Kubernetes probes: live while the process serves HTTP, ready while the
+	// database is reachable and migrated
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok\n"))
+	})
+	mux.HandleFunc("GET /readyz", func(w http.ResponseWriter, r *http.Request) {
+		if err := probe.Ready(r.Context()); err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok\n"))
+	})
+
+	lis, err := net.Listen("tcp", cfg.HTTPPort)
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: readHeaderTimeout}
+	go func() {
+		slog.Info("HTTP server running", "addr", cfg.HTTPPort)
+		if err := srv.Serve(lis); !errors.Is(err, http.ErrServerClosed) {
+			lc.Fail(fmt.Errorf("http server failed: %w", err))
+		}
+	}()
+	lc.OnStop("http server", httpShutdownTimeout, srv.Shutdown)
+	return nil
+}
+
+const (
+	readHeaderTimeout = 10 * time.Second
+	// httpShutdownTimeout bounds how long in-flight requests get to finish
+	// at shutdown
+	httpShutdownTimeout = 20 * time.Second
+)
+
+// loadSAMLKeys reads the optional SP credential
+func loadSAMLKeys(cfg *config.Config) (*sso.SAMLKeyPair, error) {
+	if cfg.SAMLCertFile == "" || cfg.SAMLKeyFile == "" {
+		return nil, nil
+	}
+	return sso.LoadSAMLKeyPair(cfg.SAMLCertFile, cfg.SAMLKeyFile)
+}
@@ -2,38 +2,217 @@ package server
 
 import (
 	"context"
-	"log"
+	"errors"
+	"log/slog"
+	"strings"
 	"time"
 
+	"auth-haven/internal/auth"
+	"auth-haven/internal/domain/audit"
+	"auth-haven/internal/domain/impersonation"
+	"auth-haven/internal/logging"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
-func Unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+type Interceptor struct {
+	JWTSecret         string
+	ImpersonationRepo impersonation.ImpersonationRepository
+	AuditRepo         audit.AuditRepository
+}
+
+func (i *Interceptor) Unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if isInfrastructure(info.FullMethod) {
+		return handler(ctx, req)
+	}
+	ctx = withRequest(ctx, info.FullMethod)
+	ctx, claims, err := i.authenticate(ctx)
+	if err != nil {
+		slog.WarnContext(ctx, "authentication failed", "error", err)
+		return nil, err
+	}
+
+	slog.DebugContext(ctx, "handling request", "request", logging.Request(req))
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	logCall(ctx, time.Since(start), err)
+
+	i.audit(ctx, claims, info.FullMethod, err)
+	return resp, err
+}
+
+// Stream authenticates and audits streaming calls the same way as Unary
+func (i *Interceptor) Stream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if isInfrastructure(info.FullMethod) {
+		return handler(srv, ss)
+	}
+	ctx := withRequest(ss.Context(), info.FullMethod)
+	ctx, claims, err := i.authenticate(ctx)
+	if err != nil {
+		slog.WarnContext(ctx, "authentication failed", "error", err)
+		return err
+	}
+
+	slog.DebugContext(ctx, "handling stream")
+	start := time.Now()
+	err = handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	logCall(ctx, time.Since(start), err)
+
+	i.audit(ctx, claims, info.FullMethod, err)
+	return err
+}
+
+// maxRequestIDLength bounds request IDs accepted from callers
+const maxRequestIDLength = 128
+
+// withRequest tags the context's log lines with the method, the trace and a
+// request ID, taken from the x-request-id metadata or generated, and echoes
+// the ID back in the response headers
+func withRequest(ctx context.Context, method string) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	var id string
+	if ids := md.Get("x-request-id"); len(ids) > 0 && validRequestID(ids[0]) {
+		id = ids[0]
+	} else {
+		id = uuid.NewString()
+	}
+	grpc.SetHeader(ctx, metadata.Pairs("x-request-id", id))
+	attrs := []slog.Attr{slog.String("request_id", id), slog.String("method", method)}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		attrs = append(attrs, slog.String("trace_id", sc.TraceID().String()))
+	}
+	return logging.With(ctx, attrs...)
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range id {
+		if c <= ' ' || c > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// logCall logs a finished call, at error level for server faults and warn
+// level for calls the client got wrong
+func logCall(ctx context.Context, duration time.Duration, err error) {
+	code := status.Code(err)
+	level := slog.LevelInfo
+	switch code {
+	case codes.OK:
+	case codes.Unknown, codes.Internal, codes.DataLoss, codes.Unavailable, codes.DeadlineExceeded, codes.Unimplemented:
+		level = slog.LevelError
+	default:
+		level = slog.LevelWarn
+	}
+	attrs := []slog.Attr{slog.String("code", code.String()), slog.Duration("duration", duration)}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", status.Convert(err).Message()))
+	}
+	slog.LogAttrs(ctx, level, "handled request", attrs...)
+}
+
+// isInfrastructure reports whether method belongs to the health or
+// reflection services. Probes call them every few seconds, so they are
+// neither logged nor audited.
+func isInfrastructure(method string) bool {
+	return strings.HasPrefix(method, "/grpc.health.v1.") || strings.HasPrefix(method, "/grpc.reflection.")
+}
+
+// serverStream carries the authenticated context into a stream handler
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context { return s.ctx }
+
+// authenticate reads the tenant and bearer token from the request metadata
+// and returns a context carrying the caller's claims, if any
+func (i *Interceptor) authenticate(ctx context.Context) (context.Context, *auth.Claims, error) {
 	md, _ := metadata.FromIncomingContext(ctx)
 
 	// Tenant ID
+	tenantID := ""
 	if tenantIDs := md.Get("tenant-id"); len(tenantIDs) > 0 {
-		ctx = context.WithValue(ctx, "tenant-id", tenantIDs[0])
+		tenantID = tenantIDs[0]
+		ctx = context.WithValue(ctx, "tenant-id", tenantID)
 	}
 
 	// JWT Authentication
+	var claims *auth.Claims
 	if authHeaders := md.Get("authorization"); len(authHeaders) > 0 {
 		token := authHeaders[0]
-		userID, err := validateJWT(token)
+		var err error
+		claims, err = i.validateJWT(ctx, token)
 		if err != nil {
-			return nil, grpc.Errorf(codes.Unauthenticated, "invalid token")
+			return ctx, nil, grpc.Errorf(codes.Unauthenticated, "invalid token")
+		}
+		ctx = context.WithValue(ctx, "user-id", claims.UserID)
+		ctx = auth.WithClaims(ctx, claims)
+		ctx = logging.With(ctx, slog.String("user_id", claims.UserID))
+		if claims.TenantID != "" {
+			tenantID = claims.TenantID
 		}
-		ctx = context.WithValue(ctx, "user-id", userID)
 	}
+	if tenantID != "" {
+		ctx = logging.With(ctx, slog.String("tenant_id", tenantID))
+	}
+	return ctx, claims, nil
+}
 
-	log.Printf("handling %s", info.FullMethod)
-	start := time.Now()
-	resp, err := handler(ctx, req)
-	duration := time.Since(start)
-	log.Printf("method=%s duration=%s error=%v", info.FullMethod, duration, err)
+// validateJWT checks a bearer token and returns its claims
+func (i *Interceptor) validateJWT(ctx context.Context, header string) (*auth.Claims, error) {
+	token := strings.TrimSpace(strings.TrimPrefix(header, "Bearer "))
+	claims, err := auth.ParseToken(i.JWTSecret, token)
+	if err != nil {
+		return nil, err
+	}
+	// Exchanged tokens are audience-restricted to other services
+	if len(claims.Audience) > 0 {
+		return nil, errors.New("token is not intended for this service")
+	}
+	// Impersonation tokens die with their session
+	if claims.ImpersonationID != "" {
+		session, err := i.ImpersonationRepo.FindById(ctx, claims.ImpersonationID)
+		if err != nil {
+			return nil, err
+		}
+		if !session.Active() {
+			return nil, errors.New("impersonation session has ended")
+		}
+	}
+	return claims, nil
+}
 
-	// TODO: Push audit log asynchronously
-	return resp, err
+// audit queues an entry for every call with its outcome. Calls made under
+// impersonation carry the session and the operator behind it.
+func (i *Interceptor) audit(ctx context.Context, claims *auth.Claims, method string, err error) {
+	outcome := status.Code(err).String()
+	entry := (&audit.AuditLog{Action: method, Method: &method, Outcome: &outcome}).FromRequest(ctx)
+	if claims != nil {
+		if claims.UserID != "" {
+			entry.UserID = &claims.UserID
+		}
+		if claims.TenantID != "" {
+			entry.TenantID = &claims.TenantID
+		}
+		if claims.ImpersonationID != "" {
+			entry.ImpersonationID = &claims.ImpersonationID
+			if claims.Act != nil {
+				entry.ImpersonatorID = &claims.Act.Subject
+			}
+		}
+	}
+	if err := i.AuditRepo.Create(ctx, entry); err != nil {
+		slog.ErrorContext(ctx, "audit failed", "error", err)
+	}
 }
@@ -0,0 +1,558 @@
+package service
+
+import (
+	"auth-haven/internal/auth"
+	"auth-haven/internal/domain/audit"
+	"auth-haven/internal/domain/client"
+	"auth-haven/internal/domain/federation"
+	"auth-haven/internal/domain/identity"
+	"auth-haven/internal/domain/impersonation"
+	"auth-haven/internal/domain/provisioning"
+	refreshtoken "auth-haven/internal/domain/refresh_token"
+	"auth-haven/internal/domain/role"
+	"auth-haven/internal/domain/tenant"
+	"auth-haven/internal/domain/user"
+	"auth-haven/internal/metrics"
+	"auth-haven/internal/sso"
+	"auth-haven/internal/utils"
+	proto "auth-haven/pkg/proto"
+	common "auth-haven/pkg/proto/common"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultImpersonationTTL = 15 * time.Minute
+	maxImpersonationTTL     = time.Hour
+)
+
+// Login method types returned by DiscoverLogin
+const (
+	LoginMethodPassword = "password"
+	LoginMethodOIDC     = "oidc"
+	LoginMethodSAML     = "saml"
+)
+
+// dummyHash is compared against when the user does not exist, so unknown
+// emails take as long as wrong passwords
+var dummyHash = sync.OnceValue(func() string {
+	h, _ := utils.Hash(context.Background(), "not-a-real-password")
+	return h
+})
+
+type AuthService struct {
+	proto.UnimplementedAuthServiceServer
+	ClientRepo        client.ClientRepository
+	FederationRepo    federation.FederationRepository
+	SAMLRepo          federation.SAMLRepository
+	LDAPRepo          federation.LDAPRepository
+	IdentityRepo      identity.IdentityRepository
+	ProvisioningRepo  provisioning.ProvisioningRepository
+	RefreshTokenRepo  refreshtoken.RefreshTokenRepository
+	UserRepo          user.UserRepository
+	TenantRepo        tenant.TenantRepository
+	RoleRepo          role.RoleRepository
+	ImpersonationRepo impersonation.ImpersonationRepository
+	AuditRepo         audit.AuditRepository
+	JWTSecret         string
+	TokenTTLs         TokenTTLs
+	SupportTenantID   string
+}
+
+// DiscoverLogin resolves which tenant an email belongs to and how its users
+// can sign in. It never reveals whether the account itself exists.
+func (s *AuthService) DiscoverLogin(ctx context.Context, req *proto.DiscoverLoginRequest) (*proto.DiscoverLoginResponse, error) {
+	if req.Email == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	t, err := s.resolveRealm(ctx, req.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &proto.DiscoverLoginResponse{
+		Methods: []*proto.LoginMethod{{Type: LoginMethodPassword}},
+	}
+	if t == nil {
+		return resp, nil
+	}
+	resp.TenantId = t.ID
+	resp.TenantName = t.Name
+
+	oidcProviders, err := s.FederationRepo.ListProviders(ctx, t.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range oidcProviders {
+		if p.Enabled {
+			resp.Methods = append(resp.Methods, &proto.LoginMethod{Type: LoginMethodOIDC, ProviderId: p.ID, Name: p.Name})
+		}
+	}
+	samlProviders, err := s.SAMLRepo.ListProviders(ctx, t.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range samlProviders {
+		if p.Enabled {
+			resp.Methods = append(resp.Methods, &proto.LoginMethod{Type: LoginMethodSAML, ProviderId: p.ID, Name: p.Name})
+		}
+	}
+	return resp, nil
+}
+
+// Login authenticates with email and password in the tenant that owns the
+// email's domain, or among personal accounts when no tenant claims it. A
+// tenant's LDAP directories are tried first; users none of them know fall
+// back to local passwords.
+func (s *AuthService) Login(ctx context.Context, req *proto.LoginRequest) (_ *common.Tokens, err error) {
+	defer func() { metrics.ObserveLogin(metrics.LoginPassword, err) }()
+	if req.Email == "" || req.Password == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	t, err := s.resolveRealm(ctx, req.Email)
+	if err != nil {
+		return nil, err
+	}
+	tenantID := ""
+	if t != nil {
+		tenantID = t.ID
+	}
+
+	var u *user.User
+	if t != nil {
+		if u, err = s.directoryLogin(ctx, t.ID, req.Email, req.Password); err != nil {
+			return nil, err
+		}
+	}
+	if u == nil {
+		if u, err = s.passwordLogin(ctx, tenantID, req.Email, req.Password); err != nil {
+			return nil, err
+		}
+	}
+	if u.Status != user.StatusActive {
+		return nil, status.Error(codes.PermissionDenied, "account is not active")
+	}
+	if err := ensureTenantActive(ctx, s.TenantRepo, u.TenantID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := s.UserRepo.Update(ctx, u.ID, &user.UpdateUser{LastLoginAt: &now}); err != nil {
+		return nil, err
+	}
+	return issueTokens(ctx, s.JWTSecret, s.TokenTTLs, s.RefreshTokenRepo, u)
+}
+
+// RefreshToken trades a refresh token for a new token pair. Refresh tokens
+// are single use: the presented one is revoked, and presenting a revoked one
+// again revokes every session of its user.
+func (s *AuthService) RefreshToken(ctx context.Context, req *proto.RefreshTokenRequest) (*common.Tokens, error) {
+	if req.RefreshToken == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	rt, err := s.RefreshTokenRepo.FindByHash(ctx, utils.HashToken(req.RefreshToken))
+	if errors.Is(err, refreshtoken.ErrTokenNotFound) {
+		return nil, status.Error(codes.Unauthenticated, "invalid refresh token")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if rt.Revoked {
+		slog.WarnContext(ctx, "revoked refresh token reused", "user", rt.UserID)
+		if err := s.RefreshTokenRepo.RevokeAllForUser(ctx, rt.UserID); err != nil {
+			return nil, err
+		}
+		metrics.CountToken(metrics.TokenRevoked)
+		return nil, status.Error(codes.Unauthenticated, "invalid refresh token")
+	}
+	if time.Now().After(rt.ExpiresAt) {
+		return nil, status.Error(codes.Unauthenticated, "refresh token expired")
+	}
+
+	u, err := s.UserRepo.FindById(ctx, rt.UserID)
+	if errors.Is(err, user.ErrUserNotFound) {
+		return nil, status.Error(codes.Unauthenticated, "invalid refresh token")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if u.Status != user.StatusActive {
+		return nil, status.Error(codes.PermissionDenied, "account is not active")
+	}
+	if err := ensureTenantActive(ctx, s.TenantRepo, u.TenantID); err != nil {
+		return nil, err
+	}
+
+	// Losing the race to a concurrent refresh counts as reuse
+	if err := s.RefreshTokenRepo.Revoke(ctx, rt.ID); err != nil {
+		if errors.Is(err, refreshtoken.ErrTokenNotFound) {
+			return nil, status.Error(codes.Unauthenticated, "invalid refresh token")
+		}
+		return nil, err
+	}
+	metrics.CountToken(metrics.TokenRefreshed)
+	return issueTokens(ctx, s.JWTSecret, s.TokenTTLs, s.RefreshTokenRepo, u)
+}
+
+// passwordLogin verifies a local password
+func (s *AuthService) passwordLogin(ctx context.Context, tenantID, email, password string) (*user.User, error) {
+	u, err := s.UserRepo.FindByEmail(ctx, tenantID, email)
+	if errors.Is(err, user.ErrUserNotFound) {
+		utils.CheckPassword(ctx, dummyHash(), password)
+		return nil, status.Error(codes.Unauthenticated, "invalid email or password")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if u.PasswordHash == "" || utils.CheckPassword(ctx, u.PasswordHash, password) != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid email or password")
+	}
+	return u, nil
+}
+
+// directoryLogin binds as the user against the tenant's enabled LDAP
+// directories in turn, then links or provisions the local user and syncs its
+// profile and group roles. It returns nil when no directory knows the login.
+func (s *AuthService) directoryLogin(ctx context.Context, tenantID, email, password string) (*user.User, error) {
+	directories, err := s.LDAPRepo.ListDirectories(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range directories {
+		if !d.Enabled {
+			continue
+		}
+		cfg, err := ldapConfig(d)
+		if err != nil {
+			return nil, err
+		}
+		ext, err := sso.LDAPAuthenticate(ctx, cfg, email, password)
+		if errors.Is(err, sso.ErrLDAPUserNotFound) {
+			continue
+		}
+		if errors.Is(err, sso.ErrLDAPInvalidCredential) {
+			return nil, status.Error(codes.Unauthenticated, "invalid email or password")
+		}
+		if err != nil {
+			slog.ErrorContext(ctx, "ldap login failed", "directory", d.ID, "error", err)
+			return nil, status.Error(codes.Unavailable, "directory unavailable")
+		}
+		if ext.Email == "" {
+			ext.Email = email
+		}
+
+		// A suspended tenant must not gain users, links or role grants
+		if err := ensureTenantActive(ctx, s.TenantRepo, tenantID); err != nil {
+			return nil, err
+		}
+		u, err := s.linker().resolveUser(ctx, tenantID, d.ID, identity.ProviderLDAP, ext)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.syncDirectoryProfile(ctx, u, ext); err != nil {
+			return nil, err
+		}
+		roles, err := ldapGroupRoles(d, ext.Groups)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.linker().syncRoles(ctx, tenantID, u, role.SourceLDAP, roles); err != nil {
+			return nil, err
+		}
+		return u, nil
+	}
+	return nil, nil
+}
+
+func (s *AuthService) linker() *identityLinker {
+	return &identityLinker{
+		identities:   s.IdentityRepo,
+		provisioning: s.ProvisioningRepo,
+		users:        s.UserRepo,
+		roles:        s.RoleRepo,
+	}
+}
+
+// syncDirectoryProfile copies the directory's name and email onto the user
+// when they have changed
+func (s *AuthService) syncDirectoryProfile(ctx context.Context, u *user.User, ext *sso.ExternalIdentity) error {
+	update := &user.UpdateUser{}
+	changed := false
+	if ext.Name != "" && ext.Name != u.FullName {
+		update.FullName = &ext.Name
+		changed = true
+	}
+	if !strings.EqualFold(ext.Email, u.Email) {
+		update.Email = &ext.Email
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	err := s.UserRepo.Update(ctx, u.ID, update)
+	if errors.Is(err, user.ErrEmailAlreadyExists) {
+		// Another account already owns the directory's address; keep ours
+		slog.WarnContext(ctx, "ldap profile sync skipped email change", "user", u.ID)
+		update.Email = nil
+		if update.FullName == nil {
+			return nil
+		}
+		err = s.UserRepo.Update(ctx, u.ID, update)
+	}
+	if err != nil {
+		return err
+	}
+	if update.FullName != nil {
+		u.FullName = *update.FullName
+	}
+	if update.Email != nil {
+		u.Email = *update.Email
+	}
+	return nil
+}
+
+// resolveRealm maps an email to the tenant owning its verified domain; nil
+// means the personal-user space
+func (s *AuthService) resolveRealm(ctx context.Context, email string) (*tenant.Tenant, error) {
+	at := strings.LastIndex(email, "@")
+	if at <= 0 || at == len(email)-1 {
+		return nil, status.Error(codes.InvalidArgument, "invalid email address")
+	}
+	t, err := s.TenantRepo.FindByVerifiedDomain(ctx, strings.ToLower(email[at+1:]))
+	if errors.Is(err, tenant.ErrTenantNotFound) {
+		return nil, nil
+	}
+	return t, err
+}
+
+// ExchangeToken trades a user's access token for a down-scoped token that a
+// client can present to another service on the user's behalf (RFC 8693).
+// The user and their tenant must still be active, as for a refresh.
+func (s *AuthService) ExchangeToken(ctx context.Context, req *proto.TokenExchangeRequest) (*proto.TokenExchangeResponse, error) {
+	if req.GrantType != auth.GrantTypeTokenExchange {
+		return nil, status.Error(codes.InvalidArgument, "unsupported grant type")
+	}
+	if req.SubjectToken == "" || req.Audience == "" || req.ClientId == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	if req.SubjectTokenType != auth.TokenTypeAccessToken && req.SubjectTokenType != auth.TokenTypeJWT {
+		return nil, status.Error(codes.InvalidArgument, "unsupported subject token type")
+	}
+	if req.RequestedTokenType != "" && req.RequestedTokenType != auth.TokenTypeAccessToken {
+		return nil, status.Error(codes.InvalidArgument, "unsupported requested token type")
+	}
+
+	// 1. Authenticate the calling client
+	c, err := s.ClientRepo.FindById(ctx, req.ClientId)
+	if errors.Is(err, client.ErrClientNotFound) {
+		return nil, status.Error(codes.Unauthenticated, "invalid client")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if utils.CheckPassword(ctx, c.SecretHash, req.ClientSecret) != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid client")
+	}
+
+	// 2. Validate the token being exchanged
+	subject, err := auth.ParseToken(s.JWTSecret, req.SubjectToken)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid subject token")
+	}
+	u, err := s.UserRepo.FindById(ctx, subject.UserID)
+	if errors.Is(err, user.ErrUserNotFound) {
+		return nil, status.Error(codes.InvalidArgument, "invalid subject token")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if u.Status != user.StatusActive {
+		return nil, status.Error(codes.PermissionDenied, "account is not active")
+	}
+	if err := ensureTenantActive(ctx, s.TenantRepo, u.TenantID); err != nil {
+		return nil, err
+	}
+
+	// 3. Apply the client's policy for the requested audience
+	p, err := s.ClientRepo.FindExchangePolicy(ctx, c.ID, req.Audience)
+	if errors.Is(err, client.ErrPolicyNotFound) {
+		return nil, status.Error(codes.PermissionDenied, auth.ErrInvalidTarget.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	policy := &auth.ExchangePolicy{
+		Audience: p.Audience,
+		Scopes:   p.Scopes,
+		TTL:      time.Duration(p.TokenTTLSeconds) * time.Second,
+	}
+	claims, ttl, err := auth.Exchange(subject, c.ID, req.Audience, auth.ParseScope(req.Scope), policy)
+	switch {
+	case errors.Is(err, auth.ErrInvalidTarget), errors.Is(err, auth.ErrInvalidScope),
+		errors.Is(err, auth.ErrImpersonated), errors.Is(err, auth.ErrDelegated):
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, auth.ErrTokenExpired):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case err != nil:
+		return nil, err
+	}
+
+	// 4. Issue the exchanged token
+	token, err := auth.SignToken(s.JWTSecret, claims, ttl)
+	if err != nil {
+		return nil, err
+	}
+	metrics.CountToken(metrics.TokenExchanged)
+	return &proto.TokenExchangeResponse{
+		AccessToken:     token,
+		IssuedTokenType: auth.TokenTypeAccessToken,
+		TokenType:       "Bearer",
+		ExpiresIn:       int64(ttl.Seconds()),
+		Scope:           claims.Scope,
+	}, nil
+}
+
+// ImpersonateUser issues a short-lived token that lets a support operator act as
+// a customer user. The token names both parties and is tied to a session that
+// can be ended early.
+func (s *AuthService) ImpersonateUser(ctx context.Context, req *proto.ImpersonateUserRequest) (*proto.ImpersonateUserResponse, error) {
+	caller := auth.ClaimsFromContext(ctx)
+	if caller == nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	if caller.ImpersonationID != "" || caller.Act != nil {
+		return nil, status.Error(codes.PermissionDenied, "delegated tokens cannot start impersonation")
+	}
+	if req.UserId == "" || req.Reason == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+
+	// 1. Only support staff holding the dedicated permission may impersonate
+	operator, err := s.UserRepo.FindById(ctx, caller.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if s.SupportTenantID == "" || operator.TenantID != s.SupportTenantID {
+		return nil, status.Error(codes.PermissionDenied, "permission denied")
+	}
+	allowed, err := hasPermission(ctx, s.UserRepo, s.RoleRepo, operator.ID, auth.PermImpersonate)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, status.Error(codes.PermissionDenied, "permission denied")
+	}
+
+	// 2. Resolve the target and honour the tenant's opt-out
+	target, err := s.UserRepo.FindById(ctx, req.UserId)
+	if errors.Is(err, user.ErrUserNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	if target.ID == operator.ID {
+		return nil, status.Error(codes.InvalidArgument, "cannot impersonate yourself")
+	}
+	var tenantID *string
+	if target.TenantID != "" {
+		t, err := s.TenantRepo.FindById(ctx, target.TenantID)
+		if err != nil {
+			return nil, err
+		}
+		if !t.AllowImpersonation {
+			return nil, status.Error(codes.FailedPrecondition, "tenant has opted out of impersonation")
+		}
+		tenantID = &t.ID
+	}
+
+	// 3. Open the session
+	ttl := defaultImpersonationTTL
+	if req.TtlSeconds > 0 {
+		ttl = min(time.Duration(req.TtlSeconds)*time.Second, maxImpersonationTTL)
+	}
+	session, err := s.ImpersonationRepo.Create(ctx, &impersonation.Session{
+		OperatorID:   operator.ID,
+		TargetUserID: target.ID,
+		TenantID:     tenantID,
+		Reason:       req.Reason,
+		ExpiresAt:    time.Now().Add(ttl),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. Issue the token, carrying both the impersonated user and the operator
+	claims := &auth.Claims{
+		UserID:          target.ID,
+		TenantID:        target.TenantID,
+		Act:             &auth.Actor{Subject: operator.ID},
+		ImpersonationID: session.ID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject: target.ID,
+		},
+	}
+	token, err := auth.SignToken(s.JWTSecret, claims, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := auditLog(auth.WithClaims(ctx, claims), "impersonation.start")
+	if err := s.AuditRepo.Create(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return &proto.ImpersonateUserResponse{
+		AccessToken: token,
+		SessionId:   session.ID,
+		ExpiresAt:   session.ExpiresAt.Unix(),
+	}, nil
+}
+
+// EndImpersonation terminates a session before it expires. It may be called
+// with the impersonation token itself or by the operator who started it.
+func (s *AuthService) EndImpersonation(ctx context.Context, req *proto.EndImpersonationRequest) (*proto.EndImpersonationResponse, error) {
+	caller := auth.ClaimsFromContext(ctx)
+	if caller == nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	if req.SessionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+
+	session, err := s.ImpersonationRepo.FindById(ctx, req.SessionId)
+	if errors.Is(err, impersonation.ErrSessionNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	if caller.ImpersonationID != session.ID && caller.UserID != session.OperatorID {
+		return nil, status.Error(codes.PermissionDenied, "permission denied")
+	}
+	if err := s.ImpersonationRepo.End(ctx, session.ID); err != nil {
+		if errors.Is(err, impersonation.ErrSessionNotFound) {
+			return nil, status.Error(codes.FailedPrecondition, "session already ended")
+		}
+		return nil, err
+	}
+
+	// Attribute the entry to the session so it lands in the same trail
+	entry := auditLog(ctx, "impersonation.end")
+	entry.UserID = &session.TargetUserID
+	entry.TenantID = session.TenantID
+	entry.ImpersonatorID = &session.OperatorID
+	entry.ImpersonationID = &session.ID
+	if err := s.AuditRepo.Create(ctx, entry); err != nil {
+		return nil, err
+	}
+	return &proto.EndImpersonationResponse{Success: true}, nil
+}
@@ -0,0 +1,701 @@
+package service
+
+import (
+	"auth-haven/internal/auth"
+	"auth-haven/internal/domain/federation"
+	"auth-haven/internal/domain/identity"
+	"auth-haven/internal/domain/provisioning"
+	refreshtoken "auth-haven/internal/domain/refresh_token"
+	"auth-haven/internal/domain/role"
+	"auth-haven/internal/domain/tenant"
+	"auth-haven/internal/domain/user"
+	"auth-haven/internal/metrics"
+	"auth-haven/internal/sso"
+	"auth-haven/internal/utils"
+	proto "auth-haven/pkg/proto"
+	common "auth-haven/pkg/proto/common"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/crewjam/saml"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	loginStateTTL  = 10 * time.Minute
+	samlRequestTTL = 10 * time.Minute
+)
+
+type FederationService struct {
+	proto.UnimplementedFederationServiceServer
+	FederationRepo   federation.FederationRepository
+	SAMLRepo         federation.SAMLRepository
+	LDAPRepo         federation.LDAPRepository
+	IdentityRepo     identity.IdentityRepository
+	ProvisioningRepo provisioning.ProvisioningRepository
+	UserRepo         user.UserRepository
+	TenantRepo       tenant.TenantRepository
+	RoleRepo         role.RoleRepository
+	RefreshTokenRepo refreshtoken.RefreshTokenRepository
+	JWTSecret        string
+	TokenTTLs        TokenTTLs
+	PublicURL        string
+	SAMLKeys         *sso.SAMLKeyPair
+}
+
+// CreateOIDCProvider registers an upstream OIDC provider for the caller's tenant
+func (s *FederationService) CreateOIDCProvider(ctx context.Context, req *proto.CreateOIDCProviderRequest) (*proto.OIDCProvider, error) {
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermManageSSO)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name == "" || req.Issuer == "" || req.ClientId == "" || req.ClientSecret == "" || req.RedirectUrl == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	mappings, err := json.Marshal(req.ClaimMappings)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := sso.ParseClaimMapping(mappings); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid claim mappings")
+	}
+
+	p, err := s.FederationRepo.CreateProvider(ctx, &federation.OIDCProvider{
+		TenantID:      caller.TenantID,
+		Name:          req.Name,
+		Issuer:        req.Issuer,
+		ClientID:      req.ClientId,
+		ClientSecret:  req.ClientSecret,
+		RedirectURL:   req.RedirectUrl,
+		Scopes:        req.Scopes,
+		ClaimMappings: mappings,
+		Enabled:       true,
+	})
+	if errors.Is(err, federation.ErrProviderAlreadyExists) {
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toProtoOIDCProvider(p), nil
+}
+
+// ListOIDCProviders returns the caller's tenant providers
+func (s *FederationService) ListOIDCProviders(ctx context.Context, req *proto.ListOIDCProvidersRequest) (*proto.ListOIDCProvidersResponse, error) {
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermManageSSO)
+	if err != nil {
+		return nil, err
+	}
+	providers, err := s.FederationRepo.ListProviders(ctx, caller.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	resp := &proto.ListOIDCProvidersResponse{}
+	for _, p := range providers {
+		resp.Providers = append(resp.Providers, toProtoOIDCProvider(p))
+	}
+	return resp, nil
+}
+
+// DeleteOIDCProvider removes a provider and, with it, its identity links
+func (s *FederationService) DeleteOIDCProvider(ctx context.Context, req *proto.DeleteOIDCProviderRequest) (*proto.DeleteOIDCProviderResponse, error) {
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermManageSSO)
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.FederationRepo.FindProviderById(ctx, req.ProviderId)
+	if errors.Is(err, federation.ErrProviderNotFound) || (err == nil && p.TenantID != caller.TenantID) {
+		return nil, status.Error(codes.NotFound, federation.ErrProviderNotFound.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := s.FederationRepo.DeleteProvider(ctx, p.ID); err != nil {
+		return nil, err
+	}
+	return &proto.DeleteOIDCProviderResponse{Success: true}, nil
+}
+
+// BeginOIDCLogin starts the redirect leg and returns where to send the browser
+func (s *FederationService) BeginOIDCLogin(ctx context.Context, req *proto.BeginOIDCLoginRequest) (*proto.BeginOIDCLoginResponse, error) {
+	if req.ProviderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	p, err := s.FederationRepo.FindProviderById(ctx, req.ProviderId)
+	if errors.Is(err, federation.ErrProviderNotFound) || (err == nil && !p.Enabled) {
+		return nil, status.Error(codes.NotFound, federation.ErrProviderNotFound.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	state := &federation.LoginState{ProviderID: p.ID, ExpiresAt: time.Now().Add(loginStateTTL)}
+	for _, v := range []*string{&state.State, &state.Nonce, &state.CodeVerifier} {
+		if *v, err = utils.RandomToken(32); err != nil {
+			return nil, err
+		}
+	}
+	cfg, err := oidcConfig(p)
+	if err != nil {
+		return nil, err
+	}
+	url, err := sso.AuthCodeURL(ctx, cfg, state.State, state.Nonce, state.CodeVerifier)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	if err := s.FederationRepo.CreateState(ctx, state); err != nil {
+		return nil, err
+	}
+	return &proto.BeginOIDCLoginResponse{AuthorizationUrl: url, State: state.State}, nil
+}
+
+// CompleteOIDCLogin finishes the callback leg: it redeems the code, finds or
+// just-in-time creates the local user, links the identity and issues tokens
+func (s *FederationService) CompleteOIDCLogin(ctx context.Context, req *proto.CompleteOIDCLoginRequest) (_ *common.Tokens, err error) {
+	defer func() { metrics.ObserveLogin(metrics.LoginOIDC, err) }()
+	if req.State == "" || req.Code == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	state, err := s.FederationRepo.ConsumeState(ctx, req.State)
+	if errors.Is(err, federation.ErrStateNotFound) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.FederationRepo.FindProviderById(ctx, state.ProviderID)
+	if err != nil {
+		return nil, err
+	}
+	if !p.Enabled {
+		return nil, status.Error(codes.FailedPrecondition, "identity provider is disabled")
+	}
+
+	cfg, err := oidcConfig(p)
+	if err != nil {
+		return nil, err
+	}
+	ext, err := sso.Exchange(ctx, cfg, req.Code, state.Nonce, state.CodeVerifier)
+	if err != nil {
+		slog.WarnContext(ctx, "oidc login failed", "provider", p.ID, "error", err)
+		return nil, status.Error(codes.Unauthenticated, "federated login failed")
+	}
+
+	// A suspended tenant must not gain users or links
+	if err := ensureTenantActive(ctx, s.TenantRepo, p.TenantID); err != nil {
+		return nil, err
+	}
+	u, err := s.linker().resolveUser(ctx, p.TenantID, p.ID, identity.ProviderOIDC, ext)
+	if err != nil {
+		return nil, err
+	}
+	return s.completeLogin(ctx, u)
+}
+
+func (s *FederationService) linker() *identityLinker {
+	return &identityLinker{
+		identities:   s.IdentityRepo,
+		provisioning: s.ProvisioningRepo,
+		users:        s.UserRepo,
+		roles:        s.RoleRepo,
+	}
+}
+
+// completeLogin issues tokens once an external login resolved to u
+func (s *FederationService) completeLogin(ctx context.Context, u *user.User) (*common.Tokens, error) {
+	if u.Status != user.StatusActive {
+		return nil, status.Error(codes.PermissionDenied, "account is not active")
+	}
+	if err := ensureTenantActive(ctx, s.TenantRepo, u.TenantID); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if err := s.UserRepo.Update(ctx, u.ID, &user.UpdateUser{LastLoginAt: &now}); err != nil {
+		return nil, err
+	}
+	return issueTokens(ctx, s.JWTSecret, s.TokenTTLs, s.RefreshTokenRepo, u)
+}
+
+func oidcConfig(p *federation.OIDCProvider) (*sso.OIDCConfig, error) {
+	claims, err := sso.ParseClaimMapping(p.ClaimMappings)
+	if err != nil {
+		return nil, err
+	}
+	return &sso.OIDCConfig{
+		Issuer:       p.Issuer,
+		ClientID:     p.ClientID,
+		ClientSecret: p.ClientSecret,
+		RedirectURL:  p.RedirectURL,
+		Scopes:       p.Scopes,
+		Claims:       claims,
+	}, nil
+}
+
+func toProtoOIDCProvider(p *federation.OIDCProvider) *proto.OIDCProvider {
+	mappings := map[string]string{}
+	_ = json.Unmarshal(p.ClaimMappings, &mappings)
+	return &proto.OIDCProvider{
+		Id:            p.ID,
+		TenantId:      p.TenantID,
+		Name:          p.Name,
+		Issuer:        p.Issuer,
+		ClientId:      p.ClientID,
+		RedirectUrl:   p.RedirectURL,
+		Scopes:        p.Scopes,
+		ClaimMappings: mappings,
+		Enabled:       p.Enabled,
+	}
+}
+
+// CreateSAMLProvider registers a SAML IdP for the caller's tenant from its
+// uploaded metadata
+func (s *FederationService) CreateSAMLProvider(ctx context.Context, req *proto.CreateSAMLProviderRequest) (*proto.SAMLProvider, error) {
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermManageSSO)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name == "" || req.EntityId == "" || req.AcsUrl == "" || req.IdpMetadataXml == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	idpEntityID, err := sso.ParseIDPMetadata([]byte(req.IdpMetadataXml))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// Mapped roles must exist in the tenant and be ones the caller could grant
+	mappings := []federation.RoleMapping{}
+	for _, m := range req.RoleMappings {
+		if err := requireGrantableRoleNames(ctx, s.UserRepo, s.RoleRepo, caller, []string{m.Role}); err != nil {
+			return nil, err
+		}
+		mappings = append(mappings, federation.RoleMapping{Value: m.Value, Role: m.Role})
+	}
+	roleMappings, err := json.Marshal(mappings)
+	if err != nil {
+		return nil, err
+	}
+	attributeMappings, err := json.Marshal(req.AttributeMappings)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := sso.ParseClaimMapping(attributeMappings); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid attribute mappings")
+	}
+	var roleAttribute *string
+	if req.RoleAttribute != "" {
+		roleAttribute = &req.RoleAttribute
+	}
+
+	p, err := s.SAMLRepo.CreateProvider(ctx, &federation.SAMLProvider{
+		TenantID:          caller.TenantID,
+		Name:              req.Name,
+		EntityID:          req.EntityId,
+		ACSURL:            req.AcsUrl,
+		IDPMetadata:       req.IdpMetadataXml,
+		IDPEntityID:       idpEntityID,
+		AllowIDPInitiated: req.AllowIdpInitiated,
+		AttributeMappings: attributeMappings,
+		RoleAttribute:     roleAttribute,
+		RoleMappings:      roleMappings,
+		Enabled:           true,
+	})
+	if errors.Is(err, federation.ErrProviderAlreadyExists) {
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.toProtoSAMLProvider(p), nil
+}
+
+// ListSAMLProviders returns the caller's tenant SAML providers
+func (s *FederationService) ListSAMLProviders(ctx context.Context, req *proto.ListSAMLProvidersRequest) (*proto.ListSAMLProvidersResponse, error) {
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermManageSSO)
+	if err != nil {
+		return nil, err
+	}
+	providers, err := s.SAMLRepo.ListProviders(ctx, caller.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	resp := &proto.ListSAMLProvidersResponse{}
+	for _, p := range providers {
+		resp.Providers = append(resp.Providers, s.toProtoSAMLProvider(p))
+	}
+	return resp, nil
+}
+
+// DeleteSAMLProvider removes a SAML provider and its identity links
+func (s *FederationService) DeleteSAMLProvider(ctx context.Context, req *proto.DeleteSAMLProviderRequest) (*proto.DeleteSAMLProviderResponse, error) {
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermManageSSO)
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.SAMLRepo.FindProviderById(ctx, req.ProviderId)
+	if errors.Is(err, federation.ErrProviderNotFound) || (err == nil && p.TenantID != caller.TenantID) {
+		return nil, status.Error(codes.NotFound, federation.ErrProviderNotFound.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := s.SAMLRepo.DeleteProvider(ctx, p.ID); err != nil {
+		return nil, err
+	}
+	return &proto.DeleteSAMLProviderResponse{Success: true}, nil
+}
+
+// BeginSAMLLogin starts SP-initiated SSO and returns the IdP redirect URL
+func (s *FederationService) BeginSAMLLogin(ctx context.Context, req *proto.BeginSAMLLoginRequest) (*proto.BeginSAMLLoginResponse, error) {
+	if req.ProviderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	p, err := s.enabledSAMLProvider(ctx, req.ProviderId)
+	if err != nil {
+		return nil, err
+	}
+	sp, err := s.serviceProvider(p)
+	if err != nil {
+		return nil, err
+	}
+	redirect, requestID, err := sso.SAMLRedirect(sp, req.RelayState)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	err = s.SAMLRepo.CreateRequest(ctx, &federation.SAMLRequest{
+		ID:         requestID,
+		ProviderID: p.ID,
+		ExpiresAt:  time.Now().Add(samlRequestTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &proto.BeginSAMLLoginResponse{RedirectUrl: redirect}, nil
+}
+
+// CompleteSAMLLogin is the ACS leg: it validates the posted response, maps the
+// assertion onto a local user and its role, and issues tokens
+func (s *FederationService) CompleteSAMLLogin(ctx context.Context, req *proto.CompleteSAMLLoginRequest) (_ *common.Tokens, err error) {
+	defer func() { metrics.ObserveLogin(metrics.LoginSAML, err) }()
+	if req.ProviderId == "" || req.SamlResponse == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	p, err := s.enabledSAMLProvider(ctx, req.ProviderId)
+	if err != nil {
+		return nil, err
+	}
+	sp, err := s.serviceProvider(p)
+	if err != nil {
+		return nil, err
+	}
+
+	// A solicited response must match an outstanding request exactly once
+	requestID, err := sso.SAMLInResponseTo(req.SamlResponse)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if requestID != "" {
+		if _, err := s.SAMLRepo.ConsumeRequest(ctx, p.ID, requestID); err != nil {
+			if errors.Is(err, federation.ErrRequestNotFound) {
+				return nil, status.Error(codes.Unauthenticated, err.Error())
+			}
+			return nil, err
+		}
+	}
+
+	attrs, err := sso.ParseClaimMapping(p.AttributeMappings)
+	if err != nil {
+		return nil, err
+	}
+	ext, err := sso.ParseSAMLResponse(sp, req.SamlResponse, requestID, attrs)
+	if err != nil {
+		slog.WarnContext(ctx, "saml login failed", "provider", p.ID, "error", err)
+		return nil, status.Error(codes.Unauthenticated, "federated login failed")
+	}
+
+	// A suspended tenant must not gain users, links or role grants
+	if err := ensureTenantActive(ctx, s.TenantRepo, p.TenantID); err != nil {
+		return nil, err
+	}
+	u, err := s.linker().resolveUser(ctx, p.TenantID, p.ID, identity.ProviderSAML, ext)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applySAMLRoles(ctx, p, ext, u); err != nil {
+		return nil, err
+	}
+	return s.completeLogin(ctx, u)
+}
+
+// SAMLMetadata renders our SP metadata for one provider, for the HTTP endpoint
+func (s *FederationService) SAMLMetadata(ctx context.Context, providerID string) ([]byte, error) {
+	p, err := s.SAMLRepo.FindProviderById(ctx, providerID)
+	if err != nil {
+		return nil, err
+	}
+	sp, err := s.serviceProvider(p)
+	if err != nil {
+		return nil, err
+	}
+	return sso.SAMLMetadata(sp)
+}
+
+// applySAMLRoles grants the role of the first mapping whose value the
+// assertion carries, replacing any role granted by an earlier SAML login. The
+// user's primary role is left alone.
+func (s *FederationService) applySAMLRoles(ctx context.Context, p *federation.SAMLProvider, ext *sso.ExternalIdentity, u *user.User) error {
+	if p.RoleAttribute == nil {
+		return nil
+	}
+	values, _ := ext.Claims[*p.RoleAttribute].([]string)
+	mappings := []federation.RoleMapping{}
+	if err := json.Unmarshal(p.RoleMappings, &mappings); err != nil {
+		return err
+	}
+	roles := []string{}
+	for _, m := range mappings {
+		if slices.Contains(values, m.Value) {
+			roles = append(roles, m.Role)
+			break
+		}
+	}
+	return s.linker().syncRoles(ctx, p.TenantID, u, role.SourceSAML, roles)
+}
+
+func (s *FederationService) enabledSAMLProvider(ctx context.Context, providerID string) (*federation.SAMLProvider, error) {
+	p, err := s.SAMLRepo.FindProviderById(ctx, providerID)
+	if errors.Is(err, federation.ErrProviderNotFound) || (err == nil && !p.Enabled) {
+		return nil, status.Error(codes.NotFound, federation.ErrProviderNotFound.Error())
+	}
+	return p, err
+}
+
+func (s *FederationService) serviceProvider(p *federation.SAMLProvider) (*saml.ServiceProvider, error) {
+	attrs, err := sso.ParseClaimMapping(p.AttributeMappings)
+	if err != nil {
+		return nil, err
+	}
+	return sso.NewServiceProvider(&sso.SAMLConfig{
+		EntityID:          p.EntityID,
+		ACSURL:            p.ACSURL,
+		MetadataURL:       s.samlMetadataURL(p.ID),
+		IDPMetadata:       []byte(p.IDPMetadata),
+		AllowIDPInitiated: p.AllowIDPInitiated,
+		Attributes:        attrs,
+	}, s.SAMLKeys)
+}
+
+func (s *FederationService) samlMetadataURL(providerID string) string {
+	return strings.TrimRight(s.PublicURL, "/") + "/saml/" + providerID + "/metadata"
+}
+
+func (s *FederationService) toProtoSAMLProvider(p *federation.SAMLProvider) *proto.SAMLProvider {
+	attributes := map[string]string{}
+	_ = json.Unmarshal(p.AttributeMappings, &attributes)
+	mappings := []federation.RoleMapping{}
+	_ = json.Unmarshal(p.RoleMappings, &mappings)
+
+	out := &proto.SAMLProvider{
+		Id:                p.ID,
+		TenantId:          p.TenantID,
+		Name:              p.Name,
+		EntityId:          p.EntityID,
+		AcsUrl:            p.ACSURL,
+		MetadataUrl:       s.samlMetadataURL(p.ID),
+		IdpEntityId:       p.IDPEntityID,
+		AllowIdpInitiated: p.AllowIDPInitiated,
+		AttributeMappings: attributes,
+		Enabled:           p.Enabled,
+	}
+	if p.RoleAttribute != nil {
+		out.RoleAttribute = *p.RoleAttribute
+	}
+	for _, m := range mappings {
+		out.RoleMappings = append(out.RoleMappings, &proto.SAMLRoleMapping{Value: m.Value, Role: m.Role})
+	}
+	return out
+}
+
+// CreateLDAPDirectory registers an LDAP or Active Directory server that will
+// verify password logins for the caller's tenant
+func (s *FederationService) CreateLDAPDirectory(ctx context.Context, req *proto.CreateLDAPDirectoryRequest) (*proto.LDAPDirectory, error) {
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermManageSSO)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name == "" || req.Url == "" || req.UserBaseDn == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	if req.UserFilter == "" {
+		req.UserFilter = "(mail=%s)"
+	}
+	attributeMappings, err := json.Marshal(req.AttributeMappings)
+	if err != nil {
+		return nil, err
+	}
+	attrs, err := sso.ParseLDAPAttributes(attributeMappings)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid attribute mappings")
+	}
+	err = sso.ValidateLDAPConfig(&sso.LDAPConfig{
+		URL:        req.Url,
+		StartTLS:   req.StartTls,
+		CACert:     []byte(req.CaCertPem),
+		UserBaseDN: req.UserBaseDn,
+		UserFilter: req.UserFilter,
+		Attributes: attrs,
+	})
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// Mapped roles must exist in the tenant and be ones the caller could grant
+	mappings := []federation.RoleMapping{}
+	for _, m := range req.GroupMappings {
+		if err := requireGrantableRoleNames(ctx, s.UserRepo, s.RoleRepo, caller, []string{m.Role}); err != nil {
+			return nil, err
+		}
+		mappings = append(mappings, federation.RoleMapping{Value: m.Value, Role: m.Role})
+	}
+	groupMappings, err := json.Marshal(mappings)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &federation.LDAPDirectory{
+		TenantID:          caller.TenantID,
+		Name:              req.Name,
+		URL:               req.Url,
+		StartTLS:          req.StartTls,
+		UserBaseDN:        req.UserBaseDn,
+		UserFilter:        req.UserFilter,
+		AttributeMappings: attributeMappings,
+		GroupMappings:     groupMappings,
+		Enabled:           true,
+	}
+	if req.CaCertPem != "" {
+		d.CACert = &req.CaCertPem
+	}
+	if req.BindDn != "" {
+		d.BindDN = &req.BindDn
+		d.BindPassword = &req.BindPassword
+	}
+	d, err = s.LDAPRepo.CreateDirectory(ctx, d)
+	if errors.Is(err, federation.ErrDirectoryAlreadyExists) {
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toProtoLDAPDirectory(d), nil
+}
+
+// ListLDAPDirectories returns the caller's tenant directories
+func (s *FederationService) ListLDAPDirectories(ctx context.Context, req *proto.ListLDAPDirectoriesRequest) (*proto.ListLDAPDirectoriesResponse, error) {
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermManageSSO)
+	if err != nil {
+		return nil, err
+	}
+	directories, err := s.LDAPRepo.ListDirectories(ctx, caller.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	resp := &proto.ListLDAPDirectoriesResponse{}
+	for _, d := range directories {
+		resp.Directories = append(resp.Directories, toProtoLDAPDirectory(d))
+	}
+	return resp, nil
+}
+
+// DeleteLDAPDirectory removes a directory and, with it, its identity links
+func (s *FederationService) DeleteLDAPDirectory(ctx context.Context, req *proto.DeleteLDAPDirectoryRequest) (*proto.DeleteLDAPDirectoryResponse, error) {
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermManageSSO)
+	if err != nil {
+		return nil, err
+	}
+	d, err := s.LDAPRepo.FindDirectoryById(ctx, req.DirectoryId)
+	if errors.Is(err, federation.ErrDirectoryNotFound) || (err == nil && d.TenantID != caller.TenantID) {
+		return nil, status.Error(codes.NotFound, federation.ErrDirectoryNotFound.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := s.LDAPRepo.DeleteDirectory(ctx, d.ID); err != nil {
+		return nil, err
+	}
+	return &proto.DeleteLDAPDirectoryResponse{Success: true}, nil
+}
+
+func ldapConfig(d *federation.LDAPDirectory) (*sso.LDAPConfig, error) {
+	attrs, err := sso.ParseLDAPAttributes(d.AttributeMappings)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &sso.LDAPConfig{
+		URL:        d.URL,
+		StartTLS:   d.StartTLS,
+		UserBaseDN: d.UserBaseDN,
+		UserFilter: d.UserFilter,
+		Attributes: attrs,
+	}
+	if d.CACert != nil {
+		cfg.CACert = []byte(*d.CACert)
+	}
+	if d.BindDN != nil {
+		cfg.BindDN = *d.BindDN
+	}
+	if d.BindPassword != nil {
+		cfg.BindPassword = *d.BindPassword
+	}
+	return cfg, nil
+}
+
+// ldapGroupRoles returns every role whose mapping matches one of the user's
+// groups, by full DN or by CN
+func ldapGroupRoles(d *federation.LDAPDirectory, groups []string) ([]string, error) {
+	mappings := []federation.RoleMapping{}
+	if err := json.Unmarshal(d.GroupMappings, &mappings); err != nil {
+		return nil, err
+	}
+	roles := []string{}
+	for _, m := range mappings {
+		for _, g := range groups {
+			if (strings.EqualFold(m.Value, g) || strings.EqualFold(m.Value, sso.GroupCN(g))) && !slices.Contains(roles, m.Role) {
+				roles = append(roles, m.Role)
+			}
+		}
+	}
+	return roles, nil
+}
+
+func toProtoLDAPDirectory(d *federation.LDAPDirectory) *proto.LDAPDirectory {
+	attributes := map[string]string{}
+	_ = json.Unmarshal(d.AttributeMappings, &attributes)
+	mappings := []federation.RoleMapping{}
+	_ = json.Unmarshal(d.GroupMappings, &mappings)
+
+	out := &proto.LDAPDirectory{
+		Id:                d.ID,
+		TenantId:          d.TenantID,
+		Name:              d.Name,
+		Url:               d.URL,
+		StartTls:          d.StartTLS,
+		HasCaCert:         d.CACert != nil,
+		UserBaseDn:        d.UserBaseDN,
+		UserFilter:        d.UserFilter,
+		AttributeMappings: attributes,
+		Enabled:           d.Enabled,
+	}
+	if d.BindDN != nil {
+		out.BindDn = *d.BindDN
+	}
+	for _, m := range mappings {
+		out.GroupMappings = append(out.GroupMappings, &proto.SAMLRoleMapping{Value: m.Value, Role: m.Role})
+	}
+	return out
+}
@@ -0,0 +1,429 @@
+package service
+
+import (
+	"auth-haven/internal/auth"
+	"auth-haven/internal/domain/group"
+	"auth-haven/internal/domain/role"
+	"auth-haven/internal/domain/user"
+	proto "auth-haven/pkg/proto"
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const maxGroupNameLength = 100
+
+type GroupService struct {
+	proto.UnimplementedGroupServiceServer
+	GroupRepo group.GroupRepository
+	RoleRepo  role.RoleRepository
+	UserRepo  user.UserRepository
+	Groups    *group.Expander
+}
+
+// CreateGroup adds a group to the caller's tenant
+func (s *GroupService) CreateGroup(ctx context.Context, req *proto.CreateGroupRequest) (*proto.Group, error) {
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermManageGroups)
+	if err != nil {
+		return nil, err
+	}
+	name, err := groupName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	g, err := s.GroupRepo.Create(ctx, &group.Group{
+		TenantID:    caller.TenantID,
+		Name:        name,
+		Description: req.Description,
+	})
+	if errors.Is(err, group.ErrGroupAlreadyExists) {
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toProtoGroup(g, nil), nil
+}
+
+// GetGroup implements proto.GroupServiceServer.
+func (s *GroupService) GetGroup(ctx context.Context, req *proto.GetGroupRequest) (*proto.Group, error) {
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermReadGroups)
+	if err != nil {
+		return nil, err
+	}
+	return s.reloadGroup(ctx, caller.TenantID, req.GroupId)
+}
+
+// ListGroups returns a page of the tenant's groups
+func (s *GroupService) ListGroups(ctx context.Context, req *proto.ListGroupsRequest) (*proto.ListGroupsResponse, error) {
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermReadGroups)
+	if err != nil {
+		return nil, err
+	}
+	offset, limit, err := pageBounds(req.PageSize, req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+	groups, total, err := s.GroupRepo.ListByTenant(ctx, caller.TenantID, group.ListFilter{
+		Name:   req.Name,
+		Offset: offset,
+		Limit:  limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &proto.ListGroupsResponse{
+		TotalSize:     int32(total),
+		NextPageToken: nextPageToken(offset, len(groups), total),
+	}
+	for _, g := range groups {
+		roleIDs, err := s.GroupRepo.ListRoles(ctx, g.ID)
+		if err != nil {
+			return nil, err
+		}
+		resp.Groups = append(resp.Groups, toProtoGroup(g, roleIDs))
+	}
+	return resp, nil
+}
+
+// UpdateGroup renames a group or changes its description
+func (s *GroupService) UpdateGroup(ctx context.Context, req *proto.UpdateGroupRequest) (*proto.Group, error) {
+	if req.Name == nil && req.Description == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermManageGroups)
+	if err != nil {
+		return nil, err
+	}
+	g, err := s.tenantGroup(ctx, caller.TenantID, req.GroupId)
+	if err != nil {
+		return nil, err
+	}
+
+	update := &group.UpdateGroup{Description: req.Description}
+	if req.Name != nil {
+		name, err := groupName(req.GetName())
+		if err != nil {
+			return nil, err
+		}
+		update.Name = &name
+	}
+	err = s.GroupRepo.Update(ctx, g.ID, update)
+	if errors.Is(err, group.ErrGroupAlreadyExists) {
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.reloadGroup(ctx, caller.TenantID, g.ID)
+}
+
+// DeleteGroup removes a group; its members lose the roles it carried
+func (s *GroupService) DeleteGroup(ctx context.Context, req *proto.DeleteGroupRequest) (*proto.DeleteGroupResponse, error) {
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermManageGroups)
+	if err != nil {
+		return nil, err
+	}
+	g, err := s.tenantGroup(ctx, caller.TenantID, req.GroupId)
+	if err != nil {
+		return nil, err
+	}
+	err = ensureOwnerRemains(ctx, s.RoleRepo, caller.TenantID, func(o role.OwnerGrant) bool {
+		return slices.Contains(o.GroupPath, g.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.GroupRepo.Delete(ctx, g.ID); err != nil {
+		return nil, err
+	}
+	s.Groups.Invalidate(caller.TenantID)
+	return &proto.DeleteGroupResponse{Success: true}, nil
+}
+
+// AddGroupMember adds a user or nests another group. Members gain the
+// group's roles, so callers can only add to groups whose roles they could
+// grant themselves.
+func (s *GroupService) AddGroupMember(ctx context.Context, req *proto.AddGroupMemberRequest) (*proto.Group, error) {
+	caller, g, err := s.memberChange(ctx, req.GroupId, req.UserId, req.MemberGroupId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireGrantableRoles(ctx, caller, g.ID); err != nil {
+		return nil, err
+	}
+
+	if req.UserId != "" {
+		err = s.GroupRepo.AddUser(ctx, g.ID, req.UserId)
+	} else {
+		err = s.GroupRepo.AddSubgroup(ctx, g.ID, req.MemberGroupId)
+	}
+	if errors.Is(err, group.ErrCycle) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.Groups.Invalidate(caller.TenantID)
+	return s.reloadGroup(ctx, caller.TenantID, g.ID)
+}
+
+// RemoveGroupMember removes a user or a nested group
+func (s *GroupService) RemoveGroupMember(ctx context.Context, req *proto.RemoveGroupMemberRequest) (*proto.Group, error) {
+	caller, g, err := s.memberChange(ctx, req.GroupId, req.UserId, req.MemberGroupId)
+	if err != nil {
+		return nil, err
+	}
+	// A grant is lost when its path starts at the removed membership or
+	// crosses the removed nesting
+	err = ensureOwnerRemains(ctx, s.RoleRepo, caller.TenantID, func(o role.OwnerGrant) bool {
+		if req.UserId != "" {
+			return o.UserID == req.UserId && len(o.GroupPath) > 0 && o.GroupPath[0] == g.ID
+		}
+		for i := 1; i < len(o.GroupPath); i++ {
+			if o.GroupPath[i-1] == req.MemberGroupId && o.GroupPath[i] == g.ID {
+				return true
+			}
+		}
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+	if req.UserId != "" {
+		err = s.GroupRepo.RemoveUser(ctx, g.ID, req.UserId)
+	} else {
+		err = s.GroupRepo.RemoveSubgroup(ctx, g.ID, req.MemberGroupId)
+	}
+	if errors.Is(err, group.ErrMemberNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.Groups.Invalidate(caller.TenantID)
+	return s.reloadGroup(ctx, caller.TenantID, g.ID)
+}
+
+// ListGroupMembers returns a group's direct members
+func (s *GroupService) ListGroupMembers(ctx context.Context, req *proto.ListGroupMembersRequest) (*proto.ListGroupMembersResponse, error) {
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermReadGroups)
+	if err != nil {
+		return nil, err
+	}
+	g, err := s.tenantGroup(ctx, caller.TenantID, req.GroupId)
+	if err != nil {
+		return nil, err
+	}
+	userIDs, groupIDs, err := s.GroupRepo.ListMembers(ctx, g.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.ListGroupMembersResponse{UserIds: userIDs, GroupIds: groupIDs}, nil
+}
+
+// AssignGroupRole gives everyone in the group a role. Callers can only hand
+// out roles whose permissions they hold themselves.
+func (s *GroupService) AssignGroupRole(ctx context.Context, req *proto.AssignGroupRoleRequest) (*proto.Group, error) {
+	caller, g, r, err := s.groupAndRole(ctx, req.GroupId, req.RoleId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.GroupRepo.AddRole(ctx, g.ID, r.ID); err != nil {
+		return nil, err
+	}
+	return s.reloadGroup(ctx, caller.TenantID, g.ID)
+}
+
+// UnassignGroupRole takes a role away from the group's members
+func (s *GroupService) UnassignGroupRole(ctx context.Context, req *proto.UnassignGroupRoleRequest) (*proto.Group, error) {
+	caller, g, r, err := s.groupAndRole(ctx, req.GroupId, req.RoleId)
+	if err != nil {
+		return nil, err
+	}
+	err = ensureOwnerRemains(ctx, s.RoleRepo, caller.TenantID, func(o role.OwnerGrant) bool {
+		return o.RoleID == r.ID && len(o.GroupPath) > 0 && o.GroupPath[len(o.GroupPath)-1] == g.ID
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = s.GroupRepo.RemoveRole(ctx, g.ID, r.ID)
+	if errors.Is(err, group.ErrRoleNotAssigned) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.reloadGroup(ctx, caller.TenantID, g.ID)
+}
+
+// GetEffectivePermissions resolves everything a user holds
+func (s *GroupService) GetEffectivePermissions(ctx context.Context, req *proto.GetEffectivePermissionsRequest) (*proto.EffectivePermissions, error) {
+	caller := auth.ClaimsFromContext(ctx)
+	if caller == nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	userID := caller.UserID
+	if req.UserId != "" && req.UserId != caller.UserID {
+		if _, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermReadUsers); err != nil {
+			return nil, err
+		}
+		u, err := s.UserRepo.FindById(ctx, req.UserId)
+		if errors.Is(err, user.ErrUserNotFound) || (err == nil && u.TenantID != caller.TenantID) {
+			return nil, status.Error(codes.NotFound, user.ErrUserNotFound.Error())
+		}
+		if err != nil {
+			return nil, err
+		}
+		userID = u.ID
+	}
+
+	held, err := s.RoleRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	groupIDs, err := s.Groups.UserGroups(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	resp := &proto.EffectivePermissions{GroupIds: groupIDs, Permissions: []string{}}
+	for _, r := range held {
+		resp.RoleIds = append(resp.RoleIds, r.ID)
+		for _, p := range permissionNames(r.Permissions) {
+			if !slices.Contains(resp.Permissions, p) {
+				resp.Permissions = append(resp.Permissions, p)
+			}
+		}
+	}
+	slices.Sort(resp.RoleIds)
+	slices.Sort(resp.Permissions)
+	return resp, nil
+}
+
+// memberChange checks a membership request and resolves its group and
+// member, which must both belong to the caller's tenant
+func (s *GroupService) memberChange(ctx context.Context, groupID, userID, memberGroupID string) (*auth.Claims, *group.Group, error) {
+	if (userID == "") == (memberGroupID == "") {
+		return nil, nil, status.Error(codes.InvalidArgument, "exactly one of user_id and member_group_id must be set")
+	}
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermManageGroups)
+	if err != nil {
+		return nil, nil, err
+	}
+	g, err := s.tenantGroup(ctx, caller.TenantID, groupID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if userID != "" {
+		u, err := s.UserRepo.FindById(ctx, userID)
+		if errors.Is(err, user.ErrUserNotFound) || (err == nil && u.TenantID != caller.TenantID) {
+			return nil, nil, status.Error(codes.NotFound, user.ErrUserNotFound.Error())
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+	} else {
+		if memberGroupID == g.ID {
+			return nil, nil, status.Error(codes.InvalidArgument, group.ErrCycle.Error())
+		}
+		if _, err := s.tenantGroup(ctx, caller.TenantID, memberGroupID); err != nil {
+			return nil, nil, err
+		}
+	}
+	return caller, g, nil
+}
+
+func (s *GroupService) groupAndRole(ctx context.Context, groupID string, roleID int64) (*auth.Claims, *group.Group, *role.Role, error) {
+	if roleID == 0 {
+		return nil, nil, nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermManageGroups)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	g, err := s.tenantGroup(ctx, caller.TenantID, groupID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	r, err := s.RoleRepo.FindById(ctx, roleID)
+	if errors.Is(err, role.ErrRoleNotFound) || (err == nil && r.TenantID != caller.TenantID) {
+		return nil, nil, nil, status.Error(codes.NotFound, role.ErrRoleNotFound.Error())
+	}
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if _, err := grantablePermissions(ctx, s.UserRepo, s.RoleRepo, caller.UserID, permissionNames(r.Permissions)); err != nil {
+		return nil, nil, nil, err
+	}
+	return caller, g, r, nil
+}
+
+// requireGrantableRoles checks that the caller holds every permission of the
+// roles new members of the group would gain, including those of the groups
+// containing it
+func (s *GroupService) requireGrantableRoles(ctx context.Context, caller *auth.Claims, groupID string) error {
+	groupIDs, err := s.Groups.Expand(ctx, caller.TenantID, []string{groupID})
+	if err != nil {
+		return err
+	}
+	roles, err := s.RoleRepo.ListByGroups(ctx, groupIDs)
+	if err != nil {
+		return err
+	}
+	for _, r := range roles {
+		if _, err := grantablePermissions(ctx, s.UserRepo, s.RoleRepo, caller.UserID, permissionNames(r.Permissions)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *GroupService) tenantGroup(ctx context.Context, tenantID, groupID string) (*group.Group, error) {
+	if groupID == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	g, err := s.GroupRepo.FindById(ctx, groupID)
+	if errors.Is(err, group.ErrGroupNotFound) || (err == nil && g.TenantID != tenantID) {
+		return nil, status.Error(codes.NotFound, group.ErrGroupNotFound.Error())
+	}
+	return g, err
+}
+
+func (s *GroupService) reloadGroup(ctx context.Context, tenantID, groupID string) (*proto.Group, error) {
+	g, err := s.tenantGroup(ctx, tenantID, groupID)
+	if err != nil {
+		return nil, err
+	}
+	roleIDs, err := s.GroupRepo.ListRoles(ctx, g.ID)
+	if err != nil {
+		return nil, err
+	}
+	return toProtoGroup(g, roleIDs), nil
+}
+
+func groupName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	if len(name) > maxGroupNameLength {
+		return "", status.Errorf(codes.InvalidArgument, "group name must be at most %d characters", maxGroupNameLength)
+	}
+	return name, nil
+}
+
+func toProtoGroup(g *group.Group, roleIDs []int64) *proto.Group {
+	return &proto.Group{
+		Id:          g.ID,
+		Name:        g.Name,
+		Description: g.Description,
+		RoleIds:     roleIDs,
+		CreatedAt:   g.CreatedAt.Unix(),
+		UpdatedAt:   g.UpdatedAt.Unix(),
+	}
+}
@@ -0,0 +1,421 @@
+package service
+
+import (
+	"auth-haven/internal/auth"
+	"auth-haven/internal/domain/orgunit"
+	"auth-haven/internal/domain/role"
+	"auth-haven/internal/domain/user"
+	proto "auth-haven/pkg/proto"
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const maxOrgUnitNameLength = 255
+
+type OrgUnitService struct {
+	proto.UnimplementedOrgUnitServiceServer
+	OrgUnitRepo orgunit.OrgUnitRepository
+	RoleRepo    role.RoleRepository
+	UserRepo    user.UserRepository
+}
+
+// CreateOrgUnit adds a unit to the caller's tenant
+func (s *OrgUnitService) CreateOrgUnit(ctx context.Context, req *proto.CreateOrgUnitRequest) (*proto.OrgUnit, error) {
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermManageOrgUnits)
+	if err != nil {
+		return nil, err
+	}
+	name, err := orgUnitName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if req.ParentId != "" {
+		if _, err := s.tenantUnit(ctx, caller.TenantID, req.ParentId); err != nil {
+			return nil, err
+		}
+	}
+
+	u, err := s.OrgUnitRepo.Create(ctx, &orgunit.OrgUnit{
+		TenantID: caller.TenantID,
+		Name:     name,
+		ParentID: req.ParentId,
+	})
+	if errors.Is(err, orgunit.ErrOrgUnitAlreadyExists) {
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.reloadUnit(ctx, caller.TenantID, u.ID)
+}
+
+// RenameOrgUnit changes a unit's name; its place in the tree is unchanged
+func (s *OrgUnitService) RenameOrgUnit(ctx context.Context, req *proto.RenameOrgUnitRequest) (*proto.OrgUnit, error) {
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermManageOrgUnits)
+	if err != nil {
+		return nil, err
+	}
+	u, err := s.tenantUnit(ctx, caller.TenantID, req.OrgUnitId)
+	if err != nil {
+		return nil, err
+	}
+	name, err := orgUnitName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	err = s.OrgUnitRepo.Rename(ctx, u.ID, name)
+	if errors.Is(err, orgunit.ErrOrgUnitAlreadyExists) {
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.reloadUnit(ctx, caller.TenantID, u.ID)
+}
+
+// MoveOrgUnit places a unit, with everything below it, under a new parent
+func (s *OrgUnitService) MoveOrgUnit(ctx context.Context, req *proto.MoveOrgUnitRequest) (*proto.OrgUnit, error) {
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermManageOrgUnits)
+	if err != nil {
+		return nil, err
+	}
+	u, err := s.tenantUnit(ctx, caller.TenantID, req.OrgUnitId)
+	if err != nil {
+		return nil, err
+	}
+	if req.ParentId == u.ParentID {
+		return s.reloadUnit(ctx, caller.TenantID, u.ID)
+	}
+	if req.ParentId != "" {
+		if _, err := s.tenantUnit(ctx, caller.TenantID, req.ParentId); err != nil {
+			return nil, err
+		}
+	}
+
+	tree, err := s.loadTree(ctx, caller.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if req.ParentId != "" && slices.Contains(tree.ancestors(req.ParentId), u.ID) {
+		return nil, status.Error(codes.InvalidArgument, orgunit.ErrCycle.Error())
+	}
+	// Members below u stop inheriting from ancestors the new parent lacks,
+	// and start inheriting from those it adds
+	oldChain, newChain := tree.ancestors(u.ParentID), tree.ancestors(req.ParentId)
+	if err := s.requireGrantableRoles(ctx, caller, gainedUnits(oldChain, newChain)); err != nil {
+		return nil, err
+	}
+	lost := map[string]bool{}
+	for _, id := range oldChain {
+		if !slices.Contains(newChain, id) {
+			lost[id] = true
+		}
+	}
+	if len(lost) > 0 {
+		inSubtree := map[string]bool{}
+		err := ensureOwnerRemains(ctx, s.RoleRepo, caller.TenantID, func(g role.OwnerGrant) bool {
+			if !lost[g.OrgUnitID] {
+				return false
+			}
+			member, ok := inSubtree[g.UserID]
+			if !ok {
+				m, err := s.UserRepo.FindById(ctx, g.UserID)
+				member = err == nil && slices.Contains(tree.ancestors(m.OrgUnitID), u.ID)
+				inSubtree[g.UserID] = member
+			}
+			return member
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = s.OrgUnitRepo.Move(ctx, u.ID, req.ParentId)
+	if errors.Is(err, orgunit.ErrCycle) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.reloadUnit(ctx, caller.TenantID, u.ID)
+}
+
+// DeleteOrgUnit removes a unit; its sub-units and members move up to its
+// parent and stop inheriting the roles assigned at it
+func (s *OrgUnitService) DeleteOrgUnit(ctx context.Context, req *proto.DeleteOrgUnitRequest) (*proto.DeleteOrgUnitResponse, error) {
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermManageOrgUnits)
+	if err != nil {
+		return nil, err
+	}
+	u, err := s.tenantUnit(ctx, caller.TenantID, req.OrgUnitId)
+	if err != nil {
+		return nil, err
+	}
+	err = ensureOwnerRemains(ctx, s.RoleRepo, caller.TenantID, func(g role.OwnerGrant) bool {
+		return g.OrgUnitID == u.ID
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.OrgUnitRepo.Delete(ctx, u.ID); err != nil {
+		return nil, err
+	}
+	return &proto.DeleteOrgUnitResponse{Success: true}, nil
+}
+
+// ListOrgUnitTree returns the tenant's units arranged as a forest
+func (s *OrgUnitService) ListOrgUnitTree(ctx context.Context, req *proto.ListOrgUnitTreeRequest) (*proto.ListOrgUnitTreeResponse, error) {
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermReadOrgUnits)
+	if err != nil {
+		return nil, err
+	}
+	tree, err := s.loadTree(ctx, caller.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	roles, err := s.OrgUnitRepo.ListRoleAssignments(ctx, caller.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := s.OrgUnitRepo.CountMembers(ctx, caller.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	var build func(parentID string) []*proto.OrgUnitNode
+	build = func(parentID string) []*proto.OrgUnitNode {
+		nodes := []*proto.OrgUnitNode{}
+		for _, u := range tree.children[parentID] {
+			nodes = append(nodes, &proto.OrgUnitNode{
+				Unit:     toProtoOrgUnit(u, roles[u.ID], counts[u.ID]),
+				Children: build(u.ID),
+			})
+		}
+		return nodes
+	}
+	return &proto.ListOrgUnitTreeResponse{Roots: build("")}, nil
+}
+
+// AssignOrgUnitRole assigns a role at a unit. Callers can only hand out roles
+// whose permissions they hold themselves.
+func (s *OrgUnitService) AssignOrgUnitRole(ctx context.Context, req *proto.AssignOrgUnitRoleRequest) (*proto.OrgUnit, error) {
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermManageOrgUnits)
+	if err != nil {
+		return nil, err
+	}
+	u, r, err := s.unitAndRole(ctx, caller, req.OrgUnitId, req.RoleId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.OrgUnitRepo.AddRole(ctx, u.ID, r.ID); err != nil {
+		return nil, err
+	}
+	return s.reloadUnit(ctx, caller.TenantID, u.ID)
+}
+
+// UnassignOrgUnitRole removes a role assigned at a unit
+func (s *OrgUnitService) UnassignOrgUnitRole(ctx context.Context, req *proto.UnassignOrgUnitRoleRequest) (*proto.OrgUnit, error) {
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermManageOrgUnits)
+	if err != nil {
+		return nil, err
+	}
+	u, r, err := s.unitAndRole(ctx, caller, req.OrgUnitId, req.RoleId)
+	if err != nil {
+		return nil, err
+	}
+	err = ensureOwnerRemains(ctx, s.RoleRepo, caller.TenantID, func(g role.OwnerGrant) bool {
+		return g.OrgUnitID == u.ID && g.RoleID == r.ID
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.OrgUnitRepo.RemoveRole(ctx, u.ID, r.ID); err != nil {
+		return nil, err
+	}
+	return s.reloadUnit(ctx, caller.TenantID, u.ID)
+}
+
+// SetUserOrgUnit moves a user into a unit, or out of any unit
+func (s *OrgUnitService) SetUserOrgUnit(ctx context.Context, req *proto.SetUserOrgUnitRequest) (*proto.SetUserOrgUnitResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermManageOrgUnits)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.UserRepo.FindById(ctx, req.UserId)
+	if errors.Is(err, user.ErrUserNotFound) || (err == nil && target.TenantID != caller.TenantID) {
+		return nil, status.Error(codes.NotFound, user.ErrUserNotFound.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	if req.OrgUnitId != "" {
+		if _, err := s.tenantUnit(ctx, caller.TenantID, req.OrgUnitId); err != nil {
+			return nil, err
+		}
+	}
+
+	tree, err := s.loadTree(ctx, caller.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	newChain := tree.ancestors(req.OrgUnitId)
+	if err := s.requireGrantableRoles(ctx, caller, gainedUnits(tree.ancestors(target.OrgUnitID), newChain)); err != nil {
+		return nil, err
+	}
+	err = ensureOwnerRemains(ctx, s.RoleRepo, caller.TenantID, func(g role.OwnerGrant) bool {
+		return g.UserID == target.ID && g.OrgUnitID != "" && !slices.Contains(newChain, g.OrgUnitID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.UserRepo.Update(ctx, target.ID, &user.UpdateUser{OrgUnitID: &req.OrgUnitId}); err != nil {
+		return nil, err
+	}
+	return &proto.SetUserOrgUnitResponse{Success: true}, nil
+}
+
+func (s *OrgUnitService) unitAndRole(ctx context.Context, caller *auth.Claims, unitID string, roleID int64) (*orgunit.OrgUnit, *role.Role, error) {
+	if roleID == 0 {
+		return nil, nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	u, err := s.tenantUnit(ctx, caller.TenantID, unitID)
+	if err != nil {
+		return nil, nil, err
+	}
+	r, err := s.RoleRepo.FindById(ctx, roleID)
+	if errors.Is(err, role.ErrRoleNotFound) || (err == nil && r.TenantID != caller.TenantID) {
+		return nil, nil, status.Error(codes.NotFound, role.ErrRoleNotFound.Error())
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := grantablePermissions(ctx, s.UserRepo, s.RoleRepo, caller.UserID, permissionNames(r.Permissions)); err != nil {
+		return nil, nil, err
+	}
+	return u, r, nil
+}
+
+// requireGrantableRoles checks that the caller holds every permission of the
+// roles assigned at units, which members are about to inherit
+func (s *OrgUnitService) requireGrantableRoles(ctx context.Context, caller *auth.Claims, units []string) error {
+	if len(units) == 0 {
+		return nil
+	}
+	assignments, err := s.OrgUnitRepo.ListRoleAssignments(ctx, caller.TenantID)
+	if err != nil {
+		return err
+	}
+	for _, id := range units {
+		for _, roleID := range assignments[id] {
+			r, err := s.RoleRepo.FindById(ctx, roleID)
+			if err != nil {
+				return err
+			}
+			if _, err := grantablePermissions(ctx, s.UserRepo, s.RoleRepo, caller.UserID, permissionNames(r.Permissions)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// gainedUnits returns the units of newChain missing from oldChain
+func gainedUnits(oldChain, newChain []string) []string {
+	return slices.DeleteFunc(slices.Clone(newChain), func(id string) bool {
+		return slices.Contains(oldChain, id)
+	})
+}
+
+func (s *OrgUnitService) tenantUnit(ctx context.Context, tenantID, unitID string) (*orgunit.OrgUnit, error) {
+	if unitID == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	u, err := s.OrgUnitRepo.FindById(ctx, unitID)
+	if errors.Is(err, orgunit.ErrOrgUnitNotFound) || (err == nil && u.TenantID != tenantID) {
+		return nil, status.Error(codes.NotFound, orgunit.ErrOrgUnitNotFound.Error())
+	}
+	return u, err
+}
+
+func (s *OrgUnitService) reloadUnit(ctx context.Context, tenantID, unitID string) (*proto.OrgUnit, error) {
+	u, err := s.tenantUnit(ctx, tenantID, unitID)
+	if err != nil {
+		return nil, err
+	}
+	roles, err := s.OrgUnitRepo.ListRoleAssignments(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := s.OrgUnitRepo.CountMembers(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return toProtoOrgUnit(u, roles[u.ID], counts[u.ID]), nil
+}
+
+// unitTree indexes a tenant's units for walking up and down the hierarchy
+type unitTree struct {
+	byID     map[string]*orgunit.OrgUnit
+	children map[string][]*orgunit.OrgUnit // keyed by parent ID, "" for roots
+}
+
+func (s *OrgUnitService) loadTree(ctx context.Context, tenantID string) (*unitTree, error) {
+	units, err := s.OrgUnitRepo.ListByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	t := &unitTree{
+		byID:     make(map[string]*orgunit.OrgUnit, len(units)),
+		children: map[string][]*orgunit.OrgUnit{},
+	}
+	for _, u := range units {
+		t.byID[u.ID] = u
+		t.children[u.ParentID] = append(t.children[u.ParentID], u)
+	}
+	return t, nil
+}
+
+// ancestors returns unitID followed by its ancestors up to the root; empty
+// for an empty unitID
+func (t *unitTree) ancestors(unitID string) []string {
+	chain := []string{}
+	for id := unitID; id != "" && !slices.Contains(chain, id); {
+		chain = append(chain, id)
+		u, ok := t.byID[id]
+		if !ok {
+			break
+		}
+		id = u.ParentID
+	}
+	return chain
+}
+
+func orgUnitName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	if len(name) > maxOrgUnitNameLength {
+		return "", status.Errorf(codes.InvalidArgument, "org unit name must be at most %d characters", maxOrgUnitNameLength)
+	}
+	return name, nil
+}
+
+func toProtoOrgUnit(u *orgunit.OrgUnit, roleIDs []int64, members int) *proto.OrgUnit {
+	return &proto.OrgUnit{
+		Id:          u.ID,
+		Name:        u.Name,
+		ParentId:    u.ParentID,
+		RoleIds:     roleIDs,
+		MemberCount: int32(members),
+		CreatedAt:   u.CreatedAt.Unix(),
+	}
+}
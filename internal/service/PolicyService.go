@@ -0,0 +1,315 @@
+package service
+
+import (
+	"auth-haven/internal/abac"
+	"auth-haven/internal/auth"
+	"auth-haven/internal/domain/policy"
+	"auth-haven/internal/domain/role"
+	"auth-haven/internal/domain/user"
+	proto "auth-haven/pkg/proto"
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	maxPolicyNameLength = 100
+	maxConditionLength  = 4096
+)
+
+type PolicyService struct {
+	proto.UnimplementedPolicyServiceServer
+	PolicyRepo policy.PolicyRepository
+	RoleRepo   role.RoleRepository
+	UserRepo   user.UserRepository
+	Authorizer *abac.Authorizer
+}
+
+// CreatePolicy attaches a condition to a role or a permission
+func (s *PolicyService) CreatePolicy(ctx context.Context, req *proto.CreatePolicyRequest) (*proto.Policy, error) {
+	if (req.RoleId == 0) == (req.Permission == "") {
+		return nil, status.Error(codes.InvalidArgument, "exactly one of role_id and permission must be set")
+	}
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermManagePolicies)
+	if err != nil {
+		return nil, err
+	}
+	name, err := policyName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateCondition(req.Condition); err != nil {
+		return nil, err
+	}
+
+	p := &policy.Policy{
+		TenantID:    caller.TenantID,
+		Name:        name,
+		Description: req.Description,
+		Permission:  req.Permission,
+		Condition:   req.Condition,
+	}
+	if req.RoleId != 0 {
+		r, err := s.RoleRepo.FindById(ctx, req.RoleId)
+		if errors.Is(err, role.ErrRoleNotFound) || (err == nil && r.TenantID != caller.TenantID) {
+			return nil, status.Error(codes.NotFound, role.ErrRoleNotFound.Error())
+		}
+		if err != nil {
+			return nil, err
+		}
+		if abac.IsUnconditional(r) {
+			return nil, status.Error(codes.FailedPrecondition, "owner roles cannot be conditioned")
+		}
+		p.RoleID = &r.ID
+	} else {
+		perm := strings.TrimSpace(req.Permission)
+		if strings.ContainsAny(perm, " \t") || perm == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid permission %q", req.Permission)
+		}
+		if perm == auth.PermAdmin {
+			return nil, status.Error(codes.FailedPrecondition, "the admin permission cannot be conditioned")
+		}
+		p.Permission = perm
+	}
+
+	p, err = s.PolicyRepo.Create(ctx, p)
+	if errors.Is(err, policy.ErrPolicyAlreadyExists) {
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toProtoPolicy(p), nil
+}
+
+// GetPolicy implements proto.PolicyServiceServer.
+func (s *PolicyService) GetPolicy(ctx context.Context, req *proto.GetPolicyRequest) (*proto.Policy, error) {
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermReadPolicies)
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.tenantPolicy(ctx, caller.TenantID, req.PolicyId)
+	if err != nil {
+		return nil, err
+	}
+	return toProtoPolicy(p), nil
+}
+
+// ListPolicies returns a page of the tenant's policies
+func (s *PolicyService) ListPolicies(ctx context.Context, req *proto.ListPoliciesRequest) (*proto.ListPoliciesResponse, error) {
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermReadPolicies)
+	if err != nil {
+		return nil, err
+	}
+	offset, limit, err := pageBounds(req.PageSize, req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+	policies, total, err := s.PolicyRepo.ListByTenant(ctx, caller.TenantID, policy.ListFilter{
+		RoleID:     req.RoleId,
+		Permission: req.Permission,
+		Offset:     offset,
+		Limit:      limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &proto.ListPoliciesResponse{
+		TotalSize:     int32(total),
+		NextPageToken: nextPageToken(offset, len(policies), total),
+	}
+	for _, p := range policies {
+		resp.Policies = append(resp.Policies, toProtoPolicy(p))
+	}
+	return resp, nil
+}
+
+// UpdatePolicy renames a policy or changes its description or condition. What
+// it is attached to cannot change.
+func (s *PolicyService) UpdatePolicy(ctx context.Context, req *proto.UpdatePolicyRequest) (*proto.Policy, error) {
+	if req.Name == nil && req.Description == nil && req.Condition == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermManagePolicies)
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.tenantPolicy(ctx, caller.TenantID, req.PolicyId)
+	if err != nil {
+		return nil, err
+	}
+
+	update := &policy.UpdatePolicy{Description: req.Description, Condition: req.Condition}
+	if req.Name != nil {
+		name, err := policyName(req.GetName())
+		if err != nil {
+			return nil, err
+		}
+		update.Name = &name
+	}
+	if req.Condition != nil {
+		if err := s.validateCondition(req.GetCondition()); err != nil {
+			return nil, err
+		}
+	}
+	err = s.PolicyRepo.Update(ctx, p.ID, update)
+	if errors.Is(err, policy.ErrPolicyAlreadyExists) {
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	p, err = s.PolicyRepo.FindById(ctx, p.ID)
+	if err != nil {
+		return nil, err
+	}
+	return toProtoPolicy(p), nil
+}
+
+// DeletePolicy implements proto.PolicyServiceServer.
+func (s *PolicyService) DeletePolicy(ctx context.Context, req *proto.DeletePolicyRequest) (*proto.DeletePolicyResponse, error) {
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermManagePolicies)
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.tenantPolicy(ctx, caller.TenantID, req.PolicyId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.PolicyRepo.Delete(ctx, p.ID); err != nil {
+		return nil, err
+	}
+	return &proto.DeletePolicyResponse{Success: true}, nil
+}
+
+// CheckAccess decides a permission for a user on a resource, optionally
+// explaining the decision
+func (s *PolicyService) CheckAccess(ctx context.Context, req *proto.CheckAccessRequest) (*proto.CheckAccessResponse, error) {
+	if req.Permission == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	caller := auth.ClaimsFromContext(ctx)
+	if caller == nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	userID := caller.UserID
+	if req.UserId != "" && req.UserId != caller.UserID {
+		if _, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermReadUsers); err != nil {
+			return nil, err
+		}
+		userID = req.UserId
+	}
+	u, err := s.UserRepo.FindById(ctx, userID)
+	if errors.Is(err, user.ErrUserNotFound) || (err == nil && u.TenantID != caller.TenantID) {
+		return nil, status.Error(codes.NotFound, user.ErrUserNotFound.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var decision *abac.Decision
+	if u.Status != user.StatusActive {
+		decision = &abac.Decision{Reason: "user is not active"}
+	} else {
+		held, err := s.RoleRepo.ListByUser(ctx, u.ID)
+		if err != nil {
+			return nil, err
+		}
+		decision, err = s.Authorizer.Decide(ctx, u, held, req.Permission, req.Resource.AsMap())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp := &proto.CheckAccessResponse{Allowed: decision.Allowed}
+	if req.Explain {
+		resp.Explanation = toProtoExplanation(decision)
+	}
+	return resp, nil
+}
+
+func (s *PolicyService) validateCondition(condition string) error {
+	if strings.TrimSpace(condition) == "" {
+		return status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	if len(condition) > maxConditionLength {
+		return status.Errorf(codes.InvalidArgument, "condition must be at most %d characters", maxConditionLength)
+	}
+	if _, err := s.Authorizer.Engine.Compile(condition); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid condition: %v", err)
+	}
+	return nil
+}
+
+func (s *PolicyService) tenantPolicy(ctx context.Context, tenantID, policyID string) (*policy.Policy, error) {
+	if policyID == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	p, err := s.PolicyRepo.FindById(ctx, policyID)
+	if errors.Is(err, policy.ErrPolicyNotFound) || (err == nil && p.TenantID != tenantID) {
+		return nil, status.Error(codes.NotFound, policy.ErrPolicyNotFound.Error())
+	}
+	return p, err
+}
+
+func policyName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	if len(name) > maxPolicyNameLength {
+		return "", status.Errorf(codes.InvalidArgument, "policy name must be at most %d characters", maxPolicyNameLength)
+	}
+	return name, nil
+}
+
+func toProtoPolicy(p *policy.Policy) *proto.Policy {
+	pp := &proto.Policy{
+		Id:          p.ID,
+		Name:        p.Name,
+		Description: p.Description,
+		Permission:  p.Permission,
+		Condition:   p.Condition,
+		CreatedAt:   p.CreatedAt.Unix(),
+		UpdatedAt:   p.UpdatedAt.Unix(),
+	}
+	if p.RoleID != nil {
+		pp.RoleId = *p.RoleID
+	}
+	return pp
+}
+
+func toProtoExplanation(d *abac.Decision) *proto.Explanation {
+	e := &proto.Explanation{Reason: d.Reason, Conditions: toProtoConditionResults(d.Conditions)}
+	for _, g := range d.Grants {
+		e.Grants = append(e.Grants, &proto.RoleGrant{
+			RoleId:        g.Role.ID,
+			RoleName:      g.Role.Name,
+			Unconditional: g.Unconditional,
+			Satisfied:     g.Satisfied,
+			Conditions:    toProtoConditionResults(g.Conditions),
+		})
+	}
+	return e
+}
+
+func toProtoConditionResults(results []abac.Result) []*proto.ConditionResult {
+	out := make([]*proto.ConditionResult, 0, len(results))
+	for _, r := range results {
+		c := &proto.ConditionResult{
+			PolicyId:   r.Policy.ID,
+			PolicyName: r.Policy.Name,
+			Condition:  r.Policy.Condition,
+			Satisfied:  r.Satisfied,
+		}
+		if r.Err != nil {
+			c.Error = r.Err.Error()
+		}
+		out = append(out, c)
+	}
+	return out
+}
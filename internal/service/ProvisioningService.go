@@ -0,0 +1,239 @@
+package service
+
+import (
+	"auth-haven/internal/auth"
+	"auth-haven/internal/domain/provisioning"
+	"auth-haven/internal/domain/role"
+	"auth-haven/internal/domain/user"
+	"auth-haven/internal/sso"
+	"auth-haven/internal/utils"
+	proto "auth-haven/pkg/proto"
+	"context"
+	"encoding/json"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type ProvisioningService struct {
+	proto.UnimplementedProvisioningServiceServer
+	ProvisioningRepo provisioning.ProvisioningRepository
+	UserRepo         user.UserRepository
+	RoleRepo         role.RoleRepository
+	PublicURL        string
+}
+
+// CreateSCIMToken issues a provisioning token for the caller's tenant. The
+// secret is returned once and only its hash is kept. A token can put users in
+// any role short of the privileged ones, all of which admin implies, so only
+// callers who could grant admin may issue one.
+func (s *ProvisioningService) CreateSCIMToken(ctx context.Context, req *proto.CreateSCIMTokenRequest) (*proto.CreateSCIMTokenResponse, error) {
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermProvision)
+	if err != nil {
+		return nil, err
+	}
+	if req.Description == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	if _, err := grantablePermissions(ctx, s.UserRepo, s.RoleRepo, caller.UserID, []string{auth.PermAdmin}); err != nil {
+		return nil, err
+	}
+
+	secret, err := utils.RandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	t, err := s.ProvisioningRepo.Create(ctx, &provisioning.Token{
+		TenantID:    caller.TenantID,
+		TokenHash:   utils.HashToken(secret),
+		Description: req.Description,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &proto.CreateSCIMTokenResponse{
+		Token:   toProtoSCIMToken(t),
+		Secret:  secret,
+		BaseUrl: s.PublicURL + "/scim/v2",
+	}, nil
+}
+
+// ListSCIMTokens returns the caller's tenant tokens, without their secrets
+func (s *ProvisioningService) ListSCIMTokens(ctx context.Context, req *proto.ListSCIMTokensRequest) (*proto.ListSCIMTokensResponse, error) {
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermProvision)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := s.ProvisioningRepo.ListByTenant(ctx, caller.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	resp := &proto.ListSCIMTokensResponse{}
+	for _, t := range tokens {
+		resp.Tokens = append(resp.Tokens, toProtoSCIMToken(t))
+	}
+	return resp, nil
+}
+
+// RevokeSCIMToken stops a token from authenticating further SCIM requests
+func (s *ProvisioningService) RevokeSCIMToken(ctx context.Context, req *proto.RevokeSCIMTokenRequest) (*proto.RevokeSCIMTokenResponse, error) {
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermProvision)
+	if err != nil {
+		return nil, err
+	}
+	if req.TokenId == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	err = s.ProvisioningRepo.Revoke(ctx, caller.TenantID, req.TokenId)
+	if errors.Is(err, provisioning.ErrTokenNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &proto.RevokeSCIMTokenResponse{Success: true}, nil
+}
+
+// GetJITPolicy returns the caller's tenant provisioning rules, or the implicit
+// default of auto-creating accounts with no roles
+func (s *ProvisioningService) GetJITPolicy(ctx context.Context, req *proto.GetJITPolicyRequest) (*proto.JITPolicy, error) {
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermProvision)
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.ProvisioningRepo.FindPolicy(ctx, caller.TenantID)
+	if errors.Is(err, provisioning.ErrPolicyNotFound) {
+		return &proto.JITPolicy{AutoCreate: true}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toProtoJITPolicy(p)
+}
+
+// SetJITPolicy replaces the caller's tenant provisioning rules. Every role the
+// rules name must exist in the tenant.
+func (s *ProvisioningService) SetJITPolicy(ctx context.Context, req *proto.SetJITPolicyRequest) (*proto.JITPolicy, error) {
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermProvision)
+	if err != nil {
+		return nil, err
+	}
+	if req.Policy == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+
+	rules := []sso.Rule{}
+	roleNames := []string{}
+	for _, r := range req.Policy.Rules {
+		rule := sso.Rule{Name: r.Name, Effect: r.Effect, Roles: r.Roles, Final: r.Final}
+		for _, c := range r.Conditions {
+			rule.Conditions = append(rule.Conditions, sso.Condition{
+				Claim:    c.Claim,
+				Operator: c.Op,
+				Values:   c.Values,
+				Negate:   c.Negate,
+			})
+		}
+		rules = append(rules, rule)
+		roleNames = append(roleNames, r.Roles...)
+	}
+	if err := sso.ValidateRules(rules); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	var defaultRole *string
+	if req.Policy.DefaultRole != "" {
+		defaultRole = &req.Policy.DefaultRole
+		roleNames = append(roleNames, req.Policy.DefaultRole)
+	}
+	// Everyone who signs in through the IdP may be granted these roles
+	if err := requireGrantableRoleNames(ctx, s.UserRepo, s.RoleRepo, caller, roleNames); err != nil {
+		return nil, err
+	}
+
+	doc, err := json.Marshal(rules)
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.ProvisioningRepo.SavePolicy(ctx, &provisioning.JITPolicy{
+		TenantID:    caller.TenantID,
+		AutoCreate:  req.Policy.AutoCreate,
+		DefaultRole: defaultRole,
+		Rules:       doc,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toProtoJITPolicy(p)
+}
+
+// EvaluateJITPolicy runs the stored rules against the supplied claims without
+// touching any account, so admins can check a rule set before relying on it
+func (s *ProvisioningService) EvaluateJITPolicy(ctx context.Context, req *proto.EvaluateJITPolicyRequest) (*proto.EvaluateJITPolicyResponse, error) {
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermProvision)
+	if err != nil {
+		return nil, err
+	}
+	if req.ClaimsJson == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	claims := map[string]any{}
+	if err := json.Unmarshal([]byte(req.ClaimsJson), &claims); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "claims_json must be a JSON object")
+	}
+
+	p, err := s.ProvisioningRepo.FindPolicy(ctx, caller.TenantID)
+	if errors.Is(err, provisioning.ErrPolicyNotFound) {
+		return &proto.EvaluateJITPolicyResponse{Roles: []string{}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	rules, err := sso.ParseRules(p.Rules)
+	if err != nil {
+		return nil, err
+	}
+	defaultRole := ""
+	if p.DefaultRole != nil {
+		defaultRole = *p.DefaultRole
+	}
+	d := sso.Evaluate(rules, defaultRole, claims)
+	return &proto.EvaluateJITPolicyResponse{Denied: d.Denied, DenyRule: d.Rule, Roles: d.Roles}, nil
+}
+
+func toProtoJITPolicy(p *provisioning.JITPolicy) (*proto.JITPolicy, error) {
+	rules, err := sso.ParseRules(p.Rules)
+	if err != nil {
+		return nil, err
+	}
+	pp := &proto.JITPolicy{AutoCreate: p.AutoCreate, UpdatedAt: p.UpdatedAt.Unix()}
+	if p.DefaultRole != nil {
+		pp.DefaultRole = *p.DefaultRole
+	}
+	for _, r := range rules {
+		pr := &proto.JITRule{Name: r.Name, Effect: r.Effect, Roles: r.Roles, Final: r.Final}
+		for _, c := range r.Conditions {
+			pr.Conditions = append(pr.Conditions, &proto.JITCondition{
+				Claim:  c.Claim,
+				Op:     c.Operator,
+				Values: c.Values,
+				Negate: c.Negate,
+			})
+		}
+		pp.Rules = append(pp.Rules, pr)
+	}
+	return pp, nil
+}
+
+func toProtoSCIMToken(t *provisioning.Token) *proto.SCIMToken {
+	pt := &proto.SCIMToken{
+		Id:          t.ID,
+		Description: t.Description,
+		Revoked:     t.Revoked,
+		CreatedAt:   t.CreatedAt.Unix(),
+	}
+	if t.LastUsedAt != nil {
+		pt.LastUsedAt = t.LastUsedAt.Unix()
+	}
+	return pt
+}
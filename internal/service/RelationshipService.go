@@ -0,0 +1,424 @@
+package service
+
+import (
+	"auth-haven/internal/auth"
+	"auth-haven/internal/domain/relation"
+	"auth-haven/internal/domain/role"
+	"auth-haven/internal/domain/user"
+	"auth-haven/internal/rebac"
+	proto "auth-haven/pkg/proto"
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	maxObjectIDLength        = 255
+	maxRelationshipsPerWrite = 1000
+	maxSchemaLength          = 64 << 10
+)
+
+type RelationshipService struct {
+	proto.UnimplementedRelationshipServiceServer
+	RelationRepo relation.RelationRepository
+	RoleRepo     role.RoleRepository
+	UserRepo     user.UserRepository
+	Cache        *rebac.Cache
+}
+
+// WriteSchema replaces the tenant's namespace configuration
+func (s *RelationshipService) WriteSchema(ctx context.Context, req *proto.WriteSchemaRequest) (*proto.RelationshipSchema, error) {
+	if strings.TrimSpace(req.Schema) == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	if len(req.Schema) > maxSchemaLength {
+		return nil, status.Error(codes.InvalidArgument, "schema is too large")
+	}
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermWriteRelations)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := rebac.ParseSchema(req.Schema); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	saved, err := s.RelationRepo.SaveSchema(ctx, caller.TenantID, req.Schema)
+	if err != nil {
+		return nil, err
+	}
+	return toProtoRelationshipSchema(saved), nil
+}
+
+// ReadSchema implements proto.RelationshipServiceServer.
+func (s *RelationshipService) ReadSchema(ctx context.Context, req *proto.ReadSchemaRequest) (*proto.RelationshipSchema, error) {
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermReadRelations)
+	if err != nil {
+		return nil, err
+	}
+	saved, err := s.RelationRepo.FindSchema(ctx, caller.TenantID)
+	if errors.Is(err, relation.ErrSchemaNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toProtoRelationshipSchema(saved), nil
+}
+
+// WriteRelationships applies writes and deletes at a single new revision
+func (s *RelationshipService) WriteRelationships(ctx context.Context, req *proto.WriteRelationshipsRequest) (*proto.WriteRelationshipsResponse, error) {
+	if len(req.Writes) == 0 && len(req.Deletes) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	if len(req.Writes)+len(req.Deletes) > maxRelationshipsPerWrite {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d relationships per write", maxRelationshipsPerWrite)
+	}
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermWriteRelations)
+	if err != nil {
+		return nil, err
+	}
+	schema, _, err := s.schema(ctx, caller.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	writes, err := toTuples(schema, req.Writes)
+	if err != nil {
+		return nil, err
+	}
+	deletes, err := toTuples(schema, req.Deletes)
+	if err != nil {
+		return nil, err
+	}
+	written := map[relation.Tuple]bool{}
+	for _, t := range writes {
+		written[t] = true
+	}
+	for _, t := range deletes {
+		if written[t] {
+			return nil, status.Errorf(codes.InvalidArgument, "%s is both written and deleted", t)
+		}
+	}
+
+	rev, err := s.RelationRepo.Write(ctx, caller.TenantID, writes, deletes)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.WriteRelationshipsResponse{WrittenAt: rebac.EncodeToken(rev)}, nil
+}
+
+// ReadRelationships returns a page of the tuples matching a filter
+func (s *RelationshipService) ReadRelationships(ctx context.Context, req *proto.ReadRelationshipsRequest) (*proto.ReadRelationshipsResponse, error) {
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermReadRelations)
+	if err != nil {
+		return nil, err
+	}
+	rev, _, err := s.revision(ctx, req.Consistency)
+	if err != nil {
+		return nil, err
+	}
+	offset, limit, err := pageBounds(req.PageSize, req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+	// One extra row tells whether another page follows
+	tuples, err := s.RelationRepo.Read(ctx, caller.TenantID, rev, relation.Filter{
+		ObjectType:  req.ObjectType,
+		ObjectID:    req.ObjectId,
+		Relation:    req.Relation,
+		SubjectType: req.SubjectType,
+		SubjectID:   req.SubjectId,
+		Offset:      offset,
+		Limit:       limit + 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &proto.ReadRelationshipsResponse{ReadAt: rebac.EncodeToken(rev)}
+	if len(tuples) > limit {
+		tuples = tuples[:limit]
+		resp.NextPageToken = strconv.Itoa(offset + limit)
+	}
+	for _, t := range tuples {
+		resp.Relationships = append(resp.Relationships, toProtoRelationship(t))
+	}
+	return resp, nil
+}
+
+// Check reports whether the subject holds the relation to the object
+func (s *RelationshipService) Check(ctx context.Context, req *proto.CheckRequest) (*proto.CheckResponse, error) {
+	if req.Object == nil || req.Relation == "" || req.Subject == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermReadRelations)
+	if err != nil {
+		return nil, err
+	}
+	schema, schemaRev, err := s.schema(ctx, caller.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	object, err := objectRef(schema, req.Object, req.Relation)
+	if err != nil {
+		return nil, err
+	}
+	subject, err := subjectRef(schema, req.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	rev, minRev, err := s.revision(ctx, req.Consistency)
+	if err != nil {
+		return nil, err
+	}
+	if minRev >= 0 {
+		if allowed, at, ok := s.Cache.Check(caller.TenantID, schemaRev, object, req.Relation, subject, minRev); ok {
+			return &proto.CheckResponse{Allowed: allowed, CheckedAt: rebac.EncodeToken(at)}, nil
+		}
+	}
+
+	allowed, cacheable, err := s.engine(schema, caller.TenantID, rev).Check(ctx, object, req.Relation, subject)
+	if err != nil {
+		return nil, evaluationError(err)
+	}
+	if cacheable {
+		s.Cache.PutCheck(caller.TenantID, schemaRev, object, req.Relation, subject, rev, allowed)
+	}
+	return &proto.CheckResponse{Allowed: allowed, CheckedAt: rebac.EncodeToken(rev)}, nil
+}
+
+// Expand returns the userset tree of a relation on an object
+func (s *RelationshipService) Expand(ctx context.Context, req *proto.ExpandRequest) (*proto.ExpandResponse, error) {
+	if req.Object == nil || req.Relation == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermReadRelations)
+	if err != nil {
+		return nil, err
+	}
+	schema, _, err := s.schema(ctx, caller.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	object, err := objectRef(schema, req.Object, req.Relation)
+	if err != nil {
+		return nil, err
+	}
+	rev, _, err := s.revision(ctx, req.Consistency)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := s.engine(schema, caller.TenantID, rev).Expand(ctx, object, req.Relation)
+	if err != nil {
+		return nil, evaluationError(err)
+	}
+	return &proto.ExpandResponse{Tree: toProtoUsersetTree(tree), ExpandedAt: rebac.EncodeToken(rev)}, nil
+}
+
+// ListObjects returns the objects of a type the subject holds the relation to
+func (s *RelationshipService) ListObjects(ctx context.Context, req *proto.ListObjectsRequest) (*proto.ListObjectsResponse, error) {
+	if req.ObjectType == "" || req.Relation == "" || req.Subject == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermReadRelations)
+	if err != nil {
+		return nil, err
+	}
+	schema, _, err := s.schema(ctx, caller.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := schema.Relation(req.ObjectType, req.Relation); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	subject, err := subjectRef(schema, req.Subject)
+	if err != nil {
+		return nil, err
+	}
+	rev, _, err := s.revision(ctx, req.Consistency)
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := s.engine(schema, caller.TenantID, rev).ListObjects(ctx, req.ObjectType, req.Relation, subject)
+	if err != nil {
+		return nil, evaluationError(err)
+	}
+	return &proto.ListObjectsResponse{ObjectIds: ids, ListedAt: rebac.EncodeToken(rev)}, nil
+}
+
+// schema returns the tenant's parsed namespace configuration and its revision
+func (s *RelationshipService) schema(ctx context.Context, tenantID string) (*rebac.Schema, int64, error) {
+	saved, err := s.RelationRepo.FindSchema(ctx, tenantID)
+	if errors.Is(err, relation.ErrSchemaNotFound) {
+		return nil, 0, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	schema, err := s.Cache.Schema(tenantID, saved.Revision, saved.Source)
+	if err != nil {
+		return nil, 0, err
+	}
+	return schema, saved.Revision, nil
+}
+
+// revision resolves a consistency requirement to the revision to evaluate at
+// and the oldest revision a cached result may come from, or -1 when the cache
+// must not be used
+func (s *RelationshipService) revision(ctx context.Context, c *proto.Consistency) (rev, minRev int64, err error) {
+	switch r := c.GetRequirement().(type) {
+	case *proto.Consistency_AtExactSnapshot:
+		rev, err := rebac.DecodeToken(r.AtExactSnapshot)
+		if err != nil {
+			return 0, 0, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return rev, -1, nil
+	case *proto.Consistency_AtLeastAsFresh:
+		if minRev, err = rebac.DecodeToken(r.AtLeastAsFresh); err != nil {
+			return 0, 0, status.Error(codes.InvalidArgument, err.Error())
+		}
+	case *proto.Consistency_FullyConsistent:
+		minRev = -1
+	}
+	if rev, err = s.RelationRepo.HeadRevision(ctx); err != nil {
+		return 0, 0, err
+	}
+	return rev, minRev, nil
+}
+
+func (s *RelationshipService) engine(schema *rebac.Schema, tenantID string, rev int64) *rebac.Engine {
+	return &rebac.Engine{
+		Schema: schema,
+		Reader: &rebac.Reader{Repo: s.RelationRepo, TenantID: tenantID, Revision: rev},
+	}
+}
+
+func evaluationError(err error) error {
+	switch {
+	case errors.Is(err, rebac.ErrMaxDepth):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, rebac.ErrUnknownNamespace), errors.Is(err, rebac.ErrUnknownRelation):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return err
+}
+
+// toTuples validates relationships against the schema: the relation must store
+// tuples and a userset subject must name a relation of its namespace
+func toTuples(schema *rebac.Schema, rels []*proto.Relationship) ([]relation.Tuple, error) {
+	tuples := make([]relation.Tuple, 0, len(rels))
+	for _, r := range rels {
+		if r.Object == nil || r.Relation == "" || r.Subject == nil {
+			return nil, status.Error(codes.InvalidArgument, "missing required fields")
+		}
+		object, err := objectRef(schema, r.Object, r.Relation)
+		if err != nil {
+			return nil, err
+		}
+		rel, _ := schema.Relation(object.Type, r.Relation)
+		if !rel.Writable() {
+			return nil, status.Errorf(codes.InvalidArgument, "%s#%s is computed and cannot be written", object.Type, r.Relation)
+		}
+		subject, err := subjectRef(schema, r.Subject)
+		if err != nil {
+			return nil, err
+		}
+		tuples = append(tuples, relation.Tuple{
+			ObjectType:      object.Type,
+			ObjectID:        object.ID,
+			Relation:        r.Relation,
+			SubjectType:     subject.Type,
+			SubjectID:       subject.ID,
+			SubjectRelation: subject.Relation,
+		})
+	}
+	return tuples, nil
+}
+
+func objectRef(schema *rebac.Schema, ref *proto.ObjectRef, rel string) (rebac.Object, error) {
+	if ref == nil || ref.Type == "" || ref.Id == "" {
+		return rebac.Object{}, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	if _, err := schema.Relation(ref.Type, rel); err != nil {
+		return rebac.Object{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if !validObjectID(ref.Id) {
+		return rebac.Object{}, status.Errorf(codes.InvalidArgument, "invalid object id %q", ref.Id)
+	}
+	return rebac.Object{Type: ref.Type, ID: ref.Id}, nil
+}
+
+// subjectRef validates a subject. Plain subjects may be of any type, since
+// users and other principals need not be namespaces; a userset's relation must
+// be declared.
+func subjectRef(schema *rebac.Schema, ref *proto.SubjectRef) (rebac.Subject, error) {
+	if ref == nil || ref.Object == nil || ref.Object.Type == "" || ref.Object.Id == "" {
+		return rebac.Subject{}, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	if !validObjectID(ref.Object.Type) || !validObjectID(ref.Object.Id) {
+		return rebac.Subject{}, status.Error(codes.InvalidArgument, "invalid subject")
+	}
+	if ref.Relation != "" {
+		if _, err := schema.Relation(ref.Object.Type, ref.Relation); err != nil {
+			return rebac.Subject{}, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	return rebac.Subject{
+		Object:   rebac.Object{Type: ref.Object.Type, ID: ref.Object.Id},
+		Relation: ref.Relation,
+	}, nil
+}
+
+// validObjectID rejects IDs that would make a tuple's string form ambiguous
+func validObjectID(id string) bool {
+	if id == "" || len(id) > maxObjectIDLength {
+		return false
+	}
+	return !strings.ContainsFunc(id, func(r rune) bool {
+		return r == '#' || r == '@' || unicode.IsSpace(r) || unicode.IsControl(r)
+	})
+}
+
+func toProtoRelationshipSchema(s *relation.Schema) *proto.RelationshipSchema {
+	return &proto.RelationshipSchema{
+		Schema:    s.Source,
+		Revision:  s.Revision,
+		UpdatedAt: s.UpdatedAt.Unix(),
+	}
+}
+
+func toProtoRelationship(t relation.Tuple) *proto.Relationship {
+	return &proto.Relationship{
+		Object:   &proto.ObjectRef{Type: t.ObjectType, Id: t.ObjectID},
+		Relation: t.Relation,
+		Subject: &proto.SubjectRef{
+			Object:   &proto.ObjectRef{Type: t.SubjectType, Id: t.SubjectID},
+			Relation: t.SubjectRelation,
+		},
+	}
+}
+
+func toProtoUsersetTree(n *rebac.Node) *proto.UsersetTree {
+	tree := &proto.UsersetTree{
+		Object:    &proto.ObjectRef{Type: n.Object.Type, Id: n.Object.ID},
+		Relation:  n.Relation,
+		Operation: n.Operation,
+	}
+	for _, s := range n.Subjects {
+		tree.Subjects = append(tree.Subjects, &proto.SubjectRef{
+			Object:   &proto.ObjectRef{Type: s.Type, Id: s.ID},
+			Relation: s.Relation,
+		})
+	}
+	for _, c := range n.Children {
+		tree.Children = append(tree.Children, toProtoUsersetTree(c))
+	}
+	return tree
+}
@@ -0,0 +1,343 @@
+package service
+
+import (
+	"auth-haven/internal/auth"
+	"auth-haven/internal/domain/role"
+	"auth-haven/internal/domain/user"
+	proto "auth-haven/pkg/proto"
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const maxRoleNameLength = 50
+
+type RoleService struct {
+	proto.UnimplementedRoleServiceServer
+	RoleRepo role.RoleRepository
+	UserRepo user.UserRepository
+}
+
+// CreateRole adds a role to the caller's tenant
+func (s *RoleService) CreateRole(ctx context.Context, req *proto.CreateRoleRequest) (*proto.Role, error) {
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermManageRoles)
+	if err != nil {
+		return nil, err
+	}
+	name, err := roleName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := grantablePermissions(ctx, s.UserRepo, s.RoleRepo, caller.UserID, req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := s.RoleRepo.Create(ctx, &role.Role{
+		TenantID:    caller.TenantID,
+		Name:        name,
+		Permissions: doc,
+	})
+	if errors.Is(err, role.ErrRoleAlreadyExists) {
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toProtoRole(r, 0), nil
+}
+
+// GetRole returns one of the tenant's roles
+func (s *RoleService) GetRole(ctx context.Context, req *proto.GetRoleRequest) (*proto.Role, error) {
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermReadRoles)
+	if err != nil {
+		return nil, err
+	}
+	r, err := s.tenantRole(ctx, caller.TenantID, req.RoleId)
+	if err != nil {
+		return nil, err
+	}
+	return s.reloadRole(ctx, r)
+}
+
+// ListRoles returns a page of the tenant's roles with their member counts
+func (s *RoleService) ListRoles(ctx context.Context, req *proto.ListRolesRequest) (*proto.ListRolesResponse, error) {
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermReadRoles)
+	if err != nil {
+		return nil, err
+	}
+	offset, limit, err := pageBounds(req.PageSize, req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, total, err := s.RoleRepo.ListByTenant(ctx, caller.TenantID, role.ListFilter{
+		Name:   req.Name,
+		Offset: offset,
+		Limit:  limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int64, len(roles))
+	for i, r := range roles {
+		ids[i] = r.ID
+	}
+	counts, err := s.RoleRepo.CountMembers(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &proto.ListRolesResponse{
+		TotalSize:     int32(total),
+		NextPageToken: nextPageToken(offset, len(roles), total),
+	}
+	for _, r := range roles {
+		resp.Roles = append(resp.Roles, toProtoRole(r, counts[r.ID]))
+	}
+	return resp, nil
+}
+
+// UpdateRole renames a role or replaces its permissions
+func (s *RoleService) UpdateRole(ctx context.Context, req *proto.UpdateRoleRequest) (*proto.Role, error) {
+	if req.Name == nil && !req.UpdatePermissions {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermManageRoles)
+	if err != nil {
+		return nil, err
+	}
+	r, err := s.tenantRole(ctx, caller.TenantID, req.RoleId)
+	if err != nil {
+		return nil, err
+	}
+
+	update := &role.UpdateRole{}
+	if req.Name != nil {
+		name, err := roleName(req.GetName())
+		if err != nil {
+			return nil, err
+		}
+		update.Name = &name
+	}
+	if req.UpdatePermissions {
+		// Both the current and the new permissions must be the caller's to give
+		if _, err := grantablePermissions(ctx, s.UserRepo, s.RoleRepo, caller.UserID, permissionNames(r.Permissions)); err != nil {
+			return nil, err
+		}
+		doc, err := grantablePermissions(ctx, s.UserRepo, s.RoleRepo, caller.UserID, req.Permissions)
+		if err != nil {
+			return nil, err
+		}
+		if isOwnerRole(r) && !auth.HasPermission(doc, auth.PermAdmin) {
+			if err := s.ensureOwnerRoleRemains(ctx, r); err != nil {
+				return nil, err
+			}
+		}
+		update.Permissions = &doc
+	}
+
+	err = s.RoleRepo.Update(ctx, r.ID, update)
+	if errors.Is(err, role.ErrRoleAlreadyExists) {
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	r, err = s.tenantRole(ctx, caller.TenantID, r.ID)
+	if err != nil {
+		return nil, err
+	}
+	return s.reloadRole(ctx, r)
+}
+
+// DeleteRole removes a role and every grant of it
+func (s *RoleService) DeleteRole(ctx context.Context, req *proto.DeleteRoleRequest) (*proto.DeleteRoleResponse, error) {
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermManageRoles)
+	if err != nil {
+		return nil, err
+	}
+	r, err := s.tenantRole(ctx, caller.TenantID, req.RoleId)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := grantablePermissions(ctx, s.UserRepo, s.RoleRepo, caller.UserID, permissionNames(r.Permissions)); err != nil {
+		return nil, err
+	}
+	if isOwnerRole(r) {
+		if err := s.ensureOwnerRoleRemains(ctx, r); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.RoleRepo.Delete(ctx, r.ID); err != nil {
+		return nil, err
+	}
+	return &proto.DeleteRoleResponse{Success: true}, nil
+}
+
+// AssignRole grants a role to a user of the tenant. Callers can only hand out
+// roles whose permissions they hold themselves.
+func (s *RoleService) AssignRole(ctx context.Context, req *proto.AssignRoleRequest) (*proto.AssignRoleResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermManageRoles)
+	if err != nil {
+		return nil, err
+	}
+	r, err := s.tenantRole(ctx, caller.TenantID, req.RoleId)
+	if err != nil {
+		return nil, err
+	}
+	u, err := s.tenantUser(ctx, caller.TenantID, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := grantablePermissions(ctx, s.UserRepo, s.RoleRepo, caller.UserID, permissionNames(r.Permissions)); err != nil {
+		return nil, err
+	}
+	if err := s.RoleRepo.AddMember(ctx, r.ID, u.ID); err != nil {
+		return nil, err
+	}
+	return &proto.AssignRoleResponse{Success: true}, nil
+}
+
+// UnassignRole revokes a role from a user, whether it was granted as a
+// membership or is the user's primary role. Roles inherited from an org unit
+// stay in place.
+func (s *RoleService) UnassignRole(ctx context.Context, req *proto.UnassignRoleRequest) (*proto.UnassignRoleResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermManageRoles)
+	if err != nil {
+		return nil, err
+	}
+	r, err := s.tenantRole(ctx, caller.TenantID, req.RoleId)
+	if err != nil {
+		return nil, err
+	}
+	u, err := s.tenantUser(ctx, caller.TenantID, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.RoleRepo.ListMembers(ctx, r.ID)
+	if err != nil {
+		return nil, err
+	}
+	if u.RoleId != r.ID && !slices.Contains(members, u.ID) {
+		// Roles inherited from an org unit are unassigned at the unit
+		return nil, status.Error(codes.NotFound, "user does not hold this role directly")
+	}
+	if _, err := grantablePermissions(ctx, s.UserRepo, s.RoleRepo, caller.UserID, permissionNames(r.Permissions)); err != nil {
+		return nil, err
+	}
+	err = ensureOwnerRemains(ctx, s.RoleRepo, caller.TenantID, func(g role.OwnerGrant) bool {
+		return g.UserID == u.ID && g.RoleID == r.ID && (g.Primary || g.Membership())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.RoleRepo.RemoveMember(ctx, r.ID, u.ID); err != nil {
+		return nil, err
+	}
+	if u.RoleId == r.ID {
+		var none int64
+		if err := s.UserRepo.Update(ctx, u.ID, &user.UpdateUser{RoleId: &none}); err != nil {
+			return nil, err
+		}
+	}
+	return &proto.UnassignRoleResponse{Success: true}, nil
+}
+
+// ensureOwnerRoleRemains stops the last owner role, or the last active
+// owner's grants of r, from going away
+func (s *RoleService) ensureOwnerRoleRemains(ctx context.Context, r *role.Role) error {
+	n, err := s.RoleRepo.CountOwnerRoles(ctx, r.TenantID)
+	if err != nil {
+		return err
+	}
+	if n <= 1 {
+		return status.Error(codes.FailedPrecondition, "a tenant must keep at least one owner role")
+	}
+	return ensureOwnerRemains(ctx, s.RoleRepo, r.TenantID, func(g role.OwnerGrant) bool {
+		return g.RoleID == r.ID
+	})
+}
+
+func (s *RoleService) tenantRole(ctx context.Context, tenantID string, roleID int64) (*role.Role, error) {
+	if roleID == 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	r, err := s.RoleRepo.FindById(ctx, roleID)
+	if errors.Is(err, role.ErrRoleNotFound) || (err == nil && r.TenantID != tenantID) {
+		return nil, status.Error(codes.NotFound, role.ErrRoleNotFound.Error())
+	}
+	return r, err
+}
+
+func (s *RoleService) tenantUser(ctx context.Context, tenantID, userID string) (*user.User, error) {
+	u, err := s.UserRepo.FindById(ctx, userID)
+	if errors.Is(err, user.ErrUserNotFound) || (err == nil && u.TenantID != tenantID) {
+		return nil, status.Error(codes.NotFound, user.ErrUserNotFound.Error())
+	}
+	return u, err
+}
+
+func (s *RoleService) reloadRole(ctx context.Context, r *role.Role) (*proto.Role, error) {
+	counts, err := s.RoleRepo.CountMembers(ctx, []int64{r.ID})
+	if err != nil {
+		return nil, err
+	}
+	return toProtoRole(r, counts[r.ID]), nil
+}
+
+func roleName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	if len(name) > maxRoleNameLength {
+		return "", status.Errorf(codes.InvalidArgument, "role name must be at most %d characters", maxRoleNameLength)
+	}
+	return name, nil
+}
+
+// isOwnerRole reports whether r grants admin
+func isOwnerRole(r *role.Role) bool {
+	return auth.HasPermission(r.Permissions, auth.PermAdmin)
+}
+
+// permissionNames lists the permissions a role's document grants, sorted
+func permissionNames(doc []byte) []string {
+	perms := map[string]bool{}
+	_ = json.Unmarshal(doc, &perms)
+	names := []string{}
+	for p, granted := range perms {
+		if granted {
+			names = append(names, p)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+func toProtoRole(r *role.Role, members int) *proto.Role {
+	pr := &proto.Role{
+		Id:          r.ID,
+		Name:        r.Name,
+		Permissions: permissionNames(r.Permissions),
+		MemberCount: int32(members),
+		CreatedAt:   r.CreatedAt.Unix(),
+	}
+	if r.ExternalID != nil {
+		pr.ExternalId = *r.ExternalID
+	}
+	return pr
+}
@@ -0,0 +1,421 @@
+package service
+
+import (
+	"auth-haven/internal/auth"
+	"auth-haven/internal/domain/audit"
+	"auth-haven/internal/domain/role"
+	"auth-haven/internal/domain/tenant"
+	"auth-haven/internal/domain/user"
+	proto "auth-haven/pkg/proto"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"log/slog"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type TenantService struct {
+	proto.UnimplementedTenantServiceServer
+	TenantRepo      tenant.TenantRepository
+	UserRepo        user.UserRepository
+	RoleRepo        role.RoleRepository
+	AuditRepo       audit.AuditRepository
+	SupportTenantID string
+	// DeletionCoolingOff is how long a scheduled deletion can still be
+	// cancelled before the tenant is purged
+	DeletionCoolingOff time.Duration
+	// DomainChallengeSecret keys the TXT values tenants publish to prove
+	// they own their domain
+	DomainChallengeSecret string
+	// Resolver looks up challenge records; net.DefaultResolver when nil
+	Resolver TXTResolver
+}
+
+// TXTResolver looks up DNS TXT records
+type TXTResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// domainChallengeLabel prefixes the domain to name the challenge record
+const domainChallengeLabel = "_auth-haven-challenge."
+
+// GetTenant returns the caller's tenant, or any tenant for an operator
+func (s *TenantService) GetTenant(ctx context.Context, req *proto.GetTenantRequest) (*proto.Tenant, error) {
+	_, t, err := s.targetTenant(ctx, req.TenantId, "")
+	if err != nil {
+		return nil, err
+	}
+	return toProtoTenant(t), nil
+}
+
+// UpdateTenant edits a tenant's profile
+func (s *TenantService) UpdateTenant(ctx context.Context, req *proto.UpdateTenantRequest) (*proto.Tenant, error) {
+	_, t, err := s.targetTenant(ctx, req.TenantId, auth.PermManageTenant)
+	if err != nil {
+		return nil, err
+	}
+
+	update := &tenant.UpdateTenant{}
+	changed := false
+	if req.Name != nil {
+		name := strings.TrimSpace(req.GetName())
+		if name == "" {
+			return nil, status.Error(codes.InvalidArgument, "name must not be empty")
+		}
+		update.Name = &name
+		changed = true
+	}
+	if req.Domain != nil {
+		domain := strings.ToLower(strings.TrimSpace(req.GetDomain()))
+		if domain == "" || strings.ContainsAny(domain, "@/ ") {
+			return nil, status.Error(codes.InvalidArgument, "invalid domain")
+		}
+		if domain != t.Domain {
+			update.Domain = &domain
+			changed = true
+		}
+	}
+	if req.AllowImpersonation != nil {
+		allow := req.GetAllowImpersonation()
+		update.AllowImpersonation = &allow
+		changed = true
+	}
+	if !changed {
+		return toProtoTenant(t), nil
+	}
+
+	err = s.TenantRepo.Update(ctx, t.ID, update)
+	if errors.Is(err, tenant.ErrTenantAlreadyExists) {
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.reloadTenant(ctx, t.ID)
+}
+
+// GetDomainChallenge returns the TXT record the tenant has to publish before
+// VerifyDomain succeeds
+func (s *TenantService) GetDomainChallenge(ctx context.Context, req *proto.GetDomainChallengeRequest) (*proto.DomainChallenge, error) {
+	_, t, err := s.targetTenant(ctx, req.TenantId, auth.PermManageTenant)
+	if err != nil {
+		return nil, err
+	}
+	if t.Domain == "" {
+		return nil, status.Error(codes.FailedPrecondition, "tenant has no domain")
+	}
+	return &proto.DomainChallenge{
+		Domain:      t.Domain,
+		RecordName:  domainChallengeLabel + t.Domain,
+		RecordValue: s.domainChallenge(t),
+		Verified:    t.DomainVerified,
+	}, nil
+}
+
+// VerifyDomain looks up the challenge record and marks the domain verified
+// once it carries the expected value
+func (s *TenantService) VerifyDomain(ctx context.Context, req *proto.VerifyDomainRequest) (*proto.Tenant, error) {
+	_, t, err := s.targetTenant(ctx, req.TenantId, auth.PermManageTenant)
+	if err != nil {
+		return nil, err
+	}
+	if t.Domain == "" {
+		return nil, status.Error(codes.FailedPrecondition, "tenant has no domain")
+	}
+	if t.DomainVerified {
+		return toProtoTenant(t), nil
+	}
+
+	var resolver TXTResolver = net.DefaultResolver
+	if s.Resolver != nil {
+		resolver = s.Resolver
+	}
+	records, err := resolver.LookupTXT(ctx, domainChallengeLabel+t.Domain)
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return nil, status.Error(codes.FailedPrecondition, "challenge record not found")
+	}
+	if err != nil {
+		slog.WarnContext(ctx, "domain challenge lookup failed", "domain", t.Domain, "error", err)
+		return nil, status.Error(codes.Unavailable, "could not look up the challenge record")
+	}
+	if !slices.Contains(records, s.domainChallenge(t)) {
+		return nil, status.Error(codes.FailedPrecondition, "challenge record does not match")
+	}
+
+	err = s.TenantRepo.MarkDomainVerified(ctx, t.ID, t.Domain)
+	if errors.Is(err, tenant.ErrTenantAlreadyExists) {
+		return nil, status.Error(codes.AlreadyExists, "domain is verified by another tenant")
+	}
+	if errors.Is(err, tenant.ErrTenantNotFound) {
+		return nil, status.Error(codes.Aborted, "domain changed during verification")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := s.audit(ctx, t.ID, "tenant.verify_domain"); err != nil {
+		return nil, err
+	}
+	return s.reloadTenant(ctx, t.ID)
+}
+
+// domainChallenge derives the challenge value for the tenant's current
+// domain, so changing the domain invalidates a published record
+func (s *TenantService) domainChallenge(t *tenant.Tenant) string {
+	mac := hmac.New(sha256.New, []byte(s.DomainChallengeSecret))
+	mac.Write([]byte("domain-challenge\x00" + t.ID + "\x00" + t.Domain))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// SuspendTenant blocks sign-in and token refresh for the tenant's users
+func (s *TenantService) SuspendTenant(ctx context.Context, req *proto.SuspendTenantRequest) (*proto.Tenant, error) {
+	if req.TenantId == "" || req.Reason == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	if err := s.requireOperator(ctx); err != nil {
+		return nil, err
+	}
+	t, err := s.findTenant(ctx, req.TenantId)
+	if err != nil {
+		return nil, err
+	}
+	if t.ID == s.SupportTenantID {
+		return nil, status.Error(codes.FailedPrecondition, "the support tenant cannot be suspended")
+	}
+	if t.Status == tenant.StatusSuspended {
+		return nil, status.Error(codes.FailedPrecondition, "tenant is already suspended")
+	}
+
+	suspended := tenant.StatusSuspended
+	if err := s.TenantRepo.Update(ctx, t.ID, &tenant.UpdateTenant{Status: &suspended, SuspendedReason: &req.Reason}); err != nil {
+		return nil, err
+	}
+	if err := s.audit(ctx, t.ID, "tenant.suspend"); err != nil {
+		return nil, err
+	}
+	return s.reloadTenant(ctx, t.ID)
+}
+
+// ReactivateTenant lifts a suspension
+func (s *TenantService) ReactivateTenant(ctx context.Context, req *proto.ReactivateTenantRequest) (*proto.Tenant, error) {
+	if req.TenantId == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	if err := s.requireOperator(ctx); err != nil {
+		return nil, err
+	}
+	t, err := s.findTenant(ctx, req.TenantId)
+	if err != nil {
+		return nil, err
+	}
+	if t.Status != tenant.StatusSuspended {
+		return nil, status.Error(codes.FailedPrecondition, "tenant is not suspended")
+	}
+
+	active, noReason := tenant.StatusActive, ""
+	if err := s.TenantRepo.Update(ctx, t.ID, &tenant.UpdateTenant{Status: &active, SuspendedReason: &noReason}); err != nil {
+		return nil, err
+	}
+	if err := s.audit(ctx, t.ID, "tenant.reactivate"); err != nil {
+		return nil, err
+	}
+	return s.reloadTenant(ctx, t.ID)
+}
+
+// ScheduleTenantDeletion marks the tenant for deletion once the cooling-off
+// window has passed
+func (s *TenantService) ScheduleTenantDeletion(ctx context.Context, req *proto.ScheduleTenantDeletionRequest) (*proto.Tenant, error) {
+	caller, t, err := s.targetTenant(ctx, req.TenantId, auth.PermManageTenant)
+	if err != nil {
+		return nil, err
+	}
+	if caller.ImpersonationID != "" {
+		return nil, status.Error(codes.PermissionDenied, "not allowed while impersonating")
+	}
+	if t.ID == s.SupportTenantID {
+		return nil, status.Error(codes.FailedPrecondition, "the support tenant cannot be deleted")
+	}
+	if t.DeletionScheduledAt != nil {
+		return nil, status.Error(codes.FailedPrecondition, "deletion is already scheduled")
+	}
+
+	at := time.Now().Add(s.DeletionCoolingOff)
+	if err := s.TenantRepo.Update(ctx, t.ID, &tenant.UpdateTenant{DeletionScheduledAt: &at}); err != nil {
+		return nil, err
+	}
+	if err := s.audit(ctx, t.ID, "tenant.deletion_scheduled"); err != nil {
+		return nil, err
+	}
+	return s.reloadTenant(ctx, t.ID)
+}
+
+// CancelTenantDeletion keeps a tenant whose deletion is still pending
+func (s *TenantService) CancelTenantDeletion(ctx context.Context, req *proto.CancelTenantDeletionRequest) (*proto.Tenant, error) {
+	_, t, err := s.targetTenant(ctx, req.TenantId, auth.PermManageTenant)
+	if err != nil {
+		return nil, err
+	}
+	if t.DeletionScheduledAt == nil {
+		return nil, status.Error(codes.FailedPrecondition, "deletion is not scheduled")
+	}
+	if err := s.TenantRepo.Update(ctx, t.ID, &tenant.UpdateTenant{CancelDeletion: true}); err != nil {
+		return nil, err
+	}
+	if err := s.audit(ctx, t.ID, "tenant.deletion_cancelled"); err != nil {
+		return nil, err
+	}
+	return s.reloadTenant(ctx, t.ID)
+}
+
+// DeleteTenant purges a tenant whose cooling-off window has passed, along
+// with its users, roles and identity provider configuration
+func (s *TenantService) DeleteTenant(ctx context.Context, req *proto.DeleteTenantRequest) (*proto.DeleteTenantResponse, error) {
+	caller, t, err := s.targetTenant(ctx, req.TenantId, auth.PermManageTenant)
+	if err != nil {
+		return nil, err
+	}
+	if caller.ImpersonationID != "" {
+		return nil, status.Error(codes.PermissionDenied, "not allowed while impersonating")
+	}
+	if t.DeletionScheduledAt == nil {
+		return nil, status.Error(codes.FailedPrecondition, "deletion must be scheduled first")
+	}
+	if time.Now().Before(*t.DeletionScheduledAt) {
+		return nil, status.Errorf(codes.FailedPrecondition, "tenant cannot be deleted before %s", t.DeletionScheduledAt.UTC().Format(time.RFC3339))
+	}
+
+	if err := s.purge(ctx, t.ID); err != nil {
+		return nil, err
+	}
+	return &proto.DeleteTenantResponse{Success: true}, nil
+}
+
+// PurgeDueTenants deletes every tenant whose cooling-off window has passed
+// and returns how many were removed
+func (s *TenantService) PurgeDueTenants(ctx context.Context) (int, error) {
+	due, err := s.TenantRepo.ListDueForDeletion(ctx, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	purged := 0
+	for _, t := range due {
+		if err := s.purge(ctx, t.ID); err != nil {
+			return purged, err
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+func (s *TenantService) purge(ctx context.Context, tenantID string) error {
+	// Record first so the entry survives with its tenant cleared
+	if err := s.audit(ctx, tenantID, "tenant.delete"); err != nil {
+		return err
+	}
+	err := s.TenantRepo.Delete(ctx, tenantID)
+	if errors.Is(err, tenant.ErrTenantNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "tenant purged", "tenant", tenantID)
+	return nil
+}
+
+// targetTenant resolves the tenant an RPC acts on: the caller's own when
+// tenantID is empty or matches it, which requires perm unless perm is empty,
+// otherwise any tenant for an operator
+func (s *TenantService) targetTenant(ctx context.Context, tenantID, perm string) (*auth.Claims, *tenant.Tenant, error) {
+	caller := auth.ClaimsFromContext(ctx)
+	if caller == nil {
+		return nil, nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	if tenantID == "" || tenantID == caller.TenantID {
+		if caller.TenantID == "" {
+			return nil, nil, status.Error(codes.FailedPrecondition, "not a member of a tenant")
+		}
+		if perm != "" {
+			if _, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, perm); err != nil {
+				return nil, nil, err
+			}
+		}
+		t, err := s.findTenant(ctx, caller.TenantID)
+		return caller, t, err
+	}
+
+	if err := s.requireOperator(ctx); err != nil {
+		return nil, nil, err
+	}
+	t, err := s.findTenant(ctx, tenantID)
+	return caller, t, err
+}
+
+// requireOperator checks that the caller is support staff allowed to manage
+// other tenants. Delegated tokens never qualify.
+func (s *TenantService) requireOperator(ctx context.Context) error {
+	caller := auth.ClaimsFromContext(ctx)
+	if caller == nil {
+		return status.Error(codes.Unauthenticated, "authentication required")
+	}
+	if s.SupportTenantID == "" || caller.TenantID != s.SupportTenantID || caller.ImpersonationID != "" || caller.Act != nil {
+		return status.Error(codes.PermissionDenied, "permission denied")
+	}
+	ok, err := hasPermission(ctx, s.UserRepo, s.RoleRepo, caller.UserID, auth.PermOperateTenants)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return status.Error(codes.PermissionDenied, "permission denied")
+	}
+	return nil
+}
+
+func (s *TenantService) findTenant(ctx context.Context, tenantID string) (*tenant.Tenant, error) {
+	t, err := s.TenantRepo.FindById(ctx, tenantID)
+	if errors.Is(err, tenant.ErrTenantNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return t, err
+}
+
+func (s *TenantService) reloadTenant(ctx context.Context, tenantID string) (*proto.Tenant, error) {
+	t, err := s.findTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return toProtoTenant(t), nil
+}
+
+// audit records a lifecycle change against the affected tenant
+func (s *TenantService) audit(ctx context.Context, tenantID, action string) error {
+	entry := auditLog(ctx, action)
+	entry.TenantID = &tenantID
+	return s.AuditRepo.Create(ctx, entry)
+}
+
+func toProtoTenant(t *tenant.Tenant) *proto.Tenant {
+	pt := &proto.Tenant{
+		Id:                 t.ID,
+		Name:               t.Name,
+		Domain:             t.Domain,
+		DomainVerified:     t.DomainVerified,
+		Status:             t.Status,
+		SuspendedReason:    t.SuspendedReason,
+		AllowImpersonation: t.AllowImpersonation,
+		CreatedAt:          t.CreatedAt.Unix(),
+		UpdatedAt:          t.UpdatedAt.Unix(),
+	}
+	if t.DeletionScheduledAt != nil {
+		pt.DeletionScheduledAt = t.DeletionScheduledAt.Unix()
+	}
+	return pt
+}
@@ -1,22 +1,38 @@
 package service
 
 import (
+	"auth-haven/internal/auth"
+	refreshtoken "auth-haven/internal/domain/refresh_token"
 	"auth-haven/internal/domain/role"
 	"auth-haven/internal/domain/tenant"
 	"auth-haven/internal/domain/user"
+	"auth-haven/internal/metrics"
+	"auth-haven/internal/notify"
 	"auth-haven/internal/utils"
 	proto "auth-haven/pkg/proto"
 	common "auth-haven/pkg/proto/common"
 	"context"
 	"errors"
+	"strings"
 	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	minPasswordLength = 8
+	emailChangeTTL    = 24 * time.Hour
 )
 
 type UserService struct {
 	proto.UnimplementedUserServiceServer
-	UserRepo   user.UserRepository
-	TenantRepo tenant.TenantRepository
-	RoleRepo   role.RoleRepository
+	UserRepo         user.UserRepository
+	TenantRepo       tenant.TenantRepository
+	RoleRepo         role.RoleRepository
+	EmailChangeRepo  user.EmailChangeRepository
+	RefreshTokenRepo refreshtoken.RefreshTokenRepository
+	Notifier         notify.Sender
 }
 
 // CreatePersonalUser creates a simple user without a company
@@ -24,7 +40,7 @@ func (s *UserService) CreatePersonalUser(ctx context.Context, req *proto.CreateP
 	if req.Email == "" || req.Password == "" || req.FullName == "" {
 		return nil, errors.New("missing required fields")
 	}
-	hashed, err := utils.Hash(req.Password)
+	hashed, err := utils.Hash(ctx, req.Password)
 	if err != nil {
 		return nil, err
 	}
@@ -58,7 +74,7 @@ func (s *UserService) CreateCompanyAndOwner(ctx context.Context, req *proto.Crea
 	newTenant := &tenant.Tenant{
 		Name:   req.CompanyName,
 		Domain: req.CompanyDomain,
-		Status: "active",
+		Status: tenant.StatusActive,
 	}
 	createdTenant, err := s.TenantRepo.Create(ctx, newTenant)
 	if err != nil {
@@ -76,7 +92,7 @@ func (s *UserService) CreateCompanyAndOwner(ctx context.Context, req *proto.Crea
 	if err != nil {
 		return nil, err
 	}
-	hashed, err := utils.Hash(req.OwnerPassword)
+	hashed, err := utils.Hash(ctx, req.OwnerPassword)
 	if err != nil {
 		return nil, err
 	}
@@ -102,3 +118,345 @@ func (s *UserService) CreateCompanyAndOwner(ctx context.Context, req *proto.Crea
 
 	return tokens, nil
 }
+
+// GetUser returns the caller, or a user of the caller's tenant
+func (s *UserService) GetUser(ctx context.Context, req *proto.GetUserRequest) (*proto.User, error) {
+	_, u, err := s.targetUser(ctx, req.UserId, auth.PermReadUsers)
+	if err != nil {
+		return nil, err
+	}
+	return toProtoUser(u), nil
+}
+
+// ListUsers pages through the caller's tenant users
+func (s *UserService) ListUsers(ctx context.Context, req *proto.ListUsersRequest) (*proto.ListUsersResponse, error) {
+	caller, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermReadUsers)
+	if err != nil {
+		return nil, err
+	}
+	offset, limit, err := pageBounds(req.PageSize, req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	users, total, err := s.UserRepo.List(ctx, caller.TenantID, user.ListFilter{
+		Email:  req.Email,
+		Status: req.Status,
+		Offset: offset,
+		Limit:  limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp := &proto.ListUsersResponse{
+		TotalSize:     int32(total),
+		NextPageToken: nextPageToken(offset, len(users), total),
+	}
+	for _, u := range users {
+		resp.Users = append(resp.Users, toProtoUser(u))
+	}
+	return resp, nil
+}
+
+// UpdateUser edits a profile. Changing a role always requires users.manage,
+// including on one's own account, and the caller must be able to grant the
+// new role.
+func (s *UserService) UpdateUser(ctx context.Context, req *proto.UpdateUserRequest) (*proto.User, error) {
+	if req.FullName == nil && req.RoleId == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	caller, u, err := s.manageUser(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	update := &user.UpdateUser{FullName: req.FullName}
+	if req.RoleId != nil {
+		if _, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, auth.PermManageUsers); err != nil {
+			return nil, err
+		}
+		if *req.RoleId != 0 {
+			r, err := s.RoleRepo.FindById(ctx, *req.RoleId)
+			if errors.Is(err, role.ErrRoleNotFound) || (err == nil && r.TenantID != caller.TenantID) {
+				return nil, status.Error(codes.InvalidArgument, role.ErrRoleNotFound.Error())
+			}
+			if err != nil {
+				return nil, err
+			}
+			if _, err := grantablePermissions(ctx, s.UserRepo, s.RoleRepo, caller.UserID, permissionNames(r.Permissions)); err != nil {
+				return nil, err
+			}
+		}
+		if *req.RoleId != u.RoleId {
+			err := ensureOwnerRemains(ctx, s.RoleRepo, u.TenantID, func(g role.OwnerGrant) bool {
+				return g.UserID == u.ID && g.Primary
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		update.RoleId = req.RoleId
+	}
+	if err := s.UserRepo.Update(ctx, u.ID, update); err != nil {
+		return nil, err
+	}
+	return s.reloadUser(ctx, u.ID)
+}
+
+// ChangeEmail starts an address change. The new address only replaces the
+// current one once the token sent to it is confirmed.
+func (s *UserService) ChangeEmail(ctx context.Context, req *proto.ChangeEmailRequest) (*proto.ChangeEmailResponse, error) {
+	if req.NewEmail == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	at := strings.LastIndex(req.NewEmail, "@")
+	if at <= 0 || at == len(req.NewEmail)-1 {
+		return nil, status.Error(codes.InvalidArgument, "invalid email address")
+	}
+	caller, u, err := s.manageUser(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if caller.ImpersonationID != "" {
+		return nil, status.Error(codes.PermissionDenied, "not allowed while impersonating")
+	}
+	if _, err := s.UserRepo.FindByEmail(ctx, u.TenantID, req.NewEmail); err == nil {
+		return nil, status.Error(codes.AlreadyExists, user.ErrEmailAlreadyExists.Error())
+	} else if !errors.Is(err, user.ErrUserNotFound) {
+		return nil, err
+	}
+
+	token, err := utils.RandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	change, err := s.EmailChangeRepo.Create(ctx, &user.EmailChange{
+		UserID:    u.ID,
+		NewEmail:  req.NewEmail,
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: time.Now().Add(emailChangeTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+	body := "Use this code to confirm your new email address: " + token +
+		"\n\nIf you did not ask for this change, ignore this message."
+	if err := s.Notifier.Send(ctx, req.NewEmail, "Confirm your new email address", body); err != nil {
+		return nil, err
+	}
+	return &proto.ChangeEmailResponse{ExpiresAt: change.ExpiresAt.Unix()}, nil
+}
+
+// ConfirmEmailChange applies a pending address change; the token is the
+// proof of ownership, so no session is needed
+func (s *UserService) ConfirmEmailChange(ctx context.Context, req *proto.ConfirmEmailChangeRequest) (*proto.User, error) {
+	if req.Token == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	change, err := s.EmailChangeRepo.Consume(ctx, utils.HashToken(req.Token))
+	if errors.Is(err, user.ErrEmailChangeNotFound) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = s.UserRepo.Update(ctx, change.UserID, &user.UpdateUser{Email: &change.NewEmail})
+	if errors.Is(err, user.ErrEmailAlreadyExists) {
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.reloadUser(ctx, change.UserID)
+}
+
+// ChangePassword replaces the caller's own password after checking the
+// current one, and signs out their other sessions
+func (s *UserService) ChangePassword(ctx context.Context, req *proto.ChangePasswordRequest) (*proto.ChangePasswordResponse, error) {
+	if req.CurrentPassword == "" || req.NewPassword == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	if len(req.NewPassword) < minPasswordLength {
+		return nil, status.Errorf(codes.InvalidArgument, "password must be at least %d characters", minPasswordLength)
+	}
+	caller, u, err := s.targetUser(ctx, "", "")
+	if err != nil {
+		return nil, err
+	}
+	if caller.ImpersonationID != "" {
+		return nil, status.Error(codes.PermissionDenied, "not allowed while impersonating")
+	}
+	if u.PasswordHash == "" {
+		return nil, status.Error(codes.FailedPrecondition, "account signs in through an external identity provider")
+	}
+	if utils.CheckPassword(ctx, u.PasswordHash, req.CurrentPassword) != nil {
+		return nil, status.Error(codes.PermissionDenied, "current password is incorrect")
+	}
+
+	hashed, err := utils.Hash(ctx, req.NewPassword)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.UserRepo.Update(ctx, u.ID, &user.UpdateUser{PasswordHash: &hashed}); err != nil {
+		return nil, err
+	}
+	if err := s.RefreshTokenRepo.RevokeAllForUser(ctx, u.ID); err != nil {
+		return nil, err
+	}
+	metrics.CountToken(metrics.TokenRevoked)
+	return &proto.ChangePasswordResponse{Success: true}, nil
+}
+
+// DeactivateUser blocks a user from signing in and revokes their refresh tokens
+func (s *UserService) DeactivateUser(ctx context.Context, req *proto.DeactivateUserRequest) (*proto.User, error) {
+	u, err := s.manageOther(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	err = ensureOwnerRemains(ctx, s.RoleRepo, u.TenantID, func(g role.OwnerGrant) bool {
+		return g.UserID == u.ID
+	})
+	if err != nil {
+		return nil, err
+	}
+	deactivated := user.StatusDeactivated
+	if err := s.UserRepo.Update(ctx, u.ID, &user.UpdateUser{Status: &deactivated}); err != nil {
+		return nil, err
+	}
+	if err := s.RefreshTokenRepo.RevokeAllForUser(ctx, u.ID); err != nil {
+		return nil, err
+	}
+	metrics.CountToken(metrics.TokenRevoked)
+	return s.reloadUser(ctx, u.ID)
+}
+
+// ReactivateUser lets a deactivated user sign in again
+func (s *UserService) ReactivateUser(ctx context.Context, req *proto.ReactivateUserRequest) (*proto.User, error) {
+	u, err := s.manageOther(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	active := user.StatusActive
+	if err := s.UserRepo.Update(ctx, u.ID, &user.UpdateUser{Status: &active}); err != nil {
+		return nil, err
+	}
+	return s.reloadUser(ctx, u.ID)
+}
+
+// DeleteUser permanently removes a user of the caller's tenant
+func (s *UserService) DeleteUser(ctx context.Context, req *proto.DeleteUserRequest) (*proto.DeleteUserResponse, error) {
+	u, err := s.manageOther(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	err = ensureOwnerRemains(ctx, s.RoleRepo, u.TenantID, func(g role.OwnerGrant) bool {
+		return g.UserID == u.ID
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.UserRepo.Delete(ctx, u.ID); err != nil {
+		return nil, err
+	}
+	return &proto.DeleteUserResponse{Success: true}, nil
+}
+
+// targetUser resolves the user an RPC acts on: the caller when userID is empty
+// or their own ID, otherwise a user of the caller's tenant, which requires perm
+func (s *UserService) targetUser(ctx context.Context, userID, perm string) (*auth.Claims, *user.User, error) {
+	caller := auth.ClaimsFromContext(ctx)
+	if caller == nil {
+		return nil, nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	if userID == "" || userID == caller.UserID {
+		u, err := s.UserRepo.FindById(ctx, caller.UserID)
+		if errors.Is(err, user.ErrUserNotFound) {
+			return nil, nil, status.Error(codes.Unauthenticated, "authentication required")
+		}
+		return caller, u, err
+	}
+
+	if perm == "" {
+		return nil, nil, status.Error(codes.PermissionDenied, "permission denied")
+	}
+	if _, err := requireTenantPermission(ctx, s.UserRepo, s.RoleRepo, perm); err != nil {
+		return nil, nil, err
+	}
+	u, err := s.UserRepo.FindById(ctx, userID)
+	if errors.Is(err, user.ErrUserNotFound) || (err == nil && u.TenantID != caller.TenantID) {
+		return nil, nil, status.Error(codes.NotFound, user.ErrUserNotFound.Error())
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return caller, u, nil
+}
+
+// manageUser resolves the user a change applies to, like targetUser with
+// users.manage. Another user can only be managed by a caller who could grant
+// every permission that user holds, so that users.manage never reaches an
+// owner's account.
+func (s *UserService) manageUser(ctx context.Context, userID string) (*auth.Claims, *user.User, error) {
+	caller, u, err := s.targetUser(ctx, userID, auth.PermManageUsers)
+	if err != nil || u.ID == caller.UserID {
+		return caller, u, err
+	}
+	held, err := s.RoleRepo.ListByUser(ctx, u.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	perms := []string{}
+	for _, r := range held {
+		perms = append(perms, permissionNames(r.Permissions)...)
+	}
+	_, err = grantablePermissions(ctx, s.UserRepo, s.RoleRepo, caller.UserID, perms)
+	if status.Code(err) == codes.PermissionDenied {
+		return nil, nil, status.Error(codes.PermissionDenied, "cannot manage a user holding permissions you could not grant")
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return caller, u, nil
+}
+
+// manageOther resolves a user of the caller's tenant for an administrative
+// action that may not be applied to oneself
+func (s *UserService) manageOther(ctx context.Context, userID string) (*user.User, error) {
+	if userID == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	caller, u, err := s.manageUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if u.ID == caller.UserID {
+		return nil, status.Error(codes.FailedPrecondition, "cannot apply this action to your own account")
+	}
+	return u, nil
+}
+
+func (s *UserService) reloadUser(ctx context.Context, userID string) (*proto.User, error) {
+	u, err := s.UserRepo.FindById(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return toProtoUser(u), nil
+}
+
+func toProtoUser(u *user.User) *proto.User {
+	pu := &proto.User{
+		Id:        u.ID,
+		TenantId:  u.TenantID,
+		Email:     u.Email,
+		FullName:  u.FullName,
+		Status:    u.Status,
+		RoleId:    u.RoleId,
+		OrgUnitId: u.OrgUnitID,
+		CreatedAt: u.CreatedAt.Unix(),
+		UpdatedAt: u.UpdatedAt.Unix(),
+	}
+	if u.LastLoginAt != nil {
+		pu.LastLoginAt = u.LastLoginAt.Unix()
+	}
+	return pu
+}
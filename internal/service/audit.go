@@ -0,0 +1,27 @@
+package service
+
+import (
+	"auth-haven/internal/auth"
+	"auth-haven/internal/domain/audit"
+	"context"
+)
+
+// auditLog starts an audit entry for action attributed to the caller in ctx
+func auditLog(ctx context.Context, action string) *audit.AuditLog {
+	l := (&audit.AuditLog{Action: action}).FromRequest(ctx)
+	c := auth.ClaimsFromContext(ctx)
+	if c == nil {
+		return l
+	}
+	if c.UserID != "" {
+		l.UserID = &c.UserID
+	}
+	if c.TenantID != "" {
+		l.TenantID = &c.TenantID
+	}
+	if c.ImpersonationID != "" && c.Act != nil {
+		l.ImpersonatorID = &c.Act.Subject
+		l.ImpersonationID = &c.ImpersonationID
+	}
+	return l
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"auth-haven/internal/abac"
+	"auth-haven/internal/auth"
+	"auth-haven/internal/domain/role"
+	"auth-haven/internal/domain/user"
+	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// requireTenantPermission checks that the caller belongs to a tenant and holds
+// perm there, returning the caller's claims
+func requireTenantPermission(ctx context.Context, users user.UserRepository, roles role.RoleRepository, perm string) (*auth.Claims, error) {
+	caller := auth.ClaimsFromContext(ctx)
+	if caller == nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	if caller.TenantID == "" {
+		return nil, status.Error(codes.PermissionDenied, "permission denied")
+	}
+	ok, err := hasPermission(ctx, users, roles, caller.UserID, perm)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "permission denied")
+	}
+	return caller, nil
+}
+
+// policyDecider is implemented by role repositories whose grants are subject
+// to attribute-based policies
+type policyDecider interface {
+	Decide(ctx context.Context, principal *user.User, perm string, resource map[string]any) (*abac.Decision, error)
+}
+
+// hasPermission reports whether any of the user's roles grants perm, subject
+// to the tenant's policies when roles enforces them
+func hasPermission(ctx context.Context, users user.UserRepository, roles role.RoleRepository, userID, perm string) (bool, error) {
+	u, err := users.FindById(ctx, userID)
+	if errors.Is(err, user.ErrUserNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if u.Status != user.StatusActive {
+		return false, nil
+	}
+	if d, ok := roles.(policyDecider); ok {
+		decision, err := d.Decide(ctx, u, perm, nil)
+		if err != nil {
+			return false, err
+		}
+		return decision.Allowed, nil
+	}
+	held, err := roles.ListByUser(ctx, u.ID)
+	if err != nil {
+		return false, err
+	}
+	for _, r := range held {
+		if auth.HasPermission(r.Permissions, perm) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// grantablePermissions validates perms and checks that the caller holds each
+// of them, returning the permission document to store. A permission the
+// caller holds only under a policy condition cannot be granted, as the
+// grant would carry it without the condition.
+func grantablePermissions(ctx context.Context, users user.UserRepository, roles role.RoleRepository, callerID string, perms []string) ([]byte, error) {
+	doc := map[string]bool{}
+	for _, p := range perms {
+		p = strings.TrimSpace(p)
+		if p == "" || strings.ContainsAny(p, " \t") {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid permission %q", p)
+		}
+		doc[p] = true
+	}
+	names := slices.Sorted(maps.Keys(doc))
+
+	u, err := users.FindById(ctx, callerID)
+	if errors.Is(err, user.ErrUserNotFound) {
+		return nil, status.Error(codes.PermissionDenied, "permission denied")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if u.Status != user.StatusActive {
+		return nil, status.Error(codes.PermissionDenied, "permission denied")
+	}
+	if d, ok := roles.(policyDecider); ok {
+		for _, p := range names {
+			decision, err := d.Decide(ctx, u, p, nil)
+			if err != nil {
+				return nil, err
+			}
+			if !decision.Allowed {
+				return nil, status.Errorf(codes.PermissionDenied, "cannot grant permission %q you do not hold", p)
+			}
+			if !decision.Unconditional() {
+				return nil, status.Errorf(codes.PermissionDenied, "cannot grant permission %q you hold only conditionally", p)
+			}
+		}
+		return json.Marshal(doc)
+	}
+	held, err := roles.ListByUser(ctx, u.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range names {
+		if !slices.ContainsFunc(held, func(h *role.Role) bool { return auth.HasPermission(h.Permissions, p) }) {
+			return nil, status.Errorf(codes.PermissionDenied, "cannot grant permission %q you do not hold", p)
+		}
+	}
+	return json.Marshal(doc)
+}
+
+// requireGrantableRoleNames resolves roles of the caller's tenant by name and
+// checks that the caller could grant each of them
+func requireGrantableRoleNames(ctx context.Context, users user.UserRepository, roles role.RoleRepository, caller *auth.Claims, names []string) error {
+	for _, name := range names {
+		r, err := roles.FindByTenantAndName(ctx, caller.TenantID, name)
+		if errors.Is(err, role.ErrRoleNotFound) {
+			return status.Errorf(codes.InvalidArgument, "unknown role %q", name)
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := grantablePermissions(ctx, users, roles, caller.UserID, permissionNames(r.Permissions)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
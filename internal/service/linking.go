@@ -0,0 +1,131 @@
+package service
+
+import (
+	"auth-haven/internal/domain/identity"
+	"auth-haven/internal/domain/provisioning"
+	"auth-haven/internal/domain/role"
+	"auth-haven/internal/domain/user"
+	"auth-haven/internal/sso"
+	"context"
+	"errors"
+	"log/slog"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// identityLinker maps identities from external sources (OIDC, SAML, LDAP)
+// onto local users
+type identityLinker struct {
+	identities   identity.IdentityRepository
+	provisioning provisioning.ProvisioningRepository
+	users        user.UserRepository
+	roles        role.RoleRepository
+}
+
+// resolveUser follows an existing identity link, or links/creates the user
+// in the provider's tenant by verified email. The tenant's provisioning rules
+// are evaluated on every sign-in and the user's rule-granted roles resynced.
+func (l *identityLinker) resolveUser(ctx context.Context, tenantID, providerID, providerType string, ext *sso.ExternalIdentity) (*user.User, error) {
+	policy, decision, err := l.provisioningDecision(ctx, tenantID, ext)
+	if err != nil {
+		return nil, err
+	}
+	if decision.Denied {
+		slog.InfoContext(ctx, "external sign-in denied", "tenant", tenantID, "provider", providerID, "rule", decision.Rule)
+		return nil, status.Error(codes.PermissionDenied, sso.ErrSignInDenied.Error())
+	}
+
+	u, err := l.findOrCreateUser(ctx, policy, tenantID, providerID, providerType, ext)
+	if err != nil {
+		return nil, err
+	}
+	if err := l.syncRoles(ctx, tenantID, u, role.SourceJIT, decision.Roles); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+func (l *identityLinker) findOrCreateUser(ctx context.Context, policy *provisioning.JITPolicy, tenantID, providerID, providerType string, ext *sso.ExternalIdentity) (*user.User, error) {
+	link, err := l.identities.FindBySubject(ctx, providerID, ext.Subject)
+	if err == nil {
+		if err := l.identities.TouchLogin(ctx, link.ID); err != nil {
+			return nil, err
+		}
+		return l.users.FindById(ctx, link.UserID)
+	}
+	if !errors.Is(err, identity.ErrIdentityNotFound) {
+		return nil, err
+	}
+
+	// First login through this provider: only trust verified addresses
+	if ext.Email == "" || !ext.EmailVerified {
+		return nil, status.Error(codes.PermissionDenied, sso.ErrEmailNotVerified.Error())
+	}
+	u, err := l.users.FindByEmail(ctx, tenantID, ext.Email)
+	if errors.Is(err, user.ErrUserNotFound) {
+		if !policy.AutoCreate {
+			return nil, status.Error(codes.PermissionDenied, "no account exists and automatic provisioning is disabled")
+		}
+		u, err = l.users.Create(ctx, &user.User{
+			TenantID: tenantID,
+			Email:    ext.Email,
+			FullName: ext.Name,
+			Status:   user.StatusActive,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = l.identities.Create(ctx, &identity.Identity{
+		UserID:       u.ID,
+		ProviderID:   providerID,
+		ProviderType: providerType,
+		Subject:      ext.Subject,
+		Email:        &ext.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// provisioningDecision evaluates the tenant's JIT rules for a sign-in. Tenants
+// without a policy auto-create accounts and grant no roles.
+func (l *identityLinker) provisioningDecision(ctx context.Context, tenantID string, ext *sso.ExternalIdentity) (*provisioning.JITPolicy, sso.Decision, error) {
+	policy, err := l.provisioning.FindPolicy(ctx, tenantID)
+	if errors.Is(err, provisioning.ErrPolicyNotFound) {
+		policy, err = &provisioning.JITPolicy{TenantID: tenantID, AutoCreate: true}, nil
+	}
+	if err != nil {
+		return nil, sso.Decision{}, err
+	}
+	rules, err := sso.ParseRules(policy.Rules)
+	if err != nil {
+		return nil, sso.Decision{}, err
+	}
+	defaultRole := ""
+	if policy.DefaultRole != nil {
+		defaultRole = *policy.DefaultRole
+	}
+	return policy, sso.Evaluate(rules, defaultRole, sso.RuleClaims(ext)), nil
+}
+
+// syncRoles makes the named roles the user's complete set of memberships from
+// source, dropping grants that no longer apply
+func (l *identityLinker) syncRoles(ctx context.Context, tenantID string, u *user.User, source string, names []string) error {
+	ids := []int64{}
+	for _, name := range names {
+		r, err := l.roles.FindByTenantAndName(ctx, tenantID, name)
+		if errors.Is(err, role.ErrRoleNotFound) {
+			slog.WarnContext(ctx, "role grant skipped: role not found", "tenant", tenantID, "source", source, "role", name)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		ids = append(ids, r.ID)
+	}
+	return l.roles.SyncGrants(ctx, u.ID, source, ids)
+}
@@ -0,0 +1,22 @@
+package service
+
+import (
+	"auth-haven/internal/domain/role"
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var errLastOwner = status.Error(codes.FailedPrecondition, role.ErrLastOwner.Error())
+
+// ensureOwnerRemains fails when dropping the owner grants matched by removed
+// would leave the tenant without an active user holding an owner role
+func ensureOwnerRemains(ctx context.Context, roles role.RoleRepository, tenantID string, removed func(role.OwnerGrant) bool) error {
+	err := role.EnsureOwnerRemains(ctx, roles, tenantID, removed)
+	if errors.Is(err, role.ErrLastOwner) {
+		return errLastOwner
+	}
+	return err
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"strconv"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 200
+)
+
+// pageBounds turns a request's page size and offset token into an offset and
+// limit
+func pageBounds(pageSize int32, pageToken string) (offset, limit int, err error) {
+	limit = int(pageSize)
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+	limit = min(limit, maxPageSize)
+	if pageToken != "" {
+		if offset, err = strconv.Atoi(pageToken); err != nil || offset < 0 {
+			return 0, 0, status.Error(codes.InvalidArgument, "invalid page token")
+		}
+	}
+	return offset, limit, nil
+}
+
+// nextPageToken returns the token for the page after one of n items starting
+// at offset, or "" when it was the last
+func nextPageToken(offset, n, total int) string {
+	if next := offset + n; next < total {
+		return strconv.Itoa(next)
+	}
+	return ""
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"auth-haven/internal/auth"
+	refreshtoken "auth-haven/internal/domain/refresh_token"
+	"auth-haven/internal/domain/tenant"
+	"auth-haven/internal/domain/user"
+	"auth-haven/internal/metrics"
+	"auth-haven/internal/utils"
+	common "auth-haven/pkg/proto/common"
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TokenTTLs are the lifetimes of the tokens issued at sign-in
+type TokenTTLs struct {
+	Access  time.Duration
+	Refresh time.Duration
+}
+
+// issueTokens signs an access token for u and stores a new refresh token
+func issueTokens(ctx context.Context, secret string, ttls TokenTTLs, refreshTokens refreshtoken.RefreshTokenRepository, u *user.User) (*common.Tokens, error) {
+	access, err := auth.GenerateToken(secret, u.ID, u.TenantID, ttls.Access)
+	if err != nil {
+		return nil, err
+	}
+	refresh, err := utils.RandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	_, err = refreshTokens.Create(ctx, &refreshtoken.RefreshToken{
+		UserID:    u.ID,
+		TokenHash: utils.HashToken(refresh),
+		ExpiresAt: time.Now().Add(ttls.Refresh),
+	})
+	if err != nil {
+		return nil, err
+	}
+	metrics.CountToken(metrics.TokenIssued)
+	return &common.Tokens{
+		AccessToken:  access,
+		RefreshToken: refresh,
+	}, nil
+}
+
+// ensureTenantActive refuses tokens to users of a suspended tenant. Personal
+// users have no tenant to check.
+func ensureTenantActive(ctx context.Context, tenants tenant.TenantRepository, tenantID string) error {
+	if tenantID == "" {
+		return nil
+	}
+	t, err := tenants.FindById(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	if t.Status != tenant.StatusActive {
+		return status.Error(codes.PermissionDenied, "tenant is suspended")
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package siem
+
+import (
+	"auth-haven/internal/domain/audit"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"time"
+)
+
+// SinkConfig describes one destination for audit events. Sinks are listed
+// in a JSON file:
+//
+//	[
+//	  {"name": "soc", "type": "syslog", "network": "tls", "address": "siem.example.com:6514",
+//	   "format": "cef", "filter": {"outcomes": ["PermissionDenied", "Unauthenticated"]}},
+//	  {"name": "acme", "type": "http", "url": "https://logs.acme.example/ingest",
+//	   "headers": {"Authorization": "Bearer ..."}, "filter": {"tenant_ids": ["..."]}}
+//	]
+type SinkConfig struct {
+	Name string `json:"name"`
+	Type string `json:"type"` // syslog or http
+
+	// syslog: network is tcp, udp or tls; format is rfc5424 or cef
+	Network string `json:"network,omitempty"`
+	Address string `json:"address,omitempty"`
+	Format  string `json:"format,omitempty"`
+	// tls: CA bundle to trust instead of the system roots
+	CAFile string `json:"ca_file,omitempty"`
+
+	// http: events are POSTed as a JSON array
+	URL     string            `json:"url,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+
+	Filter Filter `json:"filter"`
+
+	// Attempts is how often a batch is tried before it goes to the dead-letter
+	// file; the delay between attempts doubles from RetryDelay
+	Attempts   int      `json:"attempts,omitempty"`
+	RetryDelay Duration `json:"retry_delay,omitempty"`
+}
+
+// Filter selects the events a sink receives; empty lists match everything.
+// Actions are path.Match patterns such as "/auth.AuthService/*".
+type Filter struct {
+	TenantIDs []string `json:"tenant_ids,omitempty"`
+	Actions   []string `json:"actions,omitempty"`
+	Outcomes  []string `json:"outcomes,omitempty"`
+}
+
+// Match reports whether the filter selects l
+func (f Filter) Match(l *audit.AuditLog) bool {
+	if len(f.TenantIDs) > 0 && !slices.Contains(f.TenantIDs, l.Chain()) {
+		return false
+	}
+	if len(f.Outcomes) > 0 && (l.Outcome == nil || !slices.Contains(f.Outcomes, *l.Outcome)) {
+		return false
+	}
+	if len(f.Actions) > 0 && !slices.ContainsFunc(f.Actions, func(p string) bool {
+		ok, _ := path.Match(p, l.Action)
+		return ok
+	}) {
+		return false
+	}
+	return true
+}
+
+// Duration reads a time.Duration from a string such as "500ms"
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+const (
+	defaultAttempts   = 5
+	defaultRetryDelay = 500 * time.Millisecond
+)
+
+// LoadConfig reads and checks a sink configuration file
+func LoadConfig(file string) ([]SinkConfig, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var sinks []SinkConfig
+	if err := json.Unmarshal(b, &sinks); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	names := map[string]bool{}
+	for i := range sinks {
+		s := &sinks[i]
+		if err := s.validate(); err != nil {
+			return nil, fmt.Errorf("%s: sink %d: %w", file, i, err)
+		}
+		if names[s.Name] {
+			return nil, fmt.Errorf("%s: sink %q declared twice", file, s.Name)
+		}
+		names[s.Name] = true
+		if s.Attempts <= 0 {
+			s.Attempts = defaultAttempts
+		}
+		if s.RetryDelay <= 0 {
+			s.RetryDelay = Duration(defaultRetryDelay)
+		}
+	}
+	return sinks, nil
+}
+
+func (s *SinkConfig) validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("missing name")
+	}
+	for _, p := range s.Filter.Actions {
+		if _, err := path.Match(p, ""); err != nil {
+			return fmt.Errorf("invalid action pattern %q", p)
+		}
+	}
+	switch s.Type {
+	case "syslog":
+		if !slices.Contains([]string{"tcp", "udp", "tls"}, s.Network) {
+			return fmt.Errorf("network must be tcp, udp or tls")
+		}
+		if s.Address == "" {
+			return fmt.Errorf("missing address")
+		}
+		if s.Format == "" {
+			s.Format = "rfc5424"
+		}
+		if s.Format != "rfc5424" && s.Format != "cef" {
+			return fmt.Errorf("syslog format must be rfc5424 or cef")
+		}
+	case "http":
+		if s.URL == "" {
+			return fmt.Errorf("missing url")
+		}
+		if s.Format != "" && s.Format != "json" {
+			return fmt.Errorf("http format must be json")
+		}
+	default:
+		return fmt.Errorf("type must be syslog or http")
+	}
+	return nil
+}
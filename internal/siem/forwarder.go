@@ -0,0 +1,218 @@
+package siem
+
+import (
+	"auth-haven/internal/domain/audit"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// sinkQueueSize bounds the events waiting for one sink
+	sinkQueueSize = 10_000
+	// sinkBatchSize is the most events sent to a sink at once
+	sinkBatchSize = 100
+	maxRetryDelay = 30 * time.Second
+)
+
+// Forwarder fans audit events out to the configured sinks as they are
+// written. Every sink has its own queue and worker, so a slow or failing
+// sink holds up neither the others nor the audit pipeline. Events a sink
+// cannot take, because its queue is full or delivery kept failing, are
+// appended to the dead-letter file.
+type Forwarder struct {
+	workers    []*worker
+	deadLetter *deadLetter
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+type worker struct {
+	cfg   SinkConfig
+	sink  Sink
+	queue chan *audit.AuditLog
+	done  chan struct{}
+
+	sent         atomic.Uint64
+	deadLettered atomic.Uint64
+}
+
+// SinkStats are one sink's counters
+type SinkStats struct {
+	Name         string
+	Queued       int
+	Sent         uint64
+	DeadLettered uint64
+}
+
+// NewForwarder opens the sinks and starts their workers
+func NewForwarder(cfgs []SinkConfig, deadLetterFile string) (*Forwarder, error) {
+	f := &Forwarder{deadLetter: &deadLetter{path: deadLetterFile}}
+	for _, cfg := range cfgs {
+		sink, err := NewSink(cfg)
+		if err != nil {
+			f.Close(context.Background())
+			return nil, err
+		}
+		w := &worker{
+			cfg:   cfg,
+			sink:  sink,
+			queue: make(chan *audit.AuditLog, sinkQueueSize),
+			done:  make(chan struct{}),
+		}
+		f.workers = append(f.workers, w)
+		go w.run(f.deadLetter)
+	}
+	return f, nil
+}
+
+// Publish hands written entries to every sink whose filter selects them. It
+// never blocks.
+func (f *Forwarder) Publish(logs []*audit.AuditLog) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	if f.closed {
+		return
+	}
+	for _, w := range f.workers {
+		var overflow []*audit.AuditLog
+		for _, l := range logs {
+			if !w.cfg.Filter.Match(l) {
+				continue
+			}
+			select {
+			case w.queue <- l:
+			default:
+				overflow = append(overflow, l)
+			}
+		}
+		if len(overflow) > 0 {
+			w.deadLettered.Add(uint64(len(overflow)))
+			f.deadLetter.write(w.cfg.Name, errors.New("sink queue is full"), overflow)
+		}
+	}
+}
+
+// Stats returns each sink's counters
+func (f *Forwarder) Stats() []SinkStats {
+	stats := make([]SinkStats, len(f.workers))
+	for i, w := range f.workers {
+		stats[i] = SinkStats{
+			Name:         w.cfg.Name,
+			Queued:       len(w.queue),
+			Sent:         w.sent.Load(),
+			DeadLettered: w.deadLettered.Load(),
+		}
+	}
+	return stats
+}
+
+// Close stops accepting events and waits until the queued ones are delivered
+// or dead-lettered, or ctx ends
+func (f *Forwarder) Close(ctx context.Context) error {
+	f.mu.Lock()
+	if !f.closed {
+		f.closed = true
+		for _, w := range f.workers {
+			close(w.queue)
+		}
+	}
+	f.mu.Unlock()
+
+	for _, w := range f.workers {
+		select {
+		case <-w.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func (w *worker) run(dl *deadLetter) {
+	defer close(w.done)
+	defer w.sink.Close()
+	for l := range w.queue {
+		batch := []*audit.AuditLog{l}
+	fill:
+		for len(batch) < sinkBatchSize {
+			select {
+			case next, ok := <-w.queue:
+				if !ok {
+					break fill
+				}
+				batch = append(batch, next)
+			default:
+				break fill
+			}
+		}
+		if err := w.deliver(batch); err != nil {
+			slog.Error("siem sink failed, dead-lettering events", "sink", w.cfg.Name, "count", len(batch), "error", err)
+			w.deadLettered.Add(uint64(len(batch)))
+			dl.write(w.cfg.Name, err, batch)
+			continue
+		}
+		w.sent.Add(uint64(len(batch)))
+	}
+}
+
+// deliver sends a batch, retrying with exponential backoff
+func (w *worker) deliver(batch []*audit.AuditLog) error {
+	delay := time.Duration(w.cfg.RetryDelay)
+	var err error
+	for attempt := range w.cfg.Attempts {
+		if attempt > 0 {
+			time.Sleep(delay)
+			delay = min(delay*2, maxRetryDelay)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), sinkTimeout)
+		err = w.sink.Send(ctx, batch)
+		cancel()
+		if err == nil || isPermanent(err) {
+			return err
+		}
+	}
+	return err
+}
+
+// deadLetter appends undeliverable events to a JSON Lines file, one line per
+// event with the sink and the last error
+type deadLetter struct {
+	path string
+	mu   sync.Mutex
+}
+
+type deadLetterEntry struct {
+	Sink  string          `json:"sink"`
+	Error string          `json:"error"`
+	At    time.Time       `json:"at"`
+	Event *audit.AuditLog `json:"event"`
+}
+
+func (d *deadLetter) write(sink string, cause error, logs []*audit.AuditLog) {
+	if d.path == "" {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	f, err := os.OpenFile(d.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		slog.Error("siem: cannot open dead-letter file", "error", err)
+		return
+	}
+	defer f.Close()
+	enc := json.NewEncoder(f)
+	now := time.Now().UTC()
+	for _, l := range logs {
+		if err := enc.Encode(deadLetterEntry{Sink: sink, Error: cause.Error(), At: now, Event: l}); err != nil {
+			slog.Error("siem: cannot write dead-letter file", "error", err)
+			return
+		}
+	}
+}
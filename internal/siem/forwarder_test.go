@@ -0,0 +1,141 @@
+package siem
+
+import (
+	"auth-haven/internal/domain/audit"
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// collector is an HTTP sink endpoint that answers with the queued statuses,
+// then 200, and counts the events it accepts
+type collector struct {
+	*httptest.Server
+	statuses chan int
+	received atomic.Int64
+}
+
+func newCollector(t *testing.T, statuses ...int) *collector {
+	t.Helper()
+	c := &collector{statuses: make(chan int, len(statuses))}
+	for _, s := range statuses {
+		c.statuses <- s
+	}
+	c.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case s := <-c.statuses:
+			w.WriteHeader(s)
+			return
+		default:
+		}
+		var events []jsonEvent
+		if err := json.NewDecoder(r.Body).Decode(&events); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		c.received.Add(int64(len(events)))
+	}))
+	t.Cleanup(c.Close)
+	return c
+}
+
+func httpSinkConfig(name, url string) SinkConfig {
+	return SinkConfig{Name: name, Type: "http", URL: url, Attempts: 3, RetryDelay: Duration(time.Millisecond)}
+}
+
+func closeForwarder(t *testing.T, f *Forwarder) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := f.Close(ctx); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+}
+
+func TestForwarderRetriesThenDelivers(t *testing.T) {
+	soc := newCollector(t, http.StatusServiceUnavailable, http.StatusTooManyRequests)
+	filtered := newCollector(t)
+	deadLetters := filepath.Join(t.TempDir(), "dead.jsonl")
+
+	skip := httpSinkConfig("filtered", filtered.URL)
+	skip.Filter = Filter{Outcomes: []string{"OK"}}
+	f, err := NewForwarder([]SinkConfig{httpSinkConfig("soc", soc.URL), skip}, deadLetters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Publish([]*audit.AuditLog{testLog("e1"), testLog("e2")})
+	closeForwarder(t, f)
+
+	if n := soc.received.Load(); n != 2 {
+		t.Errorf("soc received %d events, want 2", n)
+	}
+	if n := filtered.received.Load(); n != 0 {
+		t.Errorf("filtered sink received %d events, want 0", n)
+	}
+	stats := f.Stats()
+	if stats[0].Sent != 2 || stats[0].DeadLettered != 0 || stats[1].Sent != 0 {
+		t.Errorf("Stats() = %+v", stats)
+	}
+	if _, err := os.Stat(deadLetters); !os.IsNotExist(err) {
+		t.Errorf("dead-letter file was written: %v", err)
+	}
+}
+
+func TestForwarderDeadLetters(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []int
+	}{
+		// rejected outright, without retrying
+		{name: "permanent", statuses: []int{http.StatusBadRequest}},
+		// every attempt fails
+		{name: "exhausted", statuses: []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			soc := newCollector(t, tt.statuses...)
+			deadLetters := filepath.Join(t.TempDir(), "dead.jsonl")
+			f, err := NewForwarder([]SinkConfig{httpSinkConfig("soc", soc.URL)}, deadLetters)
+			if err != nil {
+				t.Fatal(err)
+			}
+			f.Publish([]*audit.AuditLog{testLog("e1")})
+			closeForwarder(t, f)
+
+			if len(soc.statuses) != 0 {
+				t.Errorf("%d responses left unused", len(soc.statuses))
+			}
+			if n := soc.received.Load(); n != 0 {
+				t.Errorf("soc accepted %d events, want 0", n)
+			}
+			if stats := f.Stats(); stats[0].Sent != 0 || stats[0].DeadLettered != 1 {
+				t.Errorf("Stats() = %+v", stats)
+			}
+
+			file, err := os.Open(deadLetters)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer file.Close()
+			var entries []deadLetterEntry
+			scanner := bufio.NewScanner(file)
+			for scanner.Scan() {
+				var e deadLetterEntry
+				if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+					t.Fatal(err)
+				}
+				entries = append(entries, e)
+			}
+			if len(entries) != 1 || entries[0].Sink != "soc" || entries[0].Event.ID != "e1" || entries[0].Error == "" {
+				t.Errorf("dead-letter entries = %+v", entries)
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package siem
+
+import (
+	"auth-haven/internal/domain/audit"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Sink delivers batches of audit events to one destination
+type Sink interface {
+	Send(ctx context.Context, logs []*audit.AuditLog) error
+	Close() error
+}
+
+// permanentError marks a failure that retrying will not fix
+type permanentError struct{ error }
+
+func (e permanentError) Unwrap() error { return e.error }
+
+const sinkTimeout = 10 * time.Second
+
+// NewSink opens the sink a configuration describes
+func NewSink(cfg SinkConfig) (Sink, error) {
+	switch cfg.Type {
+	case "syslog":
+		return newSyslogSink(cfg)
+	case "http":
+		return &HTTPSink{URL: cfg.URL, Headers: cfg.Headers, Client: &http.Client{Timeout: sinkTimeout}}, nil
+	}
+	return nil, fmt.Errorf("unknown sink type %q", cfg.Type)
+}
+
+// SyslogSink sends RFC 5424 messages over UDP, TCP or TLS. Stream transports
+// use octet-counting framing (RFC 6587); UDP sends a datagram per event. The
+// connection is reopened after a failure.
+type SyslogSink struct {
+	network  string
+	address  string
+	tls      *tls.Config
+	cef      bool
+	hostname string
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+func newSyslogSink(cfg SinkConfig) (*SyslogSink, error) {
+	s := &SyslogSink{network: cfg.Network, address: cfg.Address, cef: cfg.Format == "cef"}
+	s.hostname, _ = os.Hostname()
+	if cfg.Network == "tls" {
+		host, _, err := net.SplitHostPort(cfg.Address)
+		if err != nil {
+			return nil, err
+		}
+		s.tls = &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}
+		if cfg.CAFile != "" {
+			pem, err := os.ReadFile(cfg.CAFile)
+			if err != nil {
+				return nil, err
+			}
+			s.tls.RootCAs = x509.NewCertPool()
+			if !s.tls.RootCAs.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("%s: no certificates found", cfg.CAFile)
+			}
+		}
+	}
+	return s, nil
+}
+
+func (s *SyslogSink) dial(ctx context.Context) (net.Conn, error) {
+	d := &net.Dialer{Timeout: sinkTimeout}
+	if s.tls != nil {
+		return (&tls.Dialer{NetDialer: d, Config: s.tls}).DialContext(ctx, "tcp", s.address)
+	}
+	return d.DialContext(ctx, s.network, s.address)
+}
+
+// Send implements Sink.
+func (s *SyslogSink) Send(ctx context.Context, logs []*audit.AuditLog) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		conn, err := s.dial(ctx)
+		if err != nil {
+			return err
+		}
+		s.conn = conn
+	}
+
+	var buf bytes.Buffer
+	for _, l := range logs {
+		msg := ""
+		if s.cef {
+			msg = FormatCEF(l)
+		}
+		line := FormatSyslog(l, s.hostname, msg)
+		if s.network == "udp" {
+			buf.Reset()
+			buf.WriteString(line)
+			if err := s.write(buf.Bytes()); err != nil {
+				return err
+			}
+			continue
+		}
+		buf.WriteString(strconv.Itoa(len(line)))
+		buf.WriteByte(' ')
+		buf.WriteString(line)
+	}
+	if s.network == "udp" {
+		return nil
+	}
+	return s.write(buf.Bytes())
+}
+
+func (s *SyslogSink) write(b []byte) error {
+	s.conn.SetWriteDeadline(time.Now().Add(sinkTimeout))
+	if _, err := s.conn.Write(b); err != nil {
+		s.conn.Close()
+		s.conn = nil
+		return err
+	}
+	return nil
+}
+
+// Close implements Sink.
+func (s *SyslogSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// HTTPSink POSTs each batch as a JSON array. Server errors and 429 are
+// retried; other client errors are not.
+type HTTPSink struct {
+	URL     string
+	Headers map[string]string
+	Client  *http.Client
+}
+
+// Send implements Sink.
+func (s *HTTPSink) Send(ctx context.Context, logs []*audit.AuditLog) error {
+	body, err := FormatJSON(logs)
+	if err != nil {
+		return permanentError{err}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return permanentError{err}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range s.Headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	switch {
+	case resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return fmt.Errorf("sink responded %s", resp.Status)
+	}
+	return permanentError{fmt.Errorf("sink responded %s", resp.Status)}
+}
+
+// Close implements Sink.
+func (s *HTTPSink) Close() error {
+	s.Client.CloseIdleConnections()
+	return nil
+}
+
+func isPermanent(err error) bool {
+	var p permanentError
+	return errors.As(err, &p)
+}
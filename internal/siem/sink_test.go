@@ -0,0 +1,192 @@
+package siem
+
+import (
+	"auth-haven/internal/domain/audit"
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testLog(id string) *audit.AuditLog {
+	tenant, outcome := "tenant-1", "PermissionDenied"
+	return &audit.AuditLog{
+		ID:        id,
+		TenantID:  &tenant,
+		Action:    "/user.UserService/DeleteUser",
+		Outcome:   &outcome,
+		CreatedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+}
+
+// readFrame reads one octet-counted syslog message (RFC 6587)
+func readFrame(t *testing.T, conn net.Conn, r *bufio.Reader) string {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var n int
+	if _, err := fmt.Fscanf(r, "%d ", &n); err != nil {
+		t.Fatalf("read frame length: %v", err)
+	}
+	msg := make([]byte, n)
+	if _, err := io.ReadFull(r, msg); err != nil {
+		t.Fatalf("read frame: %v", err)
+	}
+	return string(msg)
+}
+
+func listenTCP(t *testing.T) net.Listener {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	return ln
+}
+
+func accept(t *testing.T, ln net.Listener) net.Conn {
+	t.Helper()
+	ln.(*net.TCPListener).SetDeadline(time.Now().Add(5 * time.Second))
+	conn, err := ln.Accept()
+	if err != nil {
+		t.Fatalf("accept: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestSyslogSinkDelivers(t *testing.T) {
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{format: "rfc5424", want: " /user.UserService/DeleteUser"},
+		{format: "cef", want: " CEF:0|auth-haven|auth-haven|1|/user.UserService/DeleteUser|"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			ln := listenTCP(t)
+			sink, err := NewSink(SinkConfig{Type: "syslog", Network: "tcp", Address: ln.Addr().String(), Format: tt.format})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer sink.Close()
+
+			if err := sink.Send(context.Background(), []*audit.AuditLog{testLog("e1"), testLog("e2")}); err != nil {
+				t.Fatalf("Send() error = %v", err)
+			}
+			conn := accept(t, ln)
+			r := bufio.NewReader(conn)
+			for _, id := range []string{"e1", "e2"} {
+				msg := readFrame(t, conn, r)
+				// authpriv.warning
+				if !strings.HasPrefix(msg, "<84>1 2026-01-02T03:04:05Z ") {
+					t.Errorf("message header = %q", msg)
+				}
+				if !strings.Contains(msg, `[audit@32473 id="`+id+`" tenant="tenant-1"`) {
+					t.Errorf("message %q lacks structured data for %s", msg, id)
+				}
+				if !strings.Contains(msg, tt.want) {
+					t.Errorf("message %q does not contain %q", msg, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestSyslogSinkReconnects(t *testing.T) {
+	ln := listenTCP(t)
+	sink, err := NewSink(SinkConfig{Type: "syslog", Network: "tcp", Address: ln.Addr().String()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+	ctx := context.Background()
+
+	if err := sink.Send(ctx, []*audit.AuditLog{testLog("e1")}); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	first := accept(t, ln)
+	if msg := readFrame(t, first, bufio.NewReader(first)); !strings.Contains(msg, `id="e1"`) {
+		t.Fatalf("first connection got %q", msg)
+	}
+	first.Close()
+
+	// The collector going away only shows once a write fails; the forwarder
+	// retries that batch
+	failed := false
+	for range 50 {
+		if err := sink.Send(ctx, []*audit.AuditLog{testLog("lost")}); err != nil {
+			failed = true
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !failed {
+		t.Fatal("Send() kept succeeding after the collector closed the connection")
+	}
+
+	if err := sink.Send(ctx, []*audit.AuditLog{testLog("e2")}); err != nil {
+		t.Fatalf("Send() after reconnect error = %v", err)
+	}
+	second := accept(t, ln)
+	if msg := readFrame(t, second, bufio.NewReader(second)); !strings.Contains(msg, `id="e2"`) {
+		t.Fatalf("second connection got %q", msg)
+	}
+}
+
+func TestHTTPSink(t *testing.T) {
+	tests := []struct {
+		status        int
+		wantErr       bool
+		wantPermanent bool
+	}{
+		{status: http.StatusAccepted},
+		{status: http.StatusTooManyRequests, wantErr: true},
+		{status: http.StatusServiceUnavailable, wantErr: true},
+		{status: http.StatusBadRequest, wantErr: true, wantPermanent: true},
+		{status: http.StatusUnauthorized, wantErr: true, wantPermanent: true},
+	}
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			var got []jsonEvent
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Authorization") != "Bearer token" {
+					t.Errorf("Authorization = %q", r.Header.Get("Authorization"))
+				}
+				if r.Header.Get("Content-Type") != "application/json" {
+					t.Errorf("Content-Type = %q", r.Header.Get("Content-Type"))
+				}
+				if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+					t.Errorf("decode body: %v", err)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			sink, err := NewSink(SinkConfig{Type: "http", URL: srv.URL, Headers: map[string]string{"Authorization": "Bearer token"}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer sink.Close()
+
+			err = sink.Send(context.Background(), []*audit.AuditLog{testLog("e1"), testLog("e2")})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Send() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if isPermanent(err) != tt.wantPermanent {
+				t.Errorf("isPermanent(%v) = %v, want %v", err, isPermanent(err), tt.wantPermanent)
+			}
+			if len(got) != 2 || got[0].ID != "e1" || got[1].ID != "e2" || got[0].Severity != 7 {
+				t.Errorf("posted events = %+v", got)
+			}
+		})
+	}
+}
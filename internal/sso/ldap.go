@@ -0,0 +1,228 @@
+package sso
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+const ldapTimeout = 10 * time.Second
+
+var (
+	ErrLDAPUserNotFound      = errors.New("user not found in directory")
+	ErrLDAPInvalidCredential = errors.New("directory rejected the credentials")
+	ErrLDAPInsecure          = errors.New("ldap:// URLs require StartTLS; use ldaps:// or enable start_tls")
+)
+
+// LDAPConfig describes one directory server
+type LDAPConfig struct {
+	URL          string
+	StartTLS     bool
+	CACert       []byte // PEM; nil trusts the system roots
+	BindDN       string // empty binds anonymously for the user search
+	BindPassword string
+	UserBaseDN   string
+	UserFilter   string // %s is replaced by the escaped login name
+	Attributes   LDAPAttributes
+}
+
+// LDAPAttributes names the directory attributes that carry each local field.
+// Empty fields fall back to the defaults noted.
+type LDAPAttributes struct {
+	Subject string `json:"subject,omitempty"` // stable ID; defaults to the entry DN
+	Email   string `json:"email,omitempty"`   // mail
+	Name    string `json:"name,omitempty"`    // displayName, then cn
+	Groups  string `json:"groups,omitempty"`  // memberOf
+}
+
+// ParseLDAPAttributes decodes a stored mapping document
+func ParseLDAPAttributes(doc []byte) (LDAPAttributes, error) {
+	m := LDAPAttributes{}
+	if len(doc) == 0 {
+		return m, nil
+	}
+	err := json.Unmarshal(doc, &m)
+	return m, err
+}
+
+// ValidateLDAPConfig checks a directory configuration before it is stored
+func ValidateLDAPConfig(cfg *LDAPConfig) error {
+	u, err := url.Parse(cfg.URL)
+	if err != nil || u.Host == "" {
+		return errors.New("invalid directory URL")
+	}
+	switch u.Scheme {
+	case "ldaps":
+	case "ldap":
+		if !cfg.StartTLS {
+			return ErrLDAPInsecure
+		}
+	default:
+		return errors.New("directory URL must use ldap:// or ldaps://")
+	}
+	if _, err := ldap.ParseDN(cfg.UserBaseDN); err != nil || cfg.UserBaseDN == "" {
+		return errors.New("invalid user base DN")
+	}
+	if strings.Count(cfg.UserFilter, "%s") != 1 {
+		return errors.New("user filter must contain exactly one %s")
+	}
+	if _, err := ldap.CompileFilter(fmt.Sprintf(cfg.UserFilter, "probe")); err != nil {
+		return fmt.Errorf("invalid user filter: %w", err)
+	}
+	if len(cfg.CACert) > 0 && !x509.NewCertPool().AppendCertsFromPEM(cfg.CACert) {
+		return errors.New("ca_cert contains no PEM certificates")
+	}
+	return nil
+}
+
+// LDAPAuthenticate finds the entry for login and verifies password by binding
+// as it. It returns ErrLDAPUserNotFound when no entry matches, so callers can
+// fall back to local accounts.
+func LDAPAuthenticate(ctx context.Context, cfg *LDAPConfig, login, password string) (*ExternalIdentity, error) {
+	// An empty password would be an unauthenticated bind, which servers accept
+	if login == "" || password == "" {
+		return nil, ErrLDAPInvalidCredential
+	}
+	conn, err := dialLDAP(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if cfg.BindDN != "" {
+		if err := conn.Bind(cfg.BindDN, cfg.BindPassword); err != nil {
+			return nil, fmt.Errorf("service bind: %w", err)
+		}
+	}
+
+	attrs := cfg.Attributes
+	requested := []string{attrOr(attrs.Email, "mail"), attrOr(attrs.Groups, "memberOf"), "displayName", "cn"}
+	if attrs.Name != "" {
+		requested = append(requested, attrs.Name)
+	}
+	if attrs.Subject != "" {
+		requested = append(requested, attrs.Subject)
+	}
+	res, err := conn.Search(ldap.NewSearchRequest(
+		cfg.UserBaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases,
+		2, int(ldapTimeout/time.Second), false,
+		fmt.Sprintf(cfg.UserFilter, ldap.EscapeFilter(login)),
+		requested, nil,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("user search: %w", err)
+	}
+	switch len(res.Entries) {
+	case 0:
+		return nil, ErrLDAPUserNotFound
+	case 1:
+	default:
+		return nil, fmt.Errorf("user filter matched more than one entry for %q", login)
+	}
+	entry := res.Entries[0]
+
+	if err := conn.Bind(entry.DN, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return nil, ErrLDAPInvalidCredential
+		}
+		return nil, fmt.Errorf("user bind: %w", err)
+	}
+	return ldapIdentity(entry, attrs), nil
+}
+
+func ldapIdentity(entry *ldap.Entry, attrs LDAPAttributes) *ExternalIdentity {
+	id := &ExternalIdentity{
+		Subject: entry.DN,
+		Email:   entry.GetAttributeValue(attrOr(attrs.Email, "mail")),
+		Groups:  entry.GetAttributeValues(attrOr(attrs.Groups, "memberOf")),
+		Claims:  map[string]any{"dn": entry.DN},
+		// The directory is the tenant's own system of record
+		EmailVerified: true,
+	}
+	if attrs.Subject != "" {
+		// Binary IDs such as objectGUID are hex-encoded
+		if raw := entry.GetRawAttributeValue(attrs.Subject); len(raw) > 0 {
+			id.Subject = string(raw)
+			if !utf8.Valid(raw) {
+				id.Subject = hex.EncodeToString(raw)
+			}
+		}
+	}
+	id.Name = entry.GetAttributeValue(attrOr(attrs.Name, "displayName"))
+	if id.Name == "" {
+		id.Name = entry.GetAttributeValue("cn")
+	}
+	for _, a := range entry.Attributes {
+		if len(a.Values) == 1 {
+			id.Claims[a.Name] = a.Values[0]
+		} else {
+			id.Claims[a.Name] = a.Values
+		}
+	}
+	return id
+}
+
+// GroupCN returns the first RDN value of a group DN, or the input unchanged
+// when it is not a DN
+func GroupCN(group string) string {
+	dn, err := ldap.ParseDN(group)
+	if err != nil || len(dn.RDNs) == 0 || len(dn.RDNs[0].Attributes) == 0 {
+		return group
+	}
+	return dn.RDNs[0].Attributes[0].Value
+}
+
+func dialLDAP(ctx context.Context, cfg *LDAPConfig) (*ldap.Conn, error) {
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{ServerName: u.Hostname(), MinVersion: tls.VersionTLS12}
+	if len(cfg.CACert) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(cfg.CACert) {
+			return nil, errors.New("ca_cert contains no PEM certificates")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	dialer := &net.Dialer{Timeout: ldapTimeout}
+	if deadline, ok := ctx.Deadline(); ok {
+		dialer.Deadline = deadline
+	}
+	conn, err := ldap.DialURL(cfg.URL, ldap.DialWithDialer(dialer), ldap.DialWithTLSConfig(tlsConfig))
+	if err != nil {
+		return nil, fmt.Errorf("connect: %w", err)
+	}
+	conn.SetTimeout(ldapTimeout)
+
+	if u.Scheme == "ldap" {
+		if !cfg.StartTLS {
+			conn.Close()
+			return nil, ErrLDAPInsecure
+		}
+		if err := conn.StartTLS(tlsConfig); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("starttls: %w", err)
+		}
+	}
+	return conn, nil
+}
+
+func attrOr(mapped, std string) string {
+	if mapped != "" {
+		return mapped
+	}
+	return std
+}
@@ -0,0 +1,302 @@
+package sso
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+)
+
+// ldapEntry is one directory object served by fakeDirectory
+type ldapEntry struct {
+	dn       string
+	password string
+	attrs    map[string][]string
+}
+
+// fakeDirectory is an in-process LDAPS server answering just the simple
+// binds and searches LDAPAuthenticate makes. Search filters are matched
+// verbatim against the keys of entries.
+type fakeDirectory struct {
+	url     string
+	caCert  []byte
+	entries map[string]ldapEntry
+	binds   map[string]string // DN -> password, for service accounts
+}
+
+func newFakeDirectory(t *testing.T) *fakeDirectory {
+	t.Helper()
+	cert, caPEM := testServerCert(t)
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: []tls.Certificate{cert}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	d := &fakeDirectory{
+		url:     "ldaps://" + ln.Addr().String(),
+		caCert:  caPEM,
+		entries: map[string]ldapEntry{},
+		binds:   map[string]string{},
+	}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go d.serve(conn)
+		}
+	}()
+	return d
+}
+
+func (d *fakeDirectory) serve(conn net.Conn) {
+	defer conn.Close()
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil || len(packet.Children) < 2 {
+			return
+		}
+		id := packet.Children[0].Value.(int64)
+		op := packet.Children[1]
+		switch op.Tag {
+		case ldap.ApplicationBindRequest:
+			dn := op.Children[1].Value.(string)
+			password := op.Children[2].Data.String()
+			code := ldap.LDAPResultInvalidCredentials
+			if d.authenticate(dn, password) {
+				code = ldap.LDAPResultSuccess
+			}
+			d.reply(conn, id, ldapResult(ldap.ApplicationBindResponse, code))
+		case ldap.ApplicationSearchRequest:
+			filter, err := ldap.DecompileFilter(op.Children[6])
+			if err != nil {
+				return
+			}
+			if e, ok := d.entries[filter]; ok {
+				d.reply(conn, id, searchEntry(e))
+			}
+			d.reply(conn, id, ldapResult(ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess))
+		default:
+			return
+		}
+	}
+}
+
+func (d *fakeDirectory) authenticate(dn, password string) bool {
+	if p, ok := d.binds[dn]; ok {
+		return p == password
+	}
+	for _, e := range d.entries {
+		if e.dn == dn {
+			return e.password == password
+		}
+	}
+	return false
+}
+
+func (d *fakeDirectory) reply(conn net.Conn, id int64, op *ber.Packet) {
+	envelope := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+	envelope.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, "Message ID"))
+	envelope.AppendChild(op)
+	_, _ = conn.Write(envelope.Bytes())
+}
+
+func ldapResult(tag ber.Tag, code int) *ber.Packet {
+	p := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "Result")
+	p.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(code), "Result Code"))
+	p.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Matched DN"))
+	p.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Diagnostic Message"))
+	return p
+}
+
+func searchEntry(e ldapEntry) *ber.Packet {
+	p := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "Search Result Entry")
+	p.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, e.dn, "Object Name"))
+	attrs := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attributes")
+	for name, values := range e.attrs {
+		attr := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attribute")
+		attr.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, "Type"))
+		vals := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "Values")
+		for _, v := range values {
+			vals.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, v, "Value"))
+		}
+		attr.AppendChild(vals)
+		attrs.AppendChild(attr)
+	}
+	p.AppendChild(attrs)
+	return p
+}
+
+// testServerCert issues a self-signed certificate for 127.0.0.1 and returns
+// it with its PEM encoding, to be trusted as the CA
+func testServerCert(t *testing.T) (tls.Certificate, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key},
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func testLDAPConfig(d *fakeDirectory) *LDAPConfig {
+	return &LDAPConfig{
+		URL:          d.url,
+		CACert:       d.caCert,
+		BindDN:       "cn=service,dc=example,dc=com",
+		BindPassword: "service-secret",
+		UserBaseDN:   "ou=people,dc=example,dc=com",
+		UserFilter:   "(uid=%s)",
+	}
+}
+
+func TestLDAPAuthenticate(t *testing.T) {
+	d := newFakeDirectory(t)
+	d.binds["cn=service,dc=example,dc=com"] = "service-secret"
+	d.entries["(uid=jane)"] = ldapEntry{
+		dn:       "uid=jane,ou=people,dc=example,dc=com",
+		password: "correct horse",
+		attrs: map[string][]string{
+			"mail":        {"jane@example.com"},
+			"displayName": {"Jane Doe"},
+			"memberOf":    {"cn=engineering,ou=groups,dc=example,dc=com"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		cfg      func(*LDAPConfig)
+		login    string
+		password string
+		wantErr  error  // matched with errors.Is
+		wantMsg  string // matched against the message otherwise
+	}{
+		{name: "valid", login: "jane", password: "correct horse"},
+		{name: "wrong password", login: "jane", password: "wrong", wantErr: ErrLDAPInvalidCredential},
+		{name: "empty password", login: "jane", password: "", wantErr: ErrLDAPInvalidCredential},
+		{name: "unknown user", login: "john", password: "correct horse", wantErr: ErrLDAPUserNotFound},
+		{
+			name:     "service bind rejected",
+			cfg:      func(c *LDAPConfig) { c.BindPassword = "wrong" },
+			login:    "jane",
+			password: "correct horse",
+			wantMsg:  "service bind",
+		},
+		{
+			name:     "untrusted certificate",
+			cfg:      func(c *LDAPConfig) { c.CACert = nil },
+			login:    "jane",
+			password: "correct horse",
+			wantMsg:  "connect",
+		},
+		{
+			name:     "plaintext without starttls",
+			cfg:      func(c *LDAPConfig) { c.URL = strings.Replace(c.URL, "ldaps://", "ldap://", 1) },
+			login:    "jane",
+			password: "correct horse",
+			wantErr:  ErrLDAPInsecure,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testLDAPConfig(d)
+			if tt.cfg != nil {
+				tt.cfg(cfg)
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			id, err := LDAPAuthenticate(ctx, cfg, tt.login, tt.password)
+			switch {
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("LDAPAuthenticate() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			case tt.wantMsg != "":
+				if err == nil || !strings.Contains(err.Error(), tt.wantMsg) {
+					t.Fatalf("LDAPAuthenticate() error = %v, want %q", err, tt.wantMsg)
+				}
+				return
+			case err != nil:
+				t.Fatalf("LDAPAuthenticate() error = %v", err)
+			}
+			if id.Subject != "uid=jane,ou=people,dc=example,dc=com" || id.Email != "jane@example.com" || id.Name != "Jane Doe" {
+				t.Errorf("LDAPAuthenticate() identity = %+v", id)
+			}
+			if len(id.Groups) != 1 || GroupCN(id.Groups[0]) != "engineering" {
+				t.Errorf("LDAPAuthenticate() groups = %v", id.Groups)
+			}
+		})
+	}
+}
+
+func TestValidateLDAPConfig(t *testing.T) {
+	valid := func() *LDAPConfig {
+		return &LDAPConfig{URL: "ldaps://ldap.example.com", UserBaseDN: "dc=example,dc=com", UserFilter: "(uid=%s)"}
+	}
+	tests := []struct {
+		name    string
+		edit    func(*LDAPConfig)
+		wantErr bool
+	}{
+		{name: "valid", edit: func(*LDAPConfig) {}},
+		{name: "starttls", edit: func(c *LDAPConfig) { c.URL = "ldap://ldap.example.com"; c.StartTLS = true }},
+		{name: "plaintext", edit: func(c *LDAPConfig) { c.URL = "ldap://ldap.example.com" }, wantErr: true},
+		{name: "other scheme", edit: func(c *LDAPConfig) { c.URL = "https://ldap.example.com" }, wantErr: true},
+		{name: "bad base dn", edit: func(c *LDAPConfig) { c.UserBaseDN = "not a dn" }, wantErr: true},
+		{name: "filter without placeholder", edit: func(c *LDAPConfig) { c.UserFilter = "(uid=jane)" }, wantErr: true},
+		{name: "malformed filter", edit: func(c *LDAPConfig) { c.UserFilter = "uid=%s)(" }, wantErr: true},
+		{name: "bad ca", edit: func(c *LDAPConfig) { c.CACert = []byte("not pem") }, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid()
+			tt.edit(cfg)
+			if err := ValidateLDAPConfig(cfg); (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateLDAPConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGroupCN(t *testing.T) {
+	for in, want := range map[string]string{
+		"cn=engineering,ou=groups,dc=example,dc=com": "engineering",
+		"engineering": "engineering",
+	} {
+		if got := GroupCN(in); got != want {
+			t.Errorf("GroupCN(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
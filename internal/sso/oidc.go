@@ -0,0 +1,157 @@
+package sso
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+var (
+	ErrEmailNotVerified = errors.New("upstream provider did not verify the email address")
+	ErrNonceMismatch    = errors.New("id token nonce does not match")
+)
+
+// OIDCConfig describes one upstream OpenID Connect provider
+type OIDCConfig struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	Claims       ClaimMapping
+}
+
+// ClaimMapping names the upstream claims that carry each local attribute.
+// Empty fields fall back to the standard OIDC claim names.
+type ClaimMapping struct {
+	Email         string `json:"email,omitempty"`
+	EmailVerified string `json:"email_verified,omitempty"`
+	Name          string `json:"name,omitempty"`
+	Groups        string `json:"groups,omitempty"`
+}
+
+// ParseClaimMapping decodes a stored mapping document
+func ParseClaimMapping(doc []byte) (ClaimMapping, error) {
+	m := ClaimMapping{}
+	if len(doc) == 0 {
+		return m, nil
+	}
+	err := json.Unmarshal(doc, &m)
+	return m, err
+}
+
+// ExternalIdentity is what we learned about the user from the upstream provider
+type ExternalIdentity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Groups        []string
+	Claims        map[string]any
+}
+
+// providers caches discovery documents and key sets per issuer
+var providers sync.Map
+
+func discover(ctx context.Context, issuer string) (*oidc.Provider, error) {
+	if p, ok := providers.Load(issuer); ok {
+		return p.(*oidc.Provider), nil
+	}
+	p, err := oidc.NewProvider(ctx, issuer)
+	if err != nil {
+		return nil, fmt.Errorf("oidc discovery for %s: %w", issuer, err)
+	}
+	providers.Store(issuer, p)
+	return p, nil
+}
+
+func oauthConfig(p *oidc.Provider, cfg *OIDCConfig) *oauth2.Config {
+	scopes := cfg.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{oidc.ScopeOpenID, "email", "profile"}
+	}
+	return &oauth2.Config{
+		ClientID:     cfg.ClientID,
+		ClientSecret: cfg.ClientSecret,
+		RedirectURL:  cfg.RedirectURL,
+		Endpoint:     p.Endpoint(),
+		Scopes:       scopes,
+	}
+}
+
+// AuthCodeURL builds the upstream authorization URL for the redirect leg.
+// The verifier is kept server side and proves the callback is ours (PKCE).
+func AuthCodeURL(ctx context.Context, cfg *OIDCConfig, state, nonce, verifier string) (string, error) {
+	p, err := discover(ctx, cfg.Issuer)
+	if err != nil {
+		return "", err
+	}
+	return oauthConfig(p, cfg).AuthCodeURL(state, oidc.Nonce(nonce), oauth2.S256ChallengeOption(verifier)), nil
+}
+
+// Exchange redeems the authorization code from the callback, verifies the
+// ID token and maps its claims
+func Exchange(ctx context.Context, cfg *OIDCConfig, code, nonce, verifier string) (*ExternalIdentity, error) {
+	p, err := discover(ctx, cfg.Issuer)
+	if err != nil {
+		return nil, err
+	}
+	tok, err := oauthConfig(p, cfg).Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("code exchange: %w", err)
+	}
+	raw, ok := tok.Extra("id_token").(string)
+	if !ok {
+		return nil, errors.New("token response has no id_token")
+	}
+	idToken, err := p.Verifier(&oidc.Config{ClientID: cfg.ClientID}).Verify(ctx, raw)
+	if err != nil {
+		return nil, fmt.Errorf("id token verification: %w", err)
+	}
+	if idToken.Nonce != nonce {
+		return nil, ErrNonceMismatch
+	}
+
+	claims := map[string]any{}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, err
+	}
+	return mapClaims(idToken.Subject, claims, cfg.Claims), nil
+}
+
+func mapClaims(subject string, claims map[string]any, m ClaimMapping) *ExternalIdentity {
+	name := func(mapped, std string) string {
+		if mapped != "" {
+			return mapped
+		}
+		return std
+	}
+	id := &ExternalIdentity{Subject: subject, Claims: claims}
+	id.Email, _ = claims[name(m.Email, "email")].(string)
+	id.Name, _ = claims[name(m.Name, "name")].(string)
+
+	// Some providers send email_verified as a string
+	switch v := claims[name(m.EmailVerified, "email_verified")].(type) {
+	case bool:
+		id.EmailVerified = v
+	case string:
+		id.EmailVerified = v == "true"
+	}
+
+	switch v := claims[name(m.Groups, "groups")].(type) {
+	case []any:
+		for _, g := range v {
+			if s, ok := g.(string); ok {
+				id.Groups = append(id.Groups, s)
+			}
+		}
+	case string:
+		id.Groups = []string{v}
+	}
+	return id
+}
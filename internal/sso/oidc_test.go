@@ -0,0 +1,160 @@
+package sso
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// fakeIssuer is an in-process OpenID provider. It publishes key for
+// verification and answers the token endpoint with idToken.
+type fakeIssuer struct {
+	*httptest.Server
+	key     *rsa.PrivateKey
+	idToken string
+}
+
+func newFakeIssuer(t *testing.T) *fakeIssuer {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &fakeIssuer{key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		writeTestJSON(w, map[string]any{
+			"issuer":                                f.URL,
+			"authorization_endpoint":                f.URL + "/authorize",
+			"token_endpoint":                        f.URL + "/token",
+			"jwks_uri":                              f.URL + "/jwks",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("GET /jwks", func(w http.ResponseWriter, r *http.Request) {
+		writeTestJSON(w, map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "test",
+			"alg": "RS256",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+		writeTestJSON(w, map[string]any{
+			"access_token": "upstream-access-token",
+			"token_type":   "Bearer",
+			"expires_in":   300,
+			"id_token":     f.idToken,
+		})
+	})
+	f.Server = httptest.NewServer(mux)
+	t.Cleanup(f.Close)
+	return f
+}
+
+// sign issues an ID token with claims on top of valid defaults
+func (f *fakeIssuer) sign(t *testing.T, key *rsa.PrivateKey, claims jwt.MapClaims) string {
+	t.Helper()
+	c := jwt.MapClaims{
+		"iss":            f.URL,
+		"sub":            "upstream-user",
+		"aud":            "client-id",
+		"iat":            time.Now().Unix(),
+		"exp":            time.Now().Add(time.Minute).Unix(),
+		"nonce":          "nonce",
+		"email":          "jane@example.com",
+		"email_verified": true,
+		"groups":         []string{"engineering"},
+	}
+	for k, v := range claims {
+		c[k] = v
+	}
+	tok := jwt.NewWithClaims(jwt.SigningMethodRS256, c)
+	tok.Header["kid"] = "test"
+	raw, err := tok.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return raw
+}
+
+func writeTestJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func TestExchange(t *testing.T) {
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		key     *rsa.PrivateKey // nil signs with the issuer's key
+		claims  jwt.MapClaims
+		wantErr string
+	}{
+		{name: "valid"},
+		{name: "bad signature", key: otherKey, wantErr: "id token verification"},
+		{name: "wrong audience", claims: jwt.MapClaims{"aud": "another-client"}, wantErr: "id token verification"},
+		{name: "wrong issuer", claims: jwt.MapClaims{"iss": "https://evil.example.com"}, wantErr: "id token verification"},
+		{name: "expired", claims: jwt.MapClaims{"exp": time.Now().Add(-time.Minute).Unix()}, wantErr: "id token verification"},
+		{name: "nonce mismatch", claims: jwt.MapClaims{"nonce": "replayed"}, wantErr: ErrNonceMismatch.Error()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issuer := newFakeIssuer(t)
+			key := tt.key
+			if key == nil {
+				key = issuer.key
+			}
+			issuer.idToken = issuer.sign(t, key, tt.claims)
+
+			cfg := &OIDCConfig{Issuer: issuer.URL, ClientID: "client-id", ClientSecret: "secret", RedirectURL: "https://app.example.com/callback"}
+			id, err := Exchange(context.Background(), cfg, "code", "nonce", "verifier")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Exchange() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Exchange() error = %v", err)
+			}
+			if id.Subject != "upstream-user" || id.Email != "jane@example.com" || !id.EmailVerified {
+				t.Errorf("Exchange() identity = %+v", id)
+			}
+			if len(id.Groups) != 1 || id.Groups[0] != "engineering" {
+				t.Errorf("Exchange() groups = %v, want [engineering]", id.Groups)
+			}
+		})
+	}
+}
+
+func TestMapClaims(t *testing.T) {
+	claims := map[string]any{
+		"mail":     "jane@example.com",
+		"verified": "true",
+		"roles":    "admins",
+	}
+	id := mapClaims("sub", claims, ClaimMapping{Email: "mail", EmailVerified: "verified", Groups: "roles"})
+	if id.Email != "jane@example.com" || !id.EmailVerified {
+		t.Errorf("mapClaims() = %+v", id)
+	}
+	if len(id.Groups) != 1 || id.Groups[0] != "admins" {
+		t.Errorf("mapClaims() groups = %v, want [admins]", id.Groups)
+	}
+}
@@ -0,0 +1,227 @@
+package sso
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Rule effects
+const (
+	EffectGrant = "grant"
+	EffectDeny  = "deny"
+)
+
+// Condition operators
+const (
+	OpEquals   = "equals"
+	OpContains = "contains"
+	OpMatches  = "matches"
+	OpExists   = "exists"
+)
+
+var ErrSignInDenied = errors.New("sign-in denied by provisioning rules")
+
+// Condition tests one claim. Claim may be a dotted path into nested objects;
+// a condition holds when any claim value satisfies the operator against any
+// of Values. Negate inverts the result.
+type Condition struct {
+	Claim    string   `json:"claim"`
+	Operator string   `json:"op"`
+	Values   []string `json:"values,omitempty"`
+	Negate   bool     `json:"negate,omitempty"`
+}
+
+// Rule is one entry of a tenant's just-in-time provisioning rule set. It
+// matches when all its conditions hold.
+type Rule struct {
+	Name       string      `json:"name"`
+	Effect     string      `json:"effect"`
+	Conditions []Condition `json:"conditions"`
+	Roles      []string    `json:"roles,omitempty"`
+
+	// Final stops evaluation after this rule when it matches
+	Final bool `json:"final,omitempty"`
+}
+
+// Decision is the outcome of evaluating a rule set for one sign-in
+type Decision struct {
+	Denied bool
+	Rule   string   // the deny rule that matched, if Denied
+	Roles  []string // role names to hold, in the order they were granted
+}
+
+// ParseRules decodes and validates a stored rule document
+func ParseRules(doc []byte) ([]Rule, error) {
+	rules := []Rule{}
+	if len(doc) == 0 {
+		return rules, nil
+	}
+	if err := json.Unmarshal(doc, &rules); err != nil {
+		return nil, err
+	}
+	return rules, ValidateRules(rules)
+}
+
+// ValidateRules checks effects, operators and patterns before a rule set is
+// stored, so evaluation at sign-in cannot fail
+func ValidateRules(rules []Rule) error {
+	for i, r := range rules {
+		if r.Effect != EffectGrant && r.Effect != EffectDeny {
+			return fmt.Errorf("rule %d: effect must be %q or %q", i+1, EffectGrant, EffectDeny)
+		}
+		if r.Effect == EffectGrant && len(r.Roles) == 0 {
+			return fmt.Errorf("rule %d: grant rules must name at least one role", i+1)
+		}
+		if len(r.Conditions) == 0 {
+			return fmt.Errorf("rule %d: at least one condition is required", i+1)
+		}
+		for _, c := range r.Conditions {
+			if c.Claim == "" {
+				return fmt.Errorf("rule %d: condition claim is required", i+1)
+			}
+			switch c.Operator {
+			case OpExists:
+			case OpEquals, OpContains:
+				if len(c.Values) == 0 {
+					return fmt.Errorf("rule %d: %s requires values", i+1, c.Operator)
+				}
+			case OpMatches:
+				if len(c.Values) == 0 {
+					return fmt.Errorf("rule %d: %s requires values", i+1, c.Operator)
+				}
+				for _, v := range c.Values {
+					if _, err := regexp.Compile(v); err != nil {
+						return fmt.Errorf("rule %d: invalid pattern %q: %w", i+1, v, err)
+					}
+				}
+			default:
+				return fmt.Errorf("rule %d: unknown operator %q", i+1, c.Operator)
+			}
+		}
+	}
+	return nil
+}
+
+// Evaluate runs the rules in order against claims. A matching deny rule
+// rejects the sign-in; matching grant rules accumulate roles. If no grant rule
+// matched, the default role (if any) is granted.
+func Evaluate(rules []Rule, defaultRole string, claims map[string]any) Decision {
+	d := Decision{Roles: []string{}}
+	granted := false
+	for _, r := range rules {
+		if !r.matches(claims) {
+			continue
+		}
+		if r.Effect == EffectDeny {
+			return Decision{Denied: true, Rule: r.Name}
+		}
+		granted = true
+		for _, role := range r.Roles {
+			if !slices.Contains(d.Roles, role) {
+				d.Roles = append(d.Roles, role)
+			}
+		}
+		if r.Final {
+			break
+		}
+	}
+	if !granted && defaultRole != "" {
+		d.Roles = append(d.Roles, defaultRole)
+	}
+	return d
+}
+
+// RuleClaims flattens what we know about an external identity into the claim
+// set rules are evaluated against. The normalised email, email_domain, name
+// and groups claims take precedence over upstream claims of the same name.
+func RuleClaims(ext *ExternalIdentity) map[string]any {
+	claims := map[string]any{}
+	for k, v := range ext.Claims {
+		claims[k] = v
+	}
+	claims["sub"] = ext.Subject
+	if ext.Email != "" {
+		claims["email"] = ext.Email
+		if _, domain, ok := strings.Cut(ext.Email, "@"); ok {
+			claims["email_domain"] = strings.ToLower(domain)
+		}
+	}
+	if ext.Name != "" {
+		claims["name"] = ext.Name
+	}
+	if len(ext.Groups) > 0 {
+		claims["groups"] = ext.Groups
+	}
+	return claims
+}
+
+func (r Rule) matches(claims map[string]any) bool {
+	for _, c := range r.Conditions {
+		if c.holds(claims) == c.Negate {
+			return false
+		}
+	}
+	return true
+}
+
+func (c Condition) holds(claims map[string]any) bool {
+	values, present := claimValues(claims, c.Claim)
+	if c.Operator == OpExists {
+		return present
+	}
+	for _, have := range values {
+		for _, want := range c.Values {
+			switch c.Operator {
+			case OpEquals:
+				if strings.EqualFold(have, want) {
+					return true
+				}
+			case OpContains:
+				if strings.Contains(strings.ToLower(have), strings.ToLower(want)) {
+					return true
+				}
+			case OpMatches:
+				// Patterns were validated when the rule set was stored
+				if re, err := regexp.Compile(want); err == nil && re.MatchString(have) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// claimValues resolves a dotted claim path to its values as strings
+func claimValues(claims map[string]any, path string) ([]string, bool) {
+	var cur any = claims
+	for _, part := range strings.Split(path, ".") {
+		m, ok := cur.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if cur, ok = m[part]; !ok {
+			return nil, false
+		}
+	}
+
+	switch v := cur.(type) {
+	case nil:
+		return nil, false
+	case string:
+		return []string{v}, true
+	case []string:
+		return v, true
+	case []any:
+		out := []string{}
+		for _, item := range v {
+			out = append(out, fmt.Sprint(item))
+		}
+		return out, true
+	default:
+		return []string{fmt.Sprint(v)}, true
+	}
+}
@@ -0,0 +1,225 @@
+package sso
+
+import (
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/crewjam/saml"
+	"github.com/crewjam/saml/samlsp"
+)
+
+var (
+	ErrIDPInitiatedNotAllowed = errors.New("idp-initiated login is not enabled for this provider")
+)
+
+// SAMLConfig describes our SP registration with one tenant's IdP
+type SAMLConfig struct {
+	EntityID          string
+	ACSURL            string
+	MetadataURL       string
+	IDPMetadata       []byte
+	AllowIDPInitiated bool
+	Attributes        ClaimMapping
+}
+
+// SAMLKeyPair is the SP's own signing/decryption credential. Optional: without
+// it we cannot sign AuthnRequests or read encrypted assertions.
+type SAMLKeyPair struct {
+	Key         *rsa.PrivateKey
+	Certificate *x509.Certificate
+}
+
+// LoadSAMLKeyPair reads a PEM certificate and RSA key from disk
+func LoadSAMLKeyPair(certFile, keyFile string) (*SAMLKeyPair, error) {
+	pair, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := pair.PrivateKey.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("saml sp key must be RSA")
+	}
+	cert, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return nil, err
+	}
+	return &SAMLKeyPair{Key: key, Certificate: cert}, nil
+}
+
+// ParseIDPMetadata validates uploaded IdP metadata and returns its entity ID
+func ParseIDPMetadata(doc []byte) (string, error) {
+	ed, err := samlsp.ParseMetadata(doc)
+	if err != nil {
+		return "", fmt.Errorf("invalid idp metadata: %w", err)
+	}
+	if len(ed.IDPSSODescriptors) == 0 {
+		return "", errors.New("invalid idp metadata: no IDPSSODescriptor")
+	}
+	return ed.EntityID, nil
+}
+
+// NewServiceProvider builds the SP for one provider configuration
+func NewServiceProvider(cfg *SAMLConfig, keys *SAMLKeyPair) (*saml.ServiceProvider, error) {
+	idp, err := samlsp.ParseMetadata(cfg.IDPMetadata)
+	if err != nil {
+		return nil, err
+	}
+	acs, err := url.Parse(cfg.ACSURL)
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := url.Parse(cfg.MetadataURL)
+	if err != nil {
+		return nil, err
+	}
+	sp := &saml.ServiceProvider{
+		EntityID:          cfg.EntityID,
+		AcsURL:            *acs,
+		MetadataURL:       *metadata,
+		IDPMetadata:       idp,
+		AllowIDPInitiated: cfg.AllowIDPInitiated,
+		AuthnNameIDFormat: saml.UnspecifiedNameIDFormat,
+	}
+	if keys != nil {
+		sp.Key = keys.Key
+		sp.Certificate = keys.Certificate
+	}
+	return sp, nil
+}
+
+// SAMLMetadata renders the SP metadata document for the IdP administrator
+func SAMLMetadata(sp *saml.ServiceProvider) ([]byte, error) {
+	return xml.MarshalIndent(sp.Metadata(), "", "  ")
+}
+
+// SAMLRedirect builds an SP-initiated AuthnRequest using the HTTP-Redirect
+// binding and returns the IdP URL and the request ID to expect back
+func SAMLRedirect(sp *saml.ServiceProvider, relayState string) (string, string, error) {
+	idpURL := sp.GetSSOBindingLocation(saml.HTTPRedirectBinding)
+	if idpURL == "" {
+		return "", "", errors.New("idp metadata has no HTTP-Redirect SSO endpoint")
+	}
+	req, err := sp.MakeAuthenticationRequest(idpURL, saml.HTTPRedirectBinding, saml.HTTPPostBinding)
+	if err != nil {
+		return "", "", err
+	}
+	u, err := req.Redirect(relayState, sp)
+	if err != nil {
+		return "", "", err
+	}
+	return u.String(), req.ID, nil
+}
+
+// SAMLInResponseTo peeks at the unverified response to find which request it
+// answers; empty means IdP-initiated. Only use it to look up the request.
+func SAMLInResponseTo(samlResponse string) (string, error) {
+	raw, err := base64.StdEncoding.DecodeString(samlResponse)
+	if err != nil {
+		return "", fmt.Errorf("invalid SAMLResponse encoding: %w", err)
+	}
+	var resp struct {
+		InResponseTo string `xml:",attr"`
+	}
+	if err := xml.Unmarshal(raw, &resp); err != nil {
+		return "", fmt.Errorf("invalid SAMLResponse: %w", err)
+	}
+	return resp.InResponseTo, nil
+}
+
+// ParseSAMLResponse verifies the response signature, audience, destination and
+// validity window, then maps the assertion's attributes. requestID is the
+// outstanding AuthnRequest it answers, or empty for IdP-initiated logins.
+func ParseSAMLResponse(sp *saml.ServiceProvider, samlResponse, requestID string, attrs ClaimMapping) (*ExternalIdentity, error) {
+	raw, err := base64.StdEncoding.DecodeString(samlResponse)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SAMLResponse encoding: %w", err)
+	}
+
+	// Solicited responses must answer the request we sent
+	var possibleIDs []string
+	if requestID != "" {
+		verifier := *sp
+		verifier.AllowIDPInitiated = false
+		sp = &verifier
+		possibleIDs = []string{requestID}
+	} else if !sp.AllowIDPInitiated {
+		return nil, ErrIDPInitiatedNotAllowed
+	}
+
+	assertion, err := sp.ParseXMLResponse(raw, possibleIDs)
+	if err != nil {
+		var invalid *saml.InvalidResponseError
+		if errors.As(err, &invalid) {
+			return nil, fmt.Errorf("saml response rejected: %w", invalid.PrivateErr)
+		}
+		return nil, err
+	}
+	if assertion.Subject == nil || assertion.Subject.NameID == nil || assertion.Subject.NameID.Value == "" {
+		return nil, errors.New("saml assertion has no subject")
+	}
+	return mapAttributes(assertion, attrs), nil
+}
+
+// Common attribute names used by IdPs when no mapping is configured
+var (
+	defaultEmailAttrs = []string{"email", "mail", "urn:oid:0.9.2342.19200300.100.1.3",
+		"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/emailaddress"}
+	defaultNameAttrs = []string{"name", "displayName", "urn:oid:2.16.840.1.113730.3.1.241",
+		"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/name"}
+	defaultGroupAttrs = []string{"groups", "memberOf", "http://schemas.microsoft.com/ws/2008/06/identity/claims/groups"}
+)
+
+func mapAttributes(a *saml.Assertion, m ClaimMapping) *ExternalIdentity {
+	values := map[string][]string{}
+	for _, stmt := range a.AttributeStatements {
+		for _, attr := range stmt.Attributes {
+			for _, v := range attr.Values {
+				values[attr.Name] = append(values[attr.Name], v.Value)
+				if attr.FriendlyName != "" {
+					values[attr.FriendlyName] = append(values[attr.FriendlyName], v.Value)
+				}
+			}
+		}
+	}
+	lookup := func(mapped string, defaults []string) []string {
+		if mapped != "" {
+			return values[mapped]
+		}
+		for _, name := range defaults {
+			if v, ok := values[name]; ok {
+				return v
+			}
+		}
+		return nil
+	}
+	first := func(v []string) string {
+		if len(v) == 0 {
+			return ""
+		}
+		return v[0]
+	}
+
+	claims := map[string]any{}
+	for name, v := range values {
+		claims[name] = v
+	}
+	id := &ExternalIdentity{
+		Subject: a.Subject.NameID.Value,
+		Email:   first(lookup(m.Email, defaultEmailAttrs)),
+		Name:    first(lookup(m.Name, defaultNameAttrs)),
+		Groups:  lookup(m.Groups, defaultGroupAttrs),
+		Claims:  claims,
+		// The tenant's own IdP is authoritative for its users' addresses
+		EmailVerified: true,
+	}
+	if id.Email == "" && a.Subject.NameID.Format == string(saml.EmailAddressNameIDFormat) {
+		id.Email = a.Subject.NameID.Value
+	}
+	return id
+}
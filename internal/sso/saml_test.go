@@ -0,0 +1,228 @@
+package sso
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/xml"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beevik/etree"
+	"github.com/crewjam/saml"
+)
+
+const (
+	testSPEntityID = "https://sp.example.com/saml/metadata"
+	testACSURL     = "https://sp.example.com/saml/acs"
+)
+
+// newTestIDP builds an identity provider with a freshly generated signing key
+func newTestIDP(t *testing.T) *saml.IdentityProvider {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "idp.example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	metadataURL, _ := url.Parse("https://idp.example.com/metadata")
+	ssoURL, _ := url.Parse("https://idp.example.com/sso")
+	return &saml.IdentityProvider{
+		Key:         key,
+		Certificate: cert,
+		MetadataURL: *metadataURL,
+		SSOURL:      *ssoURL,
+	}
+}
+
+func idpMetadata(t *testing.T, idp *saml.IdentityProvider) []byte {
+	t.Helper()
+	doc, err := xml.Marshal(idp.Metadata())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func newTestSP(t *testing.T, idp *saml.IdentityProvider, allowIDPInitiated bool) *saml.ServiceProvider {
+	t.Helper()
+	sp, err := NewServiceProvider(&SAMLConfig{
+		EntityID:          testSPEntityID,
+		ACSURL:            testACSURL,
+		MetadataURL:       testSPEntityID,
+		IDPMetadata:       idpMetadata(t, idp),
+		AllowIDPInitiated: allowIDPInitiated,
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sp
+}
+
+// makeResponse has idp answer requestID, or send an unsolicited response when
+// it is empty, for the SP described by spMeta
+func makeResponse(t *testing.T, idp *saml.IdentityProvider, spMeta *saml.EntityDescriptor, requestID string) string {
+	t.Helper()
+	req := &saml.IdpAuthnRequest{
+		IDP:                     idp,
+		HTTPRequest:             httptest.NewRequest(http.MethodPost, "https://idp.example.com/sso", nil),
+		Request:                 saml.AuthnRequest{ID: requestID},
+		ServiceProviderMetadata: spMeta,
+		SPSSODescriptor:         &spMeta.SPSSODescriptors[0],
+		ACSEndpoint:             &spMeta.SPSSODescriptors[0].AssertionConsumerServices[0],
+		Now:                     saml.TimeNow(),
+	}
+	session := &saml.Session{
+		ID:           "session",
+		NameID:       "jane@example.com",
+		NameIDFormat: string(saml.EmailAddressNameIDFormat),
+		CustomAttributes: []saml.Attribute{
+			{Name: "displayName", Values: []saml.AttributeValue{{Type: "xs:string", Value: "Jane Doe"}}},
+			{Name: "groups", Values: []saml.AttributeValue{{Type: "xs:string", Value: "engineering"}}},
+		},
+	}
+	if err := (saml.DefaultAssertionMaker{}).MakeAssertion(req, session); err != nil {
+		t.Fatal(err)
+	}
+	if err := req.MakeResponse(); err != nil {
+		t.Fatal(err)
+	}
+	doc := etree.NewDocument()
+	doc.SetRoot(req.ResponseEl)
+	raw, err := doc.WriteToBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.StdEncoding.EncodeToString(raw)
+}
+
+func TestParseSAMLResponse(t *testing.T) {
+	idp := newTestIDP(t)
+	sp := newTestSP(t, idp, false)
+
+	otherSP := newTestSP(t, idp, false)
+	otherSP.EntityID = "https://other.example.com/saml/metadata"
+
+	tests := []struct {
+		name      string
+		response  string
+		requestID string
+		wantErr   string
+	}{
+		{
+			name:      "valid",
+			response:  makeResponse(t, idp, sp.Metadata(), "id-1"),
+			requestID: "id-1",
+		},
+		{
+			name:      "signed by another key",
+			response:  makeResponse(t, newTestIDP(t), sp.Metadata(), "id-1"),
+			requestID: "id-1",
+			wantErr:   "saml response rejected",
+		},
+		{
+			name:      "wrong audience",
+			response:  makeResponse(t, idp, otherSP.Metadata(), "id-1"),
+			requestID: "id-1",
+			wantErr:   "saml response rejected",
+		},
+		{
+			name:      "answers another request",
+			response:  makeResponse(t, idp, sp.Metadata(), "id-2"),
+			requestID: "id-1",
+			wantErr:   "saml response rejected",
+		},
+		{
+			name:     "unsolicited",
+			response: makeResponse(t, idp, sp.Metadata(), ""),
+			wantErr:  ErrIDPInitiatedNotAllowed.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := ParseSAMLResponse(sp, tt.response, tt.requestID, ClaimMapping{})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseSAMLResponse() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSAMLResponse() error = %v", err)
+			}
+			if id.Subject != "jane@example.com" || id.Email != "jane@example.com" || id.Name != "Jane Doe" {
+				t.Errorf("ParseSAMLResponse() identity = %+v", id)
+			}
+			if len(id.Groups) != 1 || id.Groups[0] != "engineering" {
+				t.Errorf("ParseSAMLResponse() groups = %v, want [engineering]", id.Groups)
+			}
+		})
+	}
+}
+
+func TestParseSAMLResponseTampered(t *testing.T) {
+	idp := newTestIDP(t)
+	sp := newTestSP(t, idp, false)
+
+	raw, _ := base64.StdEncoding.DecodeString(makeResponse(t, idp, sp.Metadata(), "id-1"))
+	tampered := strings.Replace(string(raw), "engineering", "administrators", 1)
+	if tampered == string(raw) {
+		t.Fatal("response does not carry the group attribute")
+	}
+	_, err := ParseSAMLResponse(sp, base64.StdEncoding.EncodeToString([]byte(tampered)), "id-1", ClaimMapping{})
+	if err == nil {
+		t.Fatal("ParseSAMLResponse() accepted a modified assertion")
+	}
+}
+
+func TestParseSAMLResponseIDPInitiated(t *testing.T) {
+	idp := newTestIDP(t)
+	sp := newTestSP(t, idp, true)
+
+	id, err := ParseSAMLResponse(sp, makeResponse(t, idp, sp.Metadata(), ""), "", ClaimMapping{})
+	if err != nil {
+		t.Fatalf("ParseSAMLResponse() error = %v", err)
+	}
+	if id.Subject != "jane@example.com" {
+		t.Errorf("ParseSAMLResponse() subject = %q", id.Subject)
+	}
+
+	// A solicited response still has to answer the outstanding request
+	_, err = ParseSAMLResponse(sp, makeResponse(t, idp, sp.Metadata(), ""), "id-1", ClaimMapping{})
+	if err == nil {
+		t.Fatal("ParseSAMLResponse() accepted an unsolicited response for a pending request")
+	}
+}
+
+func TestSAMLInResponseTo(t *testing.T) {
+	idp := newTestIDP(t)
+	sp := newTestSP(t, idp, false)
+
+	got, err := SAMLInResponseTo(makeResponse(t, idp, sp.Metadata(), "id-1"))
+	if err != nil || got != "id-1" {
+		t.Fatalf("SAMLInResponseTo() = %q, %v, want id-1", got, err)
+	}
+	if _, err := SAMLInResponseTo("not base64!"); err == nil {
+		t.Fatal("SAMLInResponseTo() accepted a malformed response")
+	}
+}
@@ -1,10 +1,17 @@
 package utils
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"auth-haven/internal/tracing"
+	"context"
+
+	"golang.org/x/crypto/bcrypt"
+)
 
 // Hash hashes a plain password and returns the hash or an error
-func Hash(pswd string) (string, error) {
+func Hash(ctx context.Context, pswd string) (string, error) {
+	_, span := tracing.Start(ctx, "bcrypt.GenerateFromPassword")
 	hash, err := bcrypt.GenerateFromPassword([]byte(pswd), bcrypt.DefaultCost)
+	tracing.End(span, err)
 	if err != nil {
 		return "", err
 	}
@@ -12,6 +19,8 @@ func Hash(pswd string) (string, error) {
 }
 
 // CheckPassword compares a plain password with its hashed value
-func CheckPassword(hash, pswd string) error {
+func CheckPassword(ctx context.Context, hash, pswd string) error {
+	_, span := tracing.Start(ctx, "bcrypt.CompareHashAndPassword")
+	defer span.End()
 	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(pswd))
 }
@@ -0,0 +1,17 @@
+-- Clients allowed to call the token exchange grant
+CREATE TABLE oauth_clients (
+    client_id          VARCHAR(100) PRIMARY KEY,
+    client_secret_hash VARCHAR(255) NOT NULL,
+    name               VARCHAR(255) NOT NULL,
+    created_at         TIMESTAMP DEFAULT NOW()
+);
+
+-- Which audiences a client may exchange for, and the scopes it may request there
+CREATE TABLE token_exchange_policies (
+    policy_id         INT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    client_id         VARCHAR(100) NOT NULL REFERENCES oauth_clients(client_id) ON DELETE CASCADE,
+    audience          VARCHAR(255) NOT NULL,
+    scopes            TEXT[] NOT NULL DEFAULT '{}',
+    token_ttl_seconds INT NOT NULL DEFAULT 300,
+    UNIQUE (client_id, audience)
+);
@@ -0,0 +1,8 @@
+-- Align roles and users with the domain model: roles carry a JSON permission
+-- document and every tenant user has a primary role
+ALTER TABLE roles
+    ADD COLUMN permissions JSONB NOT NULL DEFAULT '{}',
+    ADD COLUMN created_at  TIMESTAMP DEFAULT NOW();
+
+ALTER TABLE users
+    ADD COLUMN role_id INT REFERENCES roles(role_id) ON DELETE SET NULL;
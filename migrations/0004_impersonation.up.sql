@@ -0,0 +1,22 @@
+-- Tenants can refuse support impersonation of their users
+ALTER TABLE tenants
+    ADD COLUMN allow_impersonation BOOLEAN NOT NULL DEFAULT TRUE;
+
+-- Impersonation sessions
+CREATE TABLE impersonation_sessions (
+    session_id     UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    operator_id    UUID NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    target_user_id UUID NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    tenant_id      UUID REFERENCES tenants(tenant_id) ON DELETE CASCADE,
+    reason         VARCHAR(255) NOT NULL,
+    expires_at     TIMESTAMP NOT NULL,
+    ended_at       TIMESTAMP,
+    created_at     TIMESTAMP DEFAULT NOW()
+);
+
+-- Every audit entry written under an impersonation session names the real operator
+ALTER TABLE audit_logs
+    ADD COLUMN impersonator_id  UUID REFERENCES users(user_id) ON DELETE SET NULL,
+    ADD COLUMN impersonation_id UUID REFERENCES impersonation_sessions(session_id) ON DELETE SET NULL;
+
+CREATE INDEX idx_impersonation_sessions_target ON impersonation_sessions(target_user_id);
@@ -0,0 +1,40 @@
+-- Upstream OIDC identity providers configured per tenant
+CREATE TABLE oidc_providers (
+    provider_id    UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id      UUID NOT NULL REFERENCES tenants(tenant_id) ON DELETE CASCADE,
+    name           VARCHAR(100) NOT NULL,
+    issuer         VARCHAR(255) NOT NULL,
+    client_id      VARCHAR(255) NOT NULL,
+    client_secret  VARCHAR(255) NOT NULL,
+    redirect_url   VARCHAR(2048) NOT NULL, -- our callback, as registered with the provider
+    scopes         TEXT[] NOT NULL DEFAULT '{openid,email,profile}',
+    claim_mappings JSONB NOT NULL DEFAULT '{}',
+    enabled        BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at     TIMESTAMP DEFAULT NOW(),
+    UNIQUE (tenant_id, name)
+);
+
+-- In-flight authorization requests, consumed once by the callback
+CREATE TABLE federated_login_states (
+    state         VARCHAR(64) PRIMARY KEY,
+    provider_id   UUID NOT NULL REFERENCES oidc_providers(provider_id) ON DELETE CASCADE,
+    nonce         VARCHAR(64) NOT NULL,
+    code_verifier VARCHAR(128) NOT NULL,
+    expires_at    TIMESTAMP NOT NULL,
+    created_at    TIMESTAMP DEFAULT NOW()
+);
+
+-- Links between local users and their external identities
+CREATE TABLE user_identities (
+    identity_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id     UUID NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    provider_id UUID NOT NULL REFERENCES oidc_providers(provider_id) ON DELETE CASCADE,
+    subject     VARCHAR(255) NOT NULL,
+    email       VARCHAR(255),
+    created_at  TIMESTAMP DEFAULT NOW(),
+    last_login_at TIMESTAMP,
+    UNIQUE (provider_id, subject)
+);
+
+CREATE INDEX idx_user_identities_user_id ON user_identities(user_id);
+CREATE INDEX idx_refresh_tokens_token_hash ON refresh_tokens(token_hash);
@@ -0,0 +1,32 @@
+-- SAML 2.0 identity providers configured per tenant; we act as the SP
+CREATE TABLE saml_providers (
+    provider_id         UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id           UUID NOT NULL REFERENCES tenants(tenant_id) ON DELETE CASCADE,
+    name                VARCHAR(100) NOT NULL,
+    entity_id           VARCHAR(1024) NOT NULL, -- our SP entity ID as registered with the IdP
+    acs_url             VARCHAR(2048) NOT NULL,
+    idp_metadata        TEXT NOT NULL,
+    idp_entity_id       VARCHAR(1024) NOT NULL,
+    allow_idp_initiated BOOLEAN NOT NULL DEFAULT FALSE,
+    attribute_mappings  JSONB NOT NULL DEFAULT '{}',
+    role_attribute      VARCHAR(255),
+    role_mappings       JSONB NOT NULL DEFAULT '[]', -- [{"value": "...", "role": "..."}], first match wins
+    enabled             BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at          TIMESTAMP DEFAULT NOW(),
+    UNIQUE (tenant_id, name)
+);
+
+-- Outstanding SP-initiated AuthnRequests, consumed by the matching response
+CREATE TABLE saml_requests (
+    request_id  VARCHAR(64) PRIMARY KEY,
+    provider_id UUID NOT NULL REFERENCES saml_providers(provider_id) ON DELETE CASCADE,
+    expires_at  TIMESTAMP NOT NULL,
+    created_at  TIMESTAMP DEFAULT NOW()
+);
+
+-- Identity links now point at either kind of provider. Without a foreign key
+-- the provider repositories delete a provider's links in the same statement
+-- that deletes the provider.
+ALTER TABLE user_identities
+    DROP CONSTRAINT user_identities_provider_id_fkey,
+    ADD COLUMN provider_type VARCHAR(20) NOT NULL DEFAULT 'oidc';
@@ -0,0 +1,5 @@
+-- Only verified domains take part in home-realm discovery
+ALTER TABLE tenants
+    ADD COLUMN domain_verified BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE UNIQUE INDEX tenants_verified_domain_key ON tenants(lower(domain)) WHERE domain_verified;
@@ -0,0 +1,16 @@
+-- Bearer tokens an IdP uses to provision a tenant over SCIM
+CREATE TABLE scim_tokens (
+    token_id     UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id    UUID NOT NULL REFERENCES tenants(tenant_id) ON DELETE CASCADE,
+    token_hash   VARCHAR(255) NOT NULL UNIQUE,
+    description  VARCHAR(255) NOT NULL,
+    revoked      BOOLEAN NOT NULL DEFAULT FALSE,
+    last_used_at TIMESTAMP,
+    created_at   TIMESTAMP DEFAULT NOW()
+);
+
+-- The provisioning client's own identifiers, echoed back as externalId
+ALTER TABLE users ADD COLUMN external_id VARCHAR(255);
+ALTER TABLE roles ADD COLUMN external_id VARCHAR(255);
+
+CREATE INDEX idx_user_roles_role_id ON user_roles(role_id);
@@ -0,0 +1,13 @@
+-- Per-tenant rules deciding whether federated sign-ins may create accounts
+-- and which roles they hold
+CREATE TABLE jit_policies (
+    tenant_id    UUID PRIMARY KEY REFERENCES tenants(tenant_id) ON DELETE CASCADE,
+    auto_create  BOOLEAN NOT NULL DEFAULT TRUE,
+    default_role VARCHAR(50),
+    rules        JSONB NOT NULL DEFAULT '[]',
+    updated_at   TIMESTAMP DEFAULT NOW()
+);
+
+-- Where a membership came from, so rule re-evaluation only replaces the
+-- grants it made itself
+ALTER TABLE user_roles ADD COLUMN source VARCHAR(20) NOT NULL DEFAULT 'MANUAL';
@@ -0,0 +1,18 @@
+-- LDAP / Active Directory servers that authenticate a tenant's password logins
+CREATE TABLE ldap_directories (
+    directory_id       UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id          UUID NOT NULL REFERENCES tenants(tenant_id) ON DELETE CASCADE,
+    name               VARCHAR(100) NOT NULL,
+    url                VARCHAR(1024) NOT NULL, -- ldaps://host:636 or ldap://host:389 with start_tls
+    start_tls          BOOLEAN NOT NULL DEFAULT FALSE,
+    ca_cert            TEXT,                   -- PEM bundle trusted in place of the system roots
+    bind_dn            VARCHAR(1024),          -- service account used to find users; anonymous if NULL
+    bind_password      TEXT,
+    user_base_dn       VARCHAR(1024) NOT NULL,
+    user_filter        VARCHAR(1024) NOT NULL DEFAULT '(mail=%s)',
+    attribute_mappings JSONB NOT NULL DEFAULT '{}',
+    group_mappings     JSONB NOT NULL DEFAULT '[]', -- [{"value": "<group DN or CN>", "role": "..."}]
+    enabled            BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at         TIMESTAMP DEFAULT NOW(),
+    UNIQUE (tenant_id, name)
+);
@@ -0,0 +1,9 @@
+-- Pending email address changes, applied once the new address is confirmed
+CREATE TABLE email_changes (
+    change_id  UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id    UUID NOT NULL UNIQUE REFERENCES users(user_id) ON DELETE CASCADE,
+    new_email  VARCHAR(255) NOT NULL,
+    token_hash VARCHAR(255) NOT NULL UNIQUE,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW()
+);
@@ -0,0 +1,9 @@
+-- Tenant lifecycle: suspension and scheduled deletion
+UPDATE tenants SET status = upper(status);
+
+ALTER TABLE tenants
+    ADD COLUMN suspended_reason      TEXT,
+    ADD COLUMN deletion_scheduled_at TIMESTAMP;
+
+CREATE INDEX idx_tenants_deletion_scheduled_at ON tenants(deletion_scheduled_at)
+    WHERE deletion_scheduled_at IS NOT NULL;
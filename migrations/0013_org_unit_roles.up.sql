@@ -0,0 +1,17 @@
+-- Roles assigned at an org unit are inherited by its members and by the
+-- members of every unit below it
+CREATE TABLE org_unit_roles (
+    org_unit_id UUID NOT NULL REFERENCES org_units(org_unit_id) ON DELETE CASCADE,
+    role_id     INT NOT NULL REFERENCES roles(role_id) ON DELETE CASCADE,
+    created_at  TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (org_unit_id, role_id)
+);
+
+CREATE INDEX idx_org_unit_roles_role_id ON org_unit_roles(role_id);
+
+ALTER TABLE org_units
+    ADD COLUMN created_at TIMESTAMP DEFAULT NOW(),
+    ADD CONSTRAINT org_units_no_self_parent CHECK (parent_org_unit_id <> org_unit_id);
+
+CREATE INDEX idx_org_units_parent ON org_units(parent_org_unit_id);
+CREATE INDEX idx_users_org_unit_id ON users(org_unit_id);
@@ -0,0 +1,40 @@
+-- Groups: tenant-scoped sets of users and other groups that roles can be
+-- assigned to
+CREATE TABLE groups (
+    group_id    UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id   UUID NOT NULL REFERENCES tenants(tenant_id) ON DELETE CASCADE,
+    name        VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at  TIMESTAMP DEFAULT NOW(),
+    updated_at  TIMESTAMP DEFAULT NOW(),
+    UNIQUE (tenant_id, name)
+);
+
+CREATE TABLE group_users (
+    group_id   UUID NOT NULL REFERENCES groups(group_id) ON DELETE CASCADE,
+    user_id    UUID NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (group_id, user_id)
+);
+
+CREATE INDEX idx_group_users_user_id ON group_users(user_id);
+
+-- group_id contains member_group_id
+CREATE TABLE group_subgroups (
+    group_id        UUID NOT NULL REFERENCES groups(group_id) ON DELETE CASCADE,
+    member_group_id UUID NOT NULL REFERENCES groups(group_id) ON DELETE CASCADE,
+    created_at      TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (group_id, member_group_id),
+    CHECK (group_id <> member_group_id)
+);
+
+CREATE INDEX idx_group_subgroups_member ON group_subgroups(member_group_id);
+
+CREATE TABLE group_roles (
+    group_id   UUID NOT NULL REFERENCES groups(group_id) ON DELETE CASCADE,
+    role_id    INT NOT NULL REFERENCES roles(role_id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (group_id, role_id)
+);
+
+CREATE INDEX idx_group_roles_role_id ON group_roles(role_id);
@@ -0,0 +1,31 @@
+-- Relationship tuples (object#relation@subject). Every write takes the next
+-- revision; deleted tuples are kept with the revision that removed them so
+-- reads can be evaluated at a consistent snapshot.
+CREATE SEQUENCE relation_revision;
+
+CREATE TABLE relation_tuples (
+    tenant_id        UUID NOT NULL REFERENCES tenants(tenant_id) ON DELETE CASCADE,
+    object_type      VARCHAR(64) NOT NULL,
+    object_id        VARCHAR(255) NOT NULL,
+    relation         VARCHAR(64) NOT NULL,
+    subject_type     VARCHAR(64) NOT NULL,
+    subject_id       VARCHAR(255) NOT NULL,
+    subject_relation VARCHAR(64) NOT NULL DEFAULT '',
+    created_rev      BIGINT NOT NULL,
+    deleted_rev      BIGINT
+);
+
+CREATE UNIQUE INDEX relation_tuples_live_key ON relation_tuples
+    (tenant_id, object_type, object_id, relation, subject_type, subject_id, subject_relation)
+    WHERE deleted_rev IS NULL;
+CREATE INDEX idx_relation_tuples_object ON relation_tuples (tenant_id, object_type, object_id, relation);
+CREATE INDEX idx_relation_tuples_subject ON relation_tuples (tenant_id, subject_type, subject_id);
+
+-- One namespace configuration per tenant, versioned by the revision it was
+-- written at
+CREATE TABLE relation_schemas (
+    tenant_id  UUID PRIMARY KEY REFERENCES tenants(tenant_id) ON DELETE CASCADE,
+    source     TEXT NOT NULL,
+    revision   BIGINT NOT NULL,
+    updated_at TIMESTAMP DEFAULT NOW()
+);
@@ -0,0 +1,19 @@
+-- Attribute-based policies: CEL conditions that must hold for a role, or for
+-- a permission, to take effect
+CREATE TABLE policies (
+    policy_id   UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id   UUID NOT NULL REFERENCES tenants(tenant_id) ON DELETE CASCADE,
+    name        VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    role_id     INT REFERENCES roles(role_id) ON DELETE CASCADE,
+    permission  TEXT,
+    condition   TEXT NOT NULL,
+    created_at  TIMESTAMP DEFAULT NOW(),
+    updated_at  TIMESTAMP DEFAULT NOW(),
+    UNIQUE (tenant_id, name),
+    -- a policy is attached to exactly one role or one permission
+    CHECK ((role_id IS NULL) <> (permission IS NULL))
+);
+
+CREATE INDEX idx_policies_role_id ON policies(role_id) WHERE role_id IS NOT NULL;
+CREATE INDEX idx_policies_permission ON policies(tenant_id, permission) WHERE permission IS NOT NULL;
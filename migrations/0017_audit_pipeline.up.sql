@@ -0,0 +1,4 @@
+-- Audit entries recorded by the interceptor carry the RPC and its outcome
+ALTER TABLE audit_logs
+    ADD COLUMN method  VARCHAR(255),
+    ADD COLUMN outcome VARCHAR(32);
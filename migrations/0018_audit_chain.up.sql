@@ -0,0 +1,34 @@
+-- Tamper-evident audit log: each tenant's entries form a hash chain, with
+-- the entries outside any tenant in a chain of their own (chain '').
+--
+-- Audit rows are evidence and must not change after they are written, so
+-- deleting a user, tenant or session no longer nulls out their references.
+ALTER TABLE audit_logs
+    DROP CONSTRAINT IF EXISTS audit_logs_user_id_fkey,
+    DROP CONSTRAINT IF EXISTS audit_logs_tenant_id_fkey,
+    DROP CONSTRAINT IF EXISTS audit_logs_impersonator_id_fkey,
+    DROP CONSTRAINT IF EXISTS audit_logs_impersonation_id_fkey,
+    ADD COLUMN seq       BIGINT,
+    ADD COLUMN prev_hash BYTEA,
+    ADD COLUMN hash      BYTEA;
+
+-- Entries written before chaining keep a NULL seq and are not verified
+CREATE UNIQUE INDEX idx_audit_logs_chain_seq
+    ON audit_logs ((COALESCE(tenant_id::text, '')), seq) WHERE seq IS NOT NULL;
+
+-- The last link of each chain; appending compares-and-swaps it
+CREATE TABLE audit_chain_heads (
+    chain TEXT PRIMARY KEY,
+    seq   BIGINT NOT NULL,
+    hash  BYTEA NOT NULL
+);
+
+-- Signed statements that a chain reached seq with hash
+CREATE TABLE audit_checkpoints (
+    chain      TEXT NOT NULL,
+    seq        BIGINT NOT NULL,
+    hash       BYTEA NOT NULL,
+    signature  BYTEA NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (chain, seq)
+);
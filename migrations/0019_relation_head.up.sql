@@ -0,0 +1,13 @@
+-- Relation revisions used to come from relation_revision when a write began,
+-- so a slow write could commit below a revision already reported as the
+-- head, changing a snapshot after it was read. Writes now advance this
+-- single row instead; its row lock makes revisions follow commit order, and
+-- the committed value is the head. The sequence is left for instances of
+-- the previous release during the rollout.
+CREATE TABLE relation_head (
+    only_row BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (only_row),
+    revision BIGINT NOT NULL
+);
+
+INSERT INTO relation_head (revision)
+SELECT CASE WHEN is_called THEN last_value ELSE 0 END FROM relation_revision;
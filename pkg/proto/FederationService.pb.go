@@ -0,0 +1,1828 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        v5.29.3
+// source: FederationService.proto
+
+package proto
+
+import (
+	common "auth-haven/pkg/proto/common"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type OIDCProvider struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TenantId      string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Issuer        string                 `protobuf:"bytes,4,opt,name=issuer,proto3" json:"issuer,omitempty"`
+	ClientId      string                 `protobuf:"bytes,5,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	RedirectUrl   string                 `protobuf:"bytes,6,opt,name=redirect_url,json=redirectUrl,proto3" json:"redirect_url,omitempty"`
+	Scopes        []string               `protobuf:"bytes,7,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	ClaimMappings map[string]string      `protobuf:"bytes,8,rep,name=claim_mappings,json=claimMappings,proto3" json:"claim_mappings,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // email, email_verified, name, groups -> upstream claim
+	Enabled       bool                   `protobuf:"varint,9,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OIDCProvider) Reset() {
+	*x = OIDCProvider{}
+	mi := &file_FederationService_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OIDCProvider) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OIDCProvider) ProtoMessage() {}
+
+func (x *OIDCProvider) ProtoReflect() protoreflect.Message {
+	mi := &file_FederationService_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OIDCProvider.ProtoReflect.Descriptor instead.
+func (*OIDCProvider) Descriptor() ([]byte, []int) {
+	return file_FederationService_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *OIDCProvider) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *OIDCProvider) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *OIDCProvider) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *OIDCProvider) GetIssuer() string {
+	if x != nil {
+		return x.Issuer
+	}
+	return ""
+}
+
+func (x *OIDCProvider) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *OIDCProvider) GetRedirectUrl() string {
+	if x != nil {
+		return x.RedirectUrl
+	}
+	return ""
+}
+
+func (x *OIDCProvider) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *OIDCProvider) GetClaimMappings() map[string]string {
+	if x != nil {
+		return x.ClaimMappings
+	}
+	return nil
+}
+
+func (x *OIDCProvider) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+type CreateOIDCProviderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Issuer        string                 `protobuf:"bytes,2,opt,name=issuer,proto3" json:"issuer,omitempty"`
+	ClientId      string                 `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	ClientSecret  string                 `protobuf:"bytes,4,opt,name=client_secret,json=clientSecret,proto3" json:"client_secret,omitempty"`
+	RedirectUrl   string                 `protobuf:"bytes,5,opt,name=redirect_url,json=redirectUrl,proto3" json:"redirect_url,omitempty"`
+	Scopes        []string               `protobuf:"bytes,6,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	ClaimMappings map[string]string      `protobuf:"bytes,7,rep,name=claim_mappings,json=claimMappings,proto3" json:"claim_mappings,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOIDCProviderRequest) Reset() {
+	*x = CreateOIDCProviderRequest{}
+	mi := &file_FederationService_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOIDCProviderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOIDCProviderRequest) ProtoMessage() {}
+
+func (x *CreateOIDCProviderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_FederationService_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOIDCProviderRequest.ProtoReflect.Descriptor instead.
+func (*CreateOIDCProviderRequest) Descriptor() ([]byte, []int) {
+	return file_FederationService_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateOIDCProviderRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateOIDCProviderRequest) GetIssuer() string {
+	if x != nil {
+		return x.Issuer
+	}
+	return ""
+}
+
+func (x *CreateOIDCProviderRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *CreateOIDCProviderRequest) GetClientSecret() string {
+	if x != nil {
+		return x.ClientSecret
+	}
+	return ""
+}
+
+func (x *CreateOIDCProviderRequest) GetRedirectUrl() string {
+	if x != nil {
+		return x.RedirectUrl
+	}
+	return ""
+}
+
+func (x *CreateOIDCProviderRequest) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *CreateOIDCProviderRequest) GetClaimMappings() map[string]string {
+	if x != nil {
+		return x.ClaimMappings
+	}
+	return nil
+}
+
+type ListOIDCProvidersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOIDCProvidersRequest) Reset() {
+	*x = ListOIDCProvidersRequest{}
+	mi := &file_FederationService_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOIDCProvidersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOIDCProvidersRequest) ProtoMessage() {}
+
+func (x *ListOIDCProvidersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_FederationService_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOIDCProvidersRequest.ProtoReflect.Descriptor instead.
+func (*ListOIDCProvidersRequest) Descriptor() ([]byte, []int) {
+	return file_FederationService_proto_rawDescGZIP(), []int{2}
+}
+
+type ListOIDCProvidersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Providers     []*OIDCProvider        `protobuf:"bytes,1,rep,name=providers,proto3" json:"providers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOIDCProvidersResponse) Reset() {
+	*x = ListOIDCProvidersResponse{}
+	mi := &file_FederationService_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOIDCProvidersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOIDCProvidersResponse) ProtoMessage() {}
+
+func (x *ListOIDCProvidersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_FederationService_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOIDCProvidersResponse.ProtoReflect.Descriptor instead.
+func (*ListOIDCProvidersResponse) Descriptor() ([]byte, []int) {
+	return file_FederationService_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListOIDCProvidersResponse) GetProviders() []*OIDCProvider {
+	if x != nil {
+		return x.Providers
+	}
+	return nil
+}
+
+type DeleteOIDCProviderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProviderId    string                 `protobuf:"bytes,1,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteOIDCProviderRequest) Reset() {
+	*x = DeleteOIDCProviderRequest{}
+	mi := &file_FederationService_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteOIDCProviderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteOIDCProviderRequest) ProtoMessage() {}
+
+func (x *DeleteOIDCProviderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_FederationService_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteOIDCProviderRequest.ProtoReflect.Descriptor instead.
+func (*DeleteOIDCProviderRequest) Descriptor() ([]byte, []int) {
+	return file_FederationService_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeleteOIDCProviderRequest) GetProviderId() string {
+	if x != nil {
+		return x.ProviderId
+	}
+	return ""
+}
+
+type DeleteOIDCProviderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteOIDCProviderResponse) Reset() {
+	*x = DeleteOIDCProviderResponse{}
+	mi := &file_FederationService_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteOIDCProviderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteOIDCProviderResponse) ProtoMessage() {}
+
+func (x *DeleteOIDCProviderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_FederationService_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteOIDCProviderResponse.ProtoReflect.Descriptor instead.
+func (*DeleteOIDCProviderResponse) Descriptor() ([]byte, []int) {
+	return file_FederationService_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DeleteOIDCProviderResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type BeginOIDCLoginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProviderId    string                 `protobuf:"bytes,1,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BeginOIDCLoginRequest) Reset() {
+	*x = BeginOIDCLoginRequest{}
+	mi := &file_FederationService_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BeginOIDCLoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BeginOIDCLoginRequest) ProtoMessage() {}
+
+func (x *BeginOIDCLoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_FederationService_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BeginOIDCLoginRequest.ProtoReflect.Descriptor instead.
+func (*BeginOIDCLoginRequest) Descriptor() ([]byte, []int) {
+	return file_FederationService_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *BeginOIDCLoginRequest) GetProviderId() string {
+	if x != nil {
+		return x.ProviderId
+	}
+	return ""
+}
+
+type BeginOIDCLoginResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	AuthorizationUrl string                 `protobuf:"bytes,1,opt,name=authorization_url,json=authorizationUrl,proto3" json:"authorization_url,omitempty"` // send the browser here
+	State            string                 `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *BeginOIDCLoginResponse) Reset() {
+	*x = BeginOIDCLoginResponse{}
+	mi := &file_FederationService_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BeginOIDCLoginResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BeginOIDCLoginResponse) ProtoMessage() {}
+
+func (x *BeginOIDCLoginResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_FederationService_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BeginOIDCLoginResponse.ProtoReflect.Descriptor instead.
+func (*BeginOIDCLoginResponse) Descriptor() ([]byte, []int) {
+	return file_FederationService_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BeginOIDCLoginResponse) GetAuthorizationUrl() string {
+	if x != nil {
+		return x.AuthorizationUrl
+	}
+	return ""
+}
+
+func (x *BeginOIDCLoginResponse) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+type CompleteOIDCLoginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	State         string                 `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"` // as received on the callback
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteOIDCLoginRequest) Reset() {
+	*x = CompleteOIDCLoginRequest{}
+	mi := &file_FederationService_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteOIDCLoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteOIDCLoginRequest) ProtoMessage() {}
+
+func (x *CompleteOIDCLoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_FederationService_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteOIDCLoginRequest.ProtoReflect.Descriptor instead.
+func (*CompleteOIDCLoginRequest) Descriptor() ([]byte, []int) {
+	return file_FederationService_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CompleteOIDCLoginRequest) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *CompleteOIDCLoginRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type SAMLRoleMapping struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"` // value of the role attribute
+	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`   // name of a role in the tenant
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SAMLRoleMapping) Reset() {
+	*x = SAMLRoleMapping{}
+	mi := &file_FederationService_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SAMLRoleMapping) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SAMLRoleMapping) ProtoMessage() {}
+
+func (x *SAMLRoleMapping) ProtoReflect() protoreflect.Message {
+	mi := &file_FederationService_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SAMLRoleMapping.ProtoReflect.Descriptor instead.
+func (*SAMLRoleMapping) Descriptor() ([]byte, []int) {
+	return file_FederationService_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SAMLRoleMapping) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *SAMLRoleMapping) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type SAMLProvider struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TenantId          string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Name              string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	EntityId          string                 `protobuf:"bytes,4,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	AcsUrl            string                 `protobuf:"bytes,5,opt,name=acs_url,json=acsUrl,proto3" json:"acs_url,omitempty"`
+	MetadataUrl       string                 `protobuf:"bytes,6,opt,name=metadata_url,json=metadataUrl,proto3" json:"metadata_url,omitempty"`
+	IdpEntityId       string                 `protobuf:"bytes,7,opt,name=idp_entity_id,json=idpEntityId,proto3" json:"idp_entity_id,omitempty"`
+	AllowIdpInitiated bool                   `protobuf:"varint,8,opt,name=allow_idp_initiated,json=allowIdpInitiated,proto3" json:"allow_idp_initiated,omitempty"`
+	AttributeMappings map[string]string      `protobuf:"bytes,9,rep,name=attribute_mappings,json=attributeMappings,proto3" json:"attribute_mappings,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // email, name, groups -> SAML attribute
+	RoleAttribute     string                 `protobuf:"bytes,10,opt,name=role_attribute,json=roleAttribute,proto3" json:"role_attribute,omitempty"`
+	RoleMappings      []*SAMLRoleMapping     `protobuf:"bytes,11,rep,name=role_mappings,json=roleMappings,proto3" json:"role_mappings,omitempty"` // first match wins
+	Enabled           bool                   `protobuf:"varint,12,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *SAMLProvider) Reset() {
+	*x = SAMLProvider{}
+	mi := &file_FederationService_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SAMLProvider) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SAMLProvider) ProtoMessage() {}
+
+func (x *SAMLProvider) ProtoReflect() protoreflect.Message {
+	mi := &file_FederationService_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SAMLProvider.ProtoReflect.Descriptor instead.
+func (*SAMLProvider) Descriptor() ([]byte, []int) {
+	return file_FederationService_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SAMLProvider) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SAMLProvider) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *SAMLProvider) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SAMLProvider) GetEntityId() string {
+	if x != nil {
+		return x.EntityId
+	}
+	return ""
+}
+
+func (x *SAMLProvider) GetAcsUrl() string {
+	if x != nil {
+		return x.AcsUrl
+	}
+	return ""
+}
+
+func (x *SAMLProvider) GetMetadataUrl() string {
+	if x != nil {
+		return x.MetadataUrl
+	}
+	return ""
+}
+
+func (x *SAMLProvider) GetIdpEntityId() string {
+	if x != nil {
+		return x.IdpEntityId
+	}
+	return ""
+}
+
+func (x *SAMLProvider) GetAllowIdpInitiated() bool {
+	if x != nil {
+		return x.AllowIdpInitiated
+	}
+	return false
+}
+
+func (x *SAMLProvider) GetAttributeMappings() map[string]string {
+	if x != nil {
+		return x.AttributeMappings
+	}
+	return nil
+}
+
+func (x *SAMLProvider) GetRoleAttribute() string {
+	if x != nil {
+		return x.RoleAttribute
+	}
+	return ""
+}
+
+func (x *SAMLProvider) GetRoleMappings() []*SAMLRoleMapping {
+	if x != nil {
+		return x.RoleMappings
+	}
+	return nil
+}
+
+func (x *SAMLProvider) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+type CreateSAMLProviderRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Name              string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	EntityId          string                 `protobuf:"bytes,2,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	AcsUrl            string                 `protobuf:"bytes,3,opt,name=acs_url,json=acsUrl,proto3" json:"acs_url,omitempty"`
+	IdpMetadataXml    string                 `protobuf:"bytes,4,opt,name=idp_metadata_xml,json=idpMetadataXml,proto3" json:"idp_metadata_xml,omitempty"`
+	AllowIdpInitiated bool                   `protobuf:"varint,5,opt,name=allow_idp_initiated,json=allowIdpInitiated,proto3" json:"allow_idp_initiated,omitempty"`
+	AttributeMappings map[string]string      `protobuf:"bytes,6,rep,name=attribute_mappings,json=attributeMappings,proto3" json:"attribute_mappings,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	RoleAttribute     string                 `protobuf:"bytes,7,opt,name=role_attribute,json=roleAttribute,proto3" json:"role_attribute,omitempty"`
+	RoleMappings      []*SAMLRoleMapping     `protobuf:"bytes,8,rep,name=role_mappings,json=roleMappings,proto3" json:"role_mappings,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CreateSAMLProviderRequest) Reset() {
+	*x = CreateSAMLProviderRequest{}
+	mi := &file_FederationService_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSAMLProviderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSAMLProviderRequest) ProtoMessage() {}
+
+func (x *CreateSAMLProviderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_FederationService_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSAMLProviderRequest.ProtoReflect.Descriptor instead.
+func (*CreateSAMLProviderRequest) Descriptor() ([]byte, []int) {
+	return file_FederationService_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CreateSAMLProviderRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateSAMLProviderRequest) GetEntityId() string {
+	if x != nil {
+		return x.EntityId
+	}
+	return ""
+}
+
+func (x *CreateSAMLProviderRequest) GetAcsUrl() string {
+	if x != nil {
+		return x.AcsUrl
+	}
+	return ""
+}
+
+func (x *CreateSAMLProviderRequest) GetIdpMetadataXml() string {
+	if x != nil {
+		return x.IdpMetadataXml
+	}
+	return ""
+}
+
+func (x *CreateSAMLProviderRequest) GetAllowIdpInitiated() bool {
+	if x != nil {
+		return x.AllowIdpInitiated
+	}
+	return false
+}
+
+func (x *CreateSAMLProviderRequest) GetAttributeMappings() map[string]string {
+	if x != nil {
+		return x.AttributeMappings
+	}
+	return nil
+}
+
+func (x *CreateSAMLProviderRequest) GetRoleAttribute() string {
+	if x != nil {
+		return x.RoleAttribute
+	}
+	return ""
+}
+
+func (x *CreateSAMLProviderRequest) GetRoleMappings() []*SAMLRoleMapping {
+	if x != nil {
+		return x.RoleMappings
+	}
+	return nil
+}
+
+type ListSAMLProvidersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSAMLProvidersRequest) Reset() {
+	*x = ListSAMLProvidersRequest{}
+	mi := &file_FederationService_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSAMLProvidersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSAMLProvidersRequest) ProtoMessage() {}
+
+func (x *ListSAMLProvidersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_FederationService_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSAMLProvidersRequest.ProtoReflect.Descriptor instead.
+func (*ListSAMLProvidersRequest) Descriptor() ([]byte, []int) {
+	return file_FederationService_proto_rawDescGZIP(), []int{12}
+}
+
+type ListSAMLProvidersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Providers     []*SAMLProvider        `protobuf:"bytes,1,rep,name=providers,proto3" json:"providers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSAMLProvidersResponse) Reset() {
+	*x = ListSAMLProvidersResponse{}
+	mi := &file_FederationService_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSAMLProvidersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSAMLProvidersResponse) ProtoMessage() {}
+
+func (x *ListSAMLProvidersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_FederationService_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSAMLProvidersResponse.ProtoReflect.Descriptor instead.
+func (*ListSAMLProvidersResponse) Descriptor() ([]byte, []int) {
+	return file_FederationService_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListSAMLProvidersResponse) GetProviders() []*SAMLProvider {
+	if x != nil {
+		return x.Providers
+	}
+	return nil
+}
+
+type DeleteSAMLProviderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProviderId    string                 `protobuf:"bytes,1,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSAMLProviderRequest) Reset() {
+	*x = DeleteSAMLProviderRequest{}
+	mi := &file_FederationService_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSAMLProviderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSAMLProviderRequest) ProtoMessage() {}
+
+func (x *DeleteSAMLProviderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_FederationService_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSAMLProviderRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSAMLProviderRequest) Descriptor() ([]byte, []int) {
+	return file_FederationService_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DeleteSAMLProviderRequest) GetProviderId() string {
+	if x != nil {
+		return x.ProviderId
+	}
+	return ""
+}
+
+type DeleteSAMLProviderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSAMLProviderResponse) Reset() {
+	*x = DeleteSAMLProviderResponse{}
+	mi := &file_FederationService_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSAMLProviderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSAMLProviderResponse) ProtoMessage() {}
+
+func (x *DeleteSAMLProviderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_FederationService_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSAMLProviderResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSAMLProviderResponse) Descriptor() ([]byte, []int) {
+	return file_FederationService_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DeleteSAMLProviderResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type BeginSAMLLoginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProviderId    string                 `protobuf:"bytes,1,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"`
+	RelayState    string                 `protobuf:"bytes,2,opt,name=relay_state,json=relayState,proto3" json:"relay_state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BeginSAMLLoginRequest) Reset() {
+	*x = BeginSAMLLoginRequest{}
+	mi := &file_FederationService_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BeginSAMLLoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BeginSAMLLoginRequest) ProtoMessage() {}
+
+func (x *BeginSAMLLoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_FederationService_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BeginSAMLLoginRequest.ProtoReflect.Descriptor instead.
+func (*BeginSAMLLoginRequest) Descriptor() ([]byte, []int) {
+	return file_FederationService_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *BeginSAMLLoginRequest) GetProviderId() string {
+	if x != nil {
+		return x.ProviderId
+	}
+	return ""
+}
+
+func (x *BeginSAMLLoginRequest) GetRelayState() string {
+	if x != nil {
+		return x.RelayState
+	}
+	return ""
+}
+
+type BeginSAMLLoginResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RedirectUrl   string                 `protobuf:"bytes,1,opt,name=redirect_url,json=redirectUrl,proto3" json:"redirect_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BeginSAMLLoginResponse) Reset() {
+	*x = BeginSAMLLoginResponse{}
+	mi := &file_FederationService_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BeginSAMLLoginResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BeginSAMLLoginResponse) ProtoMessage() {}
+
+func (x *BeginSAMLLoginResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_FederationService_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BeginSAMLLoginResponse.ProtoReflect.Descriptor instead.
+func (*BeginSAMLLoginResponse) Descriptor() ([]byte, []int) {
+	return file_FederationService_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *BeginSAMLLoginResponse) GetRedirectUrl() string {
+	if x != nil {
+		return x.RedirectUrl
+	}
+	return ""
+}
+
+type CompleteSAMLLoginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProviderId    string                 `protobuf:"bytes,1,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"`
+	SamlResponse  string                 `protobuf:"bytes,2,opt,name=saml_response,json=samlResponse,proto3" json:"saml_response,omitempty"` // base64 SAMLResponse form value posted to the ACS URL
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteSAMLLoginRequest) Reset() {
+	*x = CompleteSAMLLoginRequest{}
+	mi := &file_FederationService_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteSAMLLoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteSAMLLoginRequest) ProtoMessage() {}
+
+func (x *CompleteSAMLLoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_FederationService_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteSAMLLoginRequest.ProtoReflect.Descriptor instead.
+func (*CompleteSAMLLoginRequest) Descriptor() ([]byte, []int) {
+	return file_FederationService_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CompleteSAMLLoginRequest) GetProviderId() string {
+	if x != nil {
+		return x.ProviderId
+	}
+	return ""
+}
+
+func (x *CompleteSAMLLoginRequest) GetSamlResponse() string {
+	if x != nil {
+		return x.SamlResponse
+	}
+	return ""
+}
+
+type LDAPDirectory struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TenantId          string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Name              string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Url               string                 `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"` // ldaps://host:636, or ldap://host:389 with start_tls
+	StartTls          bool                   `protobuf:"varint,5,opt,name=start_tls,json=startTls,proto3" json:"start_tls,omitempty"`
+	HasCaCert         bool                   `protobuf:"varint,6,opt,name=has_ca_cert,json=hasCaCert,proto3" json:"has_ca_cert,omitempty"`
+	BindDn            string                 `protobuf:"bytes,7,opt,name=bind_dn,json=bindDn,proto3" json:"bind_dn,omitempty"`
+	UserBaseDn        string                 `protobuf:"bytes,8,opt,name=user_base_dn,json=userBaseDn,proto3" json:"user_base_dn,omitempty"`
+	UserFilter        string                 `protobuf:"bytes,9,opt,name=user_filter,json=userFilter,proto3" json:"user_filter,omitempty"`                                                                                                 // e.g. (&(objectClass=person)(mail=%s))
+	AttributeMappings map[string]string      `protobuf:"bytes,10,rep,name=attribute_mappings,json=attributeMappings,proto3" json:"attribute_mappings,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // subject, email, name, groups -> LDAP attribute
+	GroupMappings     []*SAMLRoleMapping     `protobuf:"bytes,11,rep,name=group_mappings,json=groupMappings,proto3" json:"group_mappings,omitempty"`                                                                                       // group DN or CN -> role; all matches apply
+	Enabled           bool                   `protobuf:"varint,12,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *LDAPDirectory) Reset() {
+	*x = LDAPDirectory{}
+	mi := &file_FederationService_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LDAPDirectory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LDAPDirectory) ProtoMessage() {}
+
+func (x *LDAPDirectory) ProtoReflect() protoreflect.Message {
+	mi := &file_FederationService_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LDAPDirectory.ProtoReflect.Descriptor instead.
+func (*LDAPDirectory) Descriptor() ([]byte, []int) {
+	return file_FederationService_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *LDAPDirectory) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *LDAPDirectory) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *LDAPDirectory) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *LDAPDirectory) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *LDAPDirectory) GetStartTls() bool {
+	if x != nil {
+		return x.StartTls
+	}
+	return false
+}
+
+func (x *LDAPDirectory) GetHasCaCert() bool {
+	if x != nil {
+		return x.HasCaCert
+	}
+	return false
+}
+
+func (x *LDAPDirectory) GetBindDn() string {
+	if x != nil {
+		return x.BindDn
+	}
+	return ""
+}
+
+func (x *LDAPDirectory) GetUserBaseDn() string {
+	if x != nil {
+		return x.UserBaseDn
+	}
+	return ""
+}
+
+func (x *LDAPDirectory) GetUserFilter() string {
+	if x != nil {
+		return x.UserFilter
+	}
+	return ""
+}
+
+func (x *LDAPDirectory) GetAttributeMappings() map[string]string {
+	if x != nil {
+		return x.AttributeMappings
+	}
+	return nil
+}
+
+func (x *LDAPDirectory) GetGroupMappings() []*SAMLRoleMapping {
+	if x != nil {
+		return x.GroupMappings
+	}
+	return nil
+}
+
+func (x *LDAPDirectory) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+type CreateLDAPDirectoryRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Name              string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Url               string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	StartTls          bool                   `protobuf:"varint,3,opt,name=start_tls,json=startTls,proto3" json:"start_tls,omitempty"`
+	CaCertPem         string                 `protobuf:"bytes,4,opt,name=ca_cert_pem,json=caCertPem,proto3" json:"ca_cert_pem,omitempty"`
+	BindDn            string                 `protobuf:"bytes,5,opt,name=bind_dn,json=bindDn,proto3" json:"bind_dn,omitempty"`
+	BindPassword      string                 `protobuf:"bytes,6,opt,name=bind_password,json=bindPassword,proto3" json:"bind_password,omitempty"`
+	UserBaseDn        string                 `protobuf:"bytes,7,opt,name=user_base_dn,json=userBaseDn,proto3" json:"user_base_dn,omitempty"`
+	UserFilter        string                 `protobuf:"bytes,8,opt,name=user_filter,json=userFilter,proto3" json:"user_filter,omitempty"` // defaults to (mail=%s)
+	AttributeMappings map[string]string      `protobuf:"bytes,9,rep,name=attribute_mappings,json=attributeMappings,proto3" json:"attribute_mappings,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	GroupMappings     []*SAMLRoleMapping     `protobuf:"bytes,10,rep,name=group_mappings,json=groupMappings,proto3" json:"group_mappings,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CreateLDAPDirectoryRequest) Reset() {
+	*x = CreateLDAPDirectoryRequest{}
+	mi := &file_FederationService_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateLDAPDirectoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateLDAPDirectoryRequest) ProtoMessage() {}
+
+func (x *CreateLDAPDirectoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_FederationService_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateLDAPDirectoryRequest.ProtoReflect.Descriptor instead.
+func (*CreateLDAPDirectoryRequest) Descriptor() ([]byte, []int) {
+	return file_FederationService_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CreateLDAPDirectoryRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateLDAPDirectoryRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CreateLDAPDirectoryRequest) GetStartTls() bool {
+	if x != nil {
+		return x.StartTls
+	}
+	return false
+}
+
+func (x *CreateLDAPDirectoryRequest) GetCaCertPem() string {
+	if x != nil {
+		return x.CaCertPem
+	}
+	return ""
+}
+
+func (x *CreateLDAPDirectoryRequest) GetBindDn() string {
+	if x != nil {
+		return x.BindDn
+	}
+	return ""
+}
+
+func (x *CreateLDAPDirectoryRequest) GetBindPassword() string {
+	if x != nil {
+		return x.BindPassword
+	}
+	return ""
+}
+
+func (x *CreateLDAPDirectoryRequest) GetUserBaseDn() string {
+	if x != nil {
+		return x.UserBaseDn
+	}
+	return ""
+}
+
+func (x *CreateLDAPDirectoryRequest) GetUserFilter() string {
+	if x != nil {
+		return x.UserFilter
+	}
+	return ""
+}
+
+func (x *CreateLDAPDirectoryRequest) GetAttributeMappings() map[string]string {
+	if x != nil {
+		return x.AttributeMappings
+	}
+	return nil
+}
+
+func (x *CreateLDAPDirectoryRequest) GetGroupMappings() []*SAMLRoleMapping {
+	if x != nil {
+		return x.GroupMappings
+	}
+	return nil
+}
+
+type ListLDAPDirectoriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLDAPDirectoriesRequest) Reset() {
+	*x = ListLDAPDirectoriesRequest{}
+	mi := &file_FederationService_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLDAPDirectoriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLDAPDirectoriesRequest) ProtoMessage() {}
+
+func (x *ListLDAPDirectoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_FederationService_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLDAPDirectoriesRequest.ProtoReflect.Descriptor instead.
+func (*ListLDAPDirectoriesRequest) Descriptor() ([]byte, []int) {
+	return file_FederationService_proto_rawDescGZIP(), []int{21}
+}
+
+type ListLDAPDirectoriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Directories   []*LDAPDirectory       `protobuf:"bytes,1,rep,name=directories,proto3" json:"directories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLDAPDirectoriesResponse) Reset() {
+	*x = ListLDAPDirectoriesResponse{}
+	mi := &file_FederationService_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLDAPDirectoriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLDAPDirectoriesResponse) ProtoMessage() {}
+
+func (x *ListLDAPDirectoriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_FederationService_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLDAPDirectoriesResponse.ProtoReflect.Descriptor instead.
+func (*ListLDAPDirectoriesResponse) Descriptor() ([]byte, []int) {
+	return file_FederationService_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListLDAPDirectoriesResponse) GetDirectories() []*LDAPDirectory {
+	if x != nil {
+		return x.Directories
+	}
+	return nil
+}
+
+type DeleteLDAPDirectoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DirectoryId   string                 `protobuf:"bytes,1,opt,name=directory_id,json=directoryId,proto3" json:"directory_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteLDAPDirectoryRequest) Reset() {
+	*x = DeleteLDAPDirectoryRequest{}
+	mi := &file_FederationService_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteLDAPDirectoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteLDAPDirectoryRequest) ProtoMessage() {}
+
+func (x *DeleteLDAPDirectoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_FederationService_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteLDAPDirectoryRequest.ProtoReflect.Descriptor instead.
+func (*DeleteLDAPDirectoryRequest) Descriptor() ([]byte, []int) {
+	return file_FederationService_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *DeleteLDAPDirectoryRequest) GetDirectoryId() string {
+	if x != nil {
+		return x.DirectoryId
+	}
+	return ""
+}
+
+type DeleteLDAPDirectoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteLDAPDirectoryResponse) Reset() {
+	*x = DeleteLDAPDirectoryResponse{}
+	mi := &file_FederationService_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteLDAPDirectoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteLDAPDirectoryResponse) ProtoMessage() {}
+
+func (x *DeleteLDAPDirectoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_FederationService_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteLDAPDirectoryResponse.ProtoReflect.Descriptor instead.
+func (*DeleteLDAPDirectoryResponse) Descriptor() ([]byte, []int) {
+	return file_FederationService_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *DeleteLDAPDirectoryResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+var File_FederationService_proto protoreflect.FileDescriptor
+
+const file_FederationService_proto_rawDesc = "" +
+	"\n" +
+	"\x17FederationService.proto\x12\x04auth\x1a\x13common/Tokens.proto\"\xe9\x02\n" +
+	"\fOIDCProvider\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\ttenant_id\x18\x02 \x01(\tR\btenantId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x16\n" +
+	"\x06issuer\x18\x04 \x01(\tR\x06issuer\x12\x1b\n" +
+	"\tclient_id\x18\x05 \x01(\tR\bclientId\x12!\n" +
+	"\fredirect_url\x18\x06 \x01(\tR\vredirectUrl\x12\x16\n" +
+	"\x06scopes\x18\a \x03(\tR\x06scopes\x12L\n" +
+	"\x0eclaim_mappings\x18\b \x03(\v2%.auth.OIDCProvider.ClaimMappingsEntryR\rclaimMappings\x12\x18\n" +
+	"\aenabled\x18\t \x01(\bR\aenabled\x1a@\n" +
+	"\x12ClaimMappingsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xe1\x02\n" +
+	"\x19CreateOIDCProviderRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06issuer\x18\x02 \x01(\tR\x06issuer\x12\x1b\n" +
+	"\tclient_id\x18\x03 \x01(\tR\bclientId\x12#\n" +
+	"\rclient_secret\x18\x04 \x01(\tR\fclientSecret\x12!\n" +
+	"\fredirect_url\x18\x05 \x01(\tR\vredirectUrl\x12\x16\n" +
+	"\x06scopes\x18\x06 \x03(\tR\x06scopes\x12Y\n" +
+	"\x0eclaim_mappings\x18\a \x03(\v22.auth.CreateOIDCProviderRequest.ClaimMappingsEntryR\rclaimMappings\x1a@\n" +
+	"\x12ClaimMappingsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x1a\n" +
+	"\x18ListOIDCProvidersRequest\"M\n" +
+	"\x19ListOIDCProvidersResponse\x120\n" +
+	"\tproviders\x18\x01 \x03(\v2\x12.auth.OIDCProviderR\tproviders\"<\n" +
+	"\x19DeleteOIDCProviderRequest\x12\x1f\n" +
+	"\vprovider_id\x18\x01 \x01(\tR\n" +
+	"providerId\"6\n" +
+	"\x1aDeleteOIDCProviderResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"8\n" +
+	"\x15BeginOIDCLoginRequest\x12\x1f\n" +
+	"\vprovider_id\x18\x01 \x01(\tR\n" +
+	"providerId\"[\n" +
+	"\x16BeginOIDCLoginResponse\x12+\n" +
+	"\x11authorization_url\x18\x01 \x01(\tR\x10authorizationUrl\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\"D\n" +
+	"\x18CompleteOIDCLoginRequest\x12\x14\n" +
+	"\x05state\x18\x01 \x01(\tR\x05state\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\";\n" +
+	"\x0fSAMLRoleMapping\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\"\x99\x04\n" +
+	"\fSAMLProvider\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\ttenant_id\x18\x02 \x01(\tR\btenantId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1b\n" +
+	"\tentity_id\x18\x04 \x01(\tR\bentityId\x12\x17\n" +
+	"\aacs_url\x18\x05 \x01(\tR\x06acsUrl\x12!\n" +
+	"\fmetadata_url\x18\x06 \x01(\tR\vmetadataUrl\x12\"\n" +
+	"\ridp_entity_id\x18\a \x01(\tR\vidpEntityId\x12.\n" +
+	"\x13allow_idp_initiated\x18\b \x01(\bR\x11allowIdpInitiated\x12X\n" +
+	"\x12attribute_mappings\x18\t \x03(\v2).auth.SAMLProvider.AttributeMappingsEntryR\x11attributeMappings\x12%\n" +
+	"\x0erole_attribute\x18\n" +
+	" \x01(\tR\rroleAttribute\x12:\n" +
+	"\rrole_mappings\x18\v \x03(\v2\x15.auth.SAMLRoleMappingR\froleMappings\x12\x18\n" +
+	"\aenabled\x18\f \x01(\bR\aenabled\x1aD\n" +
+	"\x16AttributeMappingsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xcf\x03\n" +
+	"\x19CreateSAMLProviderRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1b\n" +
+	"\tentity_id\x18\x02 \x01(\tR\bentityId\x12\x17\n" +
+	"\aacs_url\x18\x03 \x01(\tR\x06acsUrl\x12(\n" +
+	"\x10idp_metadata_xml\x18\x04 \x01(\tR\x0eidpMetadataXml\x12.\n" +
+	"\x13allow_idp_initiated\x18\x05 \x01(\bR\x11allowIdpInitiated\x12e\n" +
+	"\x12attribute_mappings\x18\x06 \x03(\v26.auth.CreateSAMLProviderRequest.AttributeMappingsEntryR\x11attributeMappings\x12%\n" +
+	"\x0erole_attribute\x18\a \x01(\tR\rroleAttribute\x12:\n" +
+	"\rrole_mappings\x18\b \x03(\v2\x15.auth.SAMLRoleMappingR\froleMappings\x1aD\n" +
+	"\x16AttributeMappingsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x1a\n" +
+	"\x18ListSAMLProvidersRequest\"M\n" +
+	"\x19ListSAMLProvidersResponse\x120\n" +
+	"\tproviders\x18\x01 \x03(\v2\x12.auth.SAMLProviderR\tproviders\"<\n" +
+	"\x19DeleteSAMLProviderRequest\x12\x1f\n" +
+	"\vprovider_id\x18\x01 \x01(\tR\n" +
+	"providerId\"6\n" +
+	"\x1aDeleteSAMLProviderResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"Y\n" +
+	"\x15BeginSAMLLoginRequest\x12\x1f\n" +
+	"\vprovider_id\x18\x01 \x01(\tR\n" +
+	"providerId\x12\x1f\n" +
+	"\vrelay_state\x18\x02 \x01(\tR\n" +
+	"relayState\";\n" +
+	"\x16BeginSAMLLoginResponse\x12!\n" +
+	"\fredirect_url\x18\x01 \x01(\tR\vredirectUrl\"`\n" +
+	"\x18CompleteSAMLLoginRequest\x12\x1f\n" +
+	"\vprovider_id\x18\x01 \x01(\tR\n" +
+	"providerId\x12#\n" +
+	"\rsaml_response\x18\x02 \x01(\tR\fsamlResponse\"\xf4\x03\n" +
+	"\rLDAPDirectory\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\ttenant_id\x18\x02 \x01(\tR\btenantId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x10\n" +
+	"\x03url\x18\x04 \x01(\tR\x03url\x12\x1b\n" +
+	"\tstart_tls\x18\x05 \x01(\bR\bstartTls\x12\x1e\n" +
+	"\vhas_ca_cert\x18\x06 \x01(\bR\thasCaCert\x12\x17\n" +
+	"\abind_dn\x18\a \x01(\tR\x06bindDn\x12 \n" +
+	"\fuser_base_dn\x18\b \x01(\tR\n" +
+	"userBaseDn\x12\x1f\n" +
+	"\vuser_filter\x18\t \x01(\tR\n" +
+	"userFilter\x12Y\n" +
+	"\x12attribute_mappings\x18\n" +
+	" \x03(\v2*.auth.LDAPDirectory.AttributeMappingsEntryR\x11attributeMappings\x12<\n" +
+	"\x0egroup_mappings\x18\v \x03(\v2\x15.auth.SAMLRoleMappingR\rgroupMappings\x12\x18\n" +
+	"\aenabled\x18\f \x01(\bR\aenabled\x1aD\n" +
+	"\x16AttributeMappingsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xec\x03\n" +
+	"\x1aCreateLDAPDirectoryRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x1b\n" +
+	"\tstart_tls\x18\x03 \x01(\bR\bstartTls\x12\x1e\n" +
+	"\vca_cert_pem\x18\x04 \x01(\tR\tcaCertPem\x12\x17\n" +
+	"\abind_dn\x18\x05 \x01(\tR\x06bindDn\x12#\n" +
+	"\rbind_password\x18\x06 \x01(\tR\fbindPassword\x12 \n" +
+	"\fuser_base_dn\x18\a \x01(\tR\n" +
+	"userBaseDn\x12\x1f\n" +
+	"\vuser_filter\x18\b \x01(\tR\n" +
+	"userFilter\x12f\n" +
+	"\x12attribute_mappings\x18\t \x03(\v27.auth.CreateLDAPDirectoryRequest.AttributeMappingsEntryR\x11attributeMappings\x12<\n" +
+	"\x0egroup_mappings\x18\n" +
+	" \x03(\v2\x15.auth.SAMLRoleMappingR\rgroupMappings\x1aD\n" +
+	"\x16AttributeMappingsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x1c\n" +
+	"\x1aListLDAPDirectoriesRequest\"T\n" +
+	"\x1bListLDAPDirectoriesResponse\x125\n" +
+	"\vdirectories\x18\x01 \x03(\v2\x13.auth.LDAPDirectoryR\vdirectories\"?\n" +
+	"\x1aDeleteLDAPDirectoryRequest\x12!\n" +
+	"\fdirectory_id\x18\x01 \x01(\tR\vdirectoryId\"7\n" +
+	"\x1bDeleteLDAPDirectoryResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess2\xad\b\n" +
+	"\x11FederationService\x12I\n" +
+	"\x12CreateOIDCProvider\x12\x1f.auth.CreateOIDCProviderRequest\x1a\x12.auth.OIDCProvider\x12T\n" +
+	"\x11ListOIDCProviders\x12\x1e.auth.ListOIDCProvidersRequest\x1a\x1f.auth.ListOIDCProvidersResponse\x12W\n" +
+	"\x12DeleteOIDCProvider\x12\x1f.auth.DeleteOIDCProviderRequest\x1a .auth.DeleteOIDCProviderResponse\x12K\n" +
+	"\x0eBeginOIDCLogin\x12\x1b.auth.BeginOIDCLoginRequest\x1a\x1c.auth.BeginOIDCLoginResponse\x12A\n" +
+	"\x11CompleteOIDCLogin\x12\x1e.auth.CompleteOIDCLoginRequest\x1a\f.auth.Tokens\x12I\n" +
+	"\x12CreateSAMLProvider\x12\x1f.auth.CreateSAMLProviderRequest\x1a\x12.auth.SAMLProvider\x12T\n" +
+	"\x11ListSAMLProviders\x12\x1e.auth.ListSAMLProvidersRequest\x1a\x1f.auth.ListSAMLProvidersResponse\x12W\n" +
+	"\x12DeleteSAMLProvider\x12\x1f.auth.DeleteSAMLProviderRequest\x1a .auth.DeleteSAMLProviderResponse\x12K\n" +
+	"\x0eBeginSAMLLogin\x12\x1b.auth.BeginSAMLLoginRequest\x1a\x1c.auth.BeginSAMLLoginResponse\x12A\n" +
+	"\x11CompleteSAMLLogin\x12\x1e.auth.CompleteSAMLLoginRequest\x1a\f.auth.Tokens\x12L\n" +
+	"\x13CreateLDAPDirectory\x12 .auth.CreateLDAPDirectoryRequest\x1a\x13.auth.LDAPDirectory\x12Z\n" +
+	"\x13ListLDAPDirectories\x12 .auth.ListLDAPDirectoriesRequest\x1a!.auth.ListLDAPDirectoriesResponse\x12Z\n" +
+	"\x13DeleteLDAPDirectory\x12 .auth.DeleteLDAPDirectoryRequest\x1a!.auth.DeleteLDAPDirectoryResponseB\x16Z\x14auth-haven/pkg/protob\x06proto3"
+
+var (
+	file_FederationService_proto_rawDescOnce sync.Once
+	file_FederationService_proto_rawDescData []byte
+)
+
+func file_FederationService_proto_rawDescGZIP() []byte {
+	file_FederationService_proto_rawDescOnce.Do(func() {
+		file_FederationService_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_FederationService_proto_rawDesc), len(file_FederationService_proto_rawDesc)))
+	})
+	return file_FederationService_proto_rawDescData
+}
+
+var file_FederationService_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
+var file_FederationService_proto_goTypes = []any{
+	(*OIDCProvider)(nil),                // 0: auth.OIDCProvider
+	(*CreateOIDCProviderRequest)(nil),   // 1: auth.CreateOIDCProviderRequest
+	(*ListOIDCProvidersRequest)(nil),    // 2: auth.ListOIDCProvidersRequest
+	(*ListOIDCProvidersResponse)(nil),   // 3: auth.ListOIDCProvidersResponse
+	(*DeleteOIDCProviderRequest)(nil),   // 4: auth.DeleteOIDCProviderRequest
+	(*DeleteOIDCProviderResponse)(nil),  // 5: auth.DeleteOIDCProviderResponse
+	(*BeginOIDCLoginRequest)(nil),       // 6: auth.BeginOIDCLoginRequest
+	(*BeginOIDCLoginResponse)(nil),      // 7: auth.BeginOIDCLoginResponse
+	(*CompleteOIDCLoginRequest)(nil),    // 8: auth.CompleteOIDCLoginRequest
+	(*SAMLRoleMapping)(nil),             // 9: auth.SAMLRoleMapping
+	(*SAMLProvider)(nil),                // 10: auth.SAMLProvider
+	(*CreateSAMLProviderRequest)(nil),   // 11: auth.CreateSAMLProviderRequest
+	(*ListSAMLProvidersRequest)(nil),    // 12: auth.ListSAMLProvidersRequest
+	(*ListSAMLProvidersResponse)(nil),   // 13: auth.ListSAMLProvidersResponse
+	(*DeleteSAMLProviderRequest)(nil),   // 14: auth.DeleteSAMLProviderRequest
+	(*DeleteSAMLProviderResponse)(nil),  // 15: auth.DeleteSAMLProviderResponse
+	(*BeginSAMLLoginRequest)(nil),       // 16: auth.BeginSAMLLoginRequest
+	(*BeginSAMLLoginResponse)(nil),      // 17: auth.BeginSAMLLoginResponse
+	(*CompleteSAMLLoginRequest)(nil),    // 18: auth.CompleteSAMLLoginRequest
+	(*LDAPDirectory)(nil),               // 19: auth.LDAPDirectory
+	(*CreateLDAPDirectoryRequest)(nil),  // 20: auth.CreateLDAPDirectoryRequest
+	(*ListLDAPDirectoriesRequest)(nil),  // 21: auth.ListLDAPDirectoriesRequest
+	(*ListLDAPDirectoriesResponse)(nil), // 22: auth.ListLDAPDirectoriesResponse
+	(*DeleteLDAPDirectoryRequest)(nil),  // 23: auth.DeleteLDAPDirectoryRequest
+	(*DeleteLDAPDirectoryResponse)(nil), // 24: auth.DeleteLDAPDirectoryResponse
+	nil,                                 // 25: auth.OIDCProvider.ClaimMappingsEntry
+	nil,                                 // 26: auth.CreateOIDCProviderRequest.ClaimMappingsEntry
+	nil,                                 // 27: auth.SAMLProvider.AttributeMappingsEntry
+	nil,                                 // 28: auth.CreateSAMLProviderRequest.AttributeMappingsEntry
+	nil,                                 // 29: auth.LDAPDirectory.AttributeMappingsEntry
+	nil,                                 // 30: auth.CreateLDAPDirectoryRequest.AttributeMappingsEntry
+	(*common.Tokens)(nil),               // 31: auth.Tokens
+}
+var file_FederationService_proto_depIdxs = []int32{
+	25, // 0: auth.OIDCProvider.claim_mappings:type_name -> auth.OIDCProvider.ClaimMappingsEntry
+	26, // 1: auth.CreateOIDCProviderRequest.claim_mappings:type_name -> auth.CreateOIDCProviderRequest.ClaimMappingsEntry
+	0,  // 2: auth.ListOIDCProvidersResponse.providers:type_name -> auth.OIDCProvider
+	27, // 3: auth.SAMLProvider.attribute_mappings:type_name -> auth.SAMLProvider.AttributeMappingsEntry
+	9,  // 4: auth.SAMLProvider.role_mappings:type_name -> auth.SAMLRoleMapping
+	28, // 5: auth.CreateSAMLProviderRequest.attribute_mappings:type_name -> auth.CreateSAMLProviderRequest.AttributeMappingsEntry
+	9,  // 6: auth.CreateSAMLProviderRequest.role_mappings:type_name -> auth.SAMLRoleMapping
+	10, // 7: auth.ListSAMLProvidersResponse.providers:type_name -> auth.SAMLProvider
+	29, // 8: auth.LDAPDirectory.attribute_mappings:type_name -> auth.LDAPDirectory.AttributeMappingsEntry
+	9,  // 9: auth.LDAPDirectory.group_mappings:type_name -> auth.SAMLRoleMapping
+	30, // 10: auth.CreateLDAPDirectoryRequest.attribute_mappings:type_name -> auth.CreateLDAPDirectoryRequest.AttributeMappingsEntry
+	9,  // 11: auth.CreateLDAPDirectoryRequest.group_mappings:type_name -> auth.SAMLRoleMapping
+	19, // 12: auth.ListLDAPDirectoriesResponse.directories:type_name -> auth.LDAPDirectory
+	1,  // 13: auth.FederationService.CreateOIDCProvider:input_type -> auth.CreateOIDCProviderRequest
+	2,  // 14: auth.FederationService.ListOIDCProviders:input_type -> auth.ListOIDCProvidersRequest
+	4,  // 15: auth.FederationService.DeleteOIDCProvider:input_type -> auth.DeleteOIDCProviderRequest
+	6,  // 16: auth.FederationService.BeginOIDCLogin:input_type -> auth.BeginOIDCLoginRequest
+	8,  // 17: auth.FederationService.CompleteOIDCLogin:input_type -> auth.CompleteOIDCLoginRequest
+	11, // 18: auth.FederationService.CreateSAMLProvider:input_type -> auth.CreateSAMLProviderRequest
+	12, // 19: auth.FederationService.ListSAMLProviders:input_type -> auth.ListSAMLProvidersRequest
+	14, // 20: auth.FederationService.DeleteSAMLProvider:input_type -> auth.DeleteSAMLProviderRequest
+	16, // 21: auth.FederationService.BeginSAMLLogin:input_type -> auth.BeginSAMLLoginRequest
+	18, // 22: auth.FederationService.CompleteSAMLLogin:input_type -> auth.CompleteSAMLLoginRequest
+	20, // 23: auth.FederationService.CreateLDAPDirectory:input_type -> auth.CreateLDAPDirectoryRequest
+	21, // 24: auth.FederationService.ListLDAPDirectories:input_type -> auth.ListLDAPDirectoriesRequest
+	23, // 25: auth.FederationService.DeleteLDAPDirectory:input_type -> auth.DeleteLDAPDirectoryRequest
+	0,  // 26: auth.FederationService.CreateOIDCProvider:output_type -> auth.OIDCProvider
+	3,  // 27: auth.FederationService.ListOIDCProviders:output_type -> auth.ListOIDCProvidersResponse
+	5,  // 28: auth.FederationService.DeleteOIDCProvider:output_type -> auth.DeleteOIDCProviderResponse
+	7,  // 29: auth.FederationService.BeginOIDCLogin:output_type -> auth.BeginOIDCLoginResponse
+	31, // 30: auth.FederationService.CompleteOIDCLogin:output_type -> auth.Tokens
+	10, // 31: auth.FederationService.CreateSAMLProvider:output_type -> auth.SAMLProvider
+	13, // 32: auth.FederationService.ListSAMLProviders:output_type -> auth.ListSAMLProvidersResponse
+	15, // 33: auth.FederationService.DeleteSAMLProvider:output_type -> auth.DeleteSAMLProviderResponse
+	17, // 34: auth.FederationService.BeginSAMLLogin:output_type -> auth.BeginSAMLLoginResponse
+	31, // 35: auth.FederationService.CompleteSAMLLogin:output_type -> auth.Tokens
+	19, // 36: auth.FederationService.CreateLDAPDirectory:output_type -> auth.LDAPDirectory
+	22, // 37: auth.FederationService.ListLDAPDirectories:output_type -> auth.ListLDAPDirectoriesResponse
+	24, // 38: auth.FederationService.DeleteLDAPDirectory:output_type -> auth.DeleteLDAPDirectoryResponse
+	26, // [26:39] is the sub-list for method output_type
+	13, // [13:26] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_FederationService_proto_init() }
+func file_FederationService_proto_init() {
+	if File_FederationService_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_FederationService_proto_rawDesc), len(file_FederationService_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   31,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_FederationService_proto_goTypes,
+		DependencyIndexes: file_FederationService_proto_depIdxs,
+		MessageInfos:      file_FederationService_proto_msgTypes,
+	}.Build()
+	File_FederationService_proto = out.File
+	file_FederationService_proto_goTypes = nil
+	file_FederationService_proto_depIdxs = nil
+}
@@ -0,0 +1,590 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: FederationService.proto
+
+package proto
+
+import (
+	common "auth-haven/pkg/proto/common"
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	FederationService_CreateOIDCProvider_FullMethodName  = "/auth.FederationService/CreateOIDCProvider"
+	FederationService_ListOIDCProviders_FullMethodName   = "/auth.FederationService/ListOIDCProviders"
+	FederationService_DeleteOIDCProvider_FullMethodName  = "/auth.FederationService/DeleteOIDCProvider"
+	FederationService_BeginOIDCLogin_FullMethodName      = "/auth.FederationService/BeginOIDCLogin"
+	FederationService_CompleteOIDCLogin_FullMethodName   = "/auth.FederationService/CompleteOIDCLogin"
+	FederationService_CreateSAMLProvider_FullMethodName  = "/auth.FederationService/CreateSAMLProvider"
+	FederationService_ListSAMLProviders_FullMethodName   = "/auth.FederationService/ListSAMLProviders"
+	FederationService_DeleteSAMLProvider_FullMethodName  = "/auth.FederationService/DeleteSAMLProvider"
+	FederationService_BeginSAMLLogin_FullMethodName      = "/auth.FederationService/BeginSAMLLogin"
+	FederationService_CompleteSAMLLogin_FullMethodName   = "/auth.FederationService/CompleteSAMLLogin"
+	FederationService_CreateLDAPDirectory_FullMethodName = "/auth.FederationService/CreateLDAPDirectory"
+	FederationService_ListLDAPDirectories_FullMethodName = "/auth.FederationService/ListLDAPDirectories"
+	FederationService_DeleteLDAPDirectory_FullMethodName = "/auth.FederationService/DeleteLDAPDirectory"
+)
+
+// FederationServiceClient is the client API for FederationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type FederationServiceClient interface {
+	// Provider configuration (tenant admins)
+	CreateOIDCProvider(ctx context.Context, in *CreateOIDCProviderRequest, opts ...grpc.CallOption) (*OIDCProvider, error)
+	ListOIDCProviders(ctx context.Context, in *ListOIDCProvidersRequest, opts ...grpc.CallOption) (*ListOIDCProvidersResponse, error)
+	DeleteOIDCProvider(ctx context.Context, in *DeleteOIDCProviderRequest, opts ...grpc.CallOption) (*DeleteOIDCProviderResponse, error)
+	// Redirect / callback flow
+	BeginOIDCLogin(ctx context.Context, in *BeginOIDCLoginRequest, opts ...grpc.CallOption) (*BeginOIDCLoginResponse, error)
+	CompleteOIDCLogin(ctx context.Context, in *CompleteOIDCLoginRequest, opts ...grpc.CallOption) (*common.Tokens, error)
+	// SAML 2.0 provider configuration (tenant admins); SP metadata is served
+	// over HTTP at /saml/{provider_id}/metadata
+	CreateSAMLProvider(ctx context.Context, in *CreateSAMLProviderRequest, opts ...grpc.CallOption) (*SAMLProvider, error)
+	ListSAMLProviders(ctx context.Context, in *ListSAMLProvidersRequest, opts ...grpc.CallOption) (*ListSAMLProvidersResponse, error)
+	DeleteSAMLProvider(ctx context.Context, in *DeleteSAMLProviderRequest, opts ...grpc.CallOption) (*DeleteSAMLProviderResponse, error)
+	// SP-initiated redirect and the ACS leg (also used for IdP-initiated logins)
+	BeginSAMLLogin(ctx context.Context, in *BeginSAMLLoginRequest, opts ...grpc.CallOption) (*BeginSAMLLoginResponse, error)
+	CompleteSAMLLogin(ctx context.Context, in *CompleteSAMLLoginRequest, opts ...grpc.CallOption) (*common.Tokens, error)
+	// LDAP / Active Directory servers that verify the tenant's password logins
+	CreateLDAPDirectory(ctx context.Context, in *CreateLDAPDirectoryRequest, opts ...grpc.CallOption) (*LDAPDirectory, error)
+	ListLDAPDirectories(ctx context.Context, in *ListLDAPDirectoriesRequest, opts ...grpc.CallOption) (*ListLDAPDirectoriesResponse, error)
+	DeleteLDAPDirectory(ctx context.Context, in *DeleteLDAPDirectoryRequest, opts ...grpc.CallOption) (*DeleteLDAPDirectoryResponse, error)
+}
+
+type federationServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFederationServiceClient(cc grpc.ClientConnInterface) FederationServiceClient {
+	return &federationServiceClient{cc}
+}
+
+func (c *federationServiceClient) CreateOIDCProvider(ctx context.Context, in *CreateOIDCProviderRequest, opts ...grpc.CallOption) (*OIDCProvider, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OIDCProvider)
+	err := c.cc.Invoke(ctx, FederationService_CreateOIDCProvider_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *federationServiceClient) ListOIDCProviders(ctx context.Context, in *ListOIDCProvidersRequest, opts ...grpc.CallOption) (*ListOIDCProvidersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOIDCProvidersResponse)
+	err := c.cc.Invoke(ctx, FederationService_ListOIDCProviders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *federationServiceClient) DeleteOIDCProvider(ctx context.Context, in *DeleteOIDCProviderRequest, opts ...grpc.CallOption) (*DeleteOIDCProviderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteOIDCProviderResponse)
+	err := c.cc.Invoke(ctx, FederationService_DeleteOIDCProvider_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *federationServiceClient) BeginOIDCLogin(ctx context.Context, in *BeginOIDCLoginRequest, opts ...grpc.CallOption) (*BeginOIDCLoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BeginOIDCLoginResponse)
+	err := c.cc.Invoke(ctx, FederationService_BeginOIDCLogin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *federationServiceClient) CompleteOIDCLogin(ctx context.Context, in *CompleteOIDCLoginRequest, opts ...grpc.CallOption) (*common.Tokens, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(common.Tokens)
+	err := c.cc.Invoke(ctx, FederationService_CompleteOIDCLogin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *federationServiceClient) CreateSAMLProvider(ctx context.Context, in *CreateSAMLProviderRequest, opts ...grpc.CallOption) (*SAMLProvider, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SAMLProvider)
+	err := c.cc.Invoke(ctx, FederationService_CreateSAMLProvider_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *federationServiceClient) ListSAMLProviders(ctx context.Context, in *ListSAMLProvidersRequest, opts ...grpc.CallOption) (*ListSAMLProvidersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSAMLProvidersResponse)
+	err := c.cc.Invoke(ctx, FederationService_ListSAMLProviders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *federationServiceClient) DeleteSAMLProvider(ctx context.Context, in *DeleteSAMLProviderRequest, opts ...grpc.CallOption) (*DeleteSAMLProviderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteSAMLProviderResponse)
+	err := c.cc.Invoke(ctx, FederationService_DeleteSAMLProvider_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *federationServiceClient) BeginSAMLLogin(ctx context.Context, in *BeginSAMLLoginRequest, opts ...grpc.CallOption) (*BeginSAMLLoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BeginSAMLLoginResponse)
+	err := c.cc.Invoke(ctx, FederationService_BeginSAMLLogin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *federationServiceClient) CompleteSAMLLogin(ctx context.Context, in *CompleteSAMLLoginRequest, opts ...grpc.CallOption) (*common.Tokens, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(common.Tokens)
+	err := c.cc.Invoke(ctx, FederationService_CompleteSAMLLogin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *federationServiceClient) CreateLDAPDirectory(ctx context.Context, in *CreateLDAPDirectoryRequest, opts ...grpc.CallOption) (*LDAPDirectory, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LDAPDirectory)
+	err := c.cc.Invoke(ctx, FederationService_CreateLDAPDirectory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *federationServiceClient) ListLDAPDirectories(ctx context.Context, in *ListLDAPDirectoriesRequest, opts ...grpc.CallOption) (*ListLDAPDirectoriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLDAPDirectoriesResponse)
+	err := c.cc.Invoke(ctx, FederationService_ListLDAPDirectories_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *federationServiceClient) DeleteLDAPDirectory(ctx context.Context, in *DeleteLDAPDirectoryRequest, opts ...grpc.CallOption) (*DeleteLDAPDirectoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteLDAPDirectoryResponse)
+	err := c.cc.Invoke(ctx, FederationService_DeleteLDAPDirectory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FederationServiceServer is the server API for FederationService service.
+// All implementations must embed UnimplementedFederationServiceServer
+// for forward compatibility.
+type FederationServiceServer interface {
+	// Provider configuration (tenant admins)
+	CreateOIDCProvider(context.Context, *CreateOIDCProviderRequest) (*OIDCProvider, error)
+	ListOIDCProviders(context.Context, *ListOIDCProvidersRequest) (*ListOIDCProvidersResponse, error)
+	DeleteOIDCProvider(context.Context, *DeleteOIDCProviderRequest) (*DeleteOIDCProviderResponse, error)
+	// Redirect / callback flow
+	BeginOIDCLogin(context.Context, *BeginOIDCLoginRequest) (*BeginOIDCLoginResponse, error)
+	CompleteOIDCLogin(context.Context, *CompleteOIDCLoginRequest) (*common.Tokens, error)
+	// SAML 2.0 provider configuration (tenant admins); SP metadata is served
+	// over HTTP at /saml/{provider_id}/metadata
+	CreateSAMLProvider(context.Context, *CreateSAMLProviderRequest) (*SAMLProvider, error)
+	ListSAMLProviders(context.Context, *ListSAMLProvidersRequest) (*ListSAMLProvidersResponse, error)
+	DeleteSAMLProvider(context.Context, *DeleteSAMLProviderRequest) (*DeleteSAMLProviderResponse, error)
+	// SP-initiated redirect and the ACS leg (also used for IdP-initiated logins)
+	BeginSAMLLogin(context.Context, *BeginSAMLLoginRequest) (*BeginSAMLLoginResponse, error)
+	CompleteSAMLLogin(context.Context, *CompleteSAMLLoginRequest) (*common.Tokens, error)
+	// LDAP / Active Directory servers that verify the tenant's password logins
+	CreateLDAPDirectory(context.Context, *CreateLDAPDirectoryRequest) (*LDAPDirectory, error)
+	ListLDAPDirectories(context.Context, *ListLDAPDirectoriesRequest) (*ListLDAPDirectoriesResponse, error)
+	DeleteLDAPDirectory(context.Context, *DeleteLDAPDirectoryRequest) (*DeleteLDAPDirectoryResponse, error)
+	mustEmbedUnimplementedFederationServiceServer()
+}
+
+// UnimplementedFederationServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedFederationServiceServer struct{}
+
+func (UnimplementedFederationServiceServer) CreateOIDCProvider(context.Context, *CreateOIDCProviderRequest) (*OIDCProvider, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOIDCProvider not implemented")
+}
+func (UnimplementedFederationServiceServer) ListOIDCProviders(context.Context, *ListOIDCProvidersRequest) (*ListOIDCProvidersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOIDCProviders not implemented")
+}
+func (UnimplementedFederationServiceServer) DeleteOIDCProvider(context.Context, *DeleteOIDCProviderRequest) (*DeleteOIDCProviderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteOIDCProvider not implemented")
+}
+func (UnimplementedFederationServiceServer) BeginOIDCLogin(context.Context, *BeginOIDCLoginRequest) (*BeginOIDCLoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BeginOIDCLogin not implemented")
+}
+func (UnimplementedFederationServiceServer) CompleteOIDCLogin(context.Context, *CompleteOIDCLoginRequest) (*common.Tokens, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompleteOIDCLogin not implemented")
+}
+func (UnimplementedFederationServiceServer) CreateSAMLProvider(context.Context, *CreateSAMLProviderRequest) (*SAMLProvider, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSAMLProvider not implemented")
+}
+func (UnimplementedFederationServiceServer) ListSAMLProviders(context.Context, *ListSAMLProvidersRequest) (*ListSAMLProvidersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSAMLProviders not implemented")
+}
+func (UnimplementedFederationServiceServer) DeleteSAMLProvider(context.Context, *DeleteSAMLProviderRequest) (*DeleteSAMLProviderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSAMLProvider not implemented")
+}
+func (UnimplementedFederationServiceServer) BeginSAMLLogin(context.Context, *BeginSAMLLoginRequest) (*BeginSAMLLoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BeginSAMLLogin not implemented")
+}
+func (UnimplementedFederationServiceServer) CompleteSAMLLogin(context.Context, *CompleteSAMLLoginRequest) (*common.Tokens, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompleteSAMLLogin not implemented")
+}
+func (UnimplementedFederationServiceServer) CreateLDAPDirectory(context.Context, *CreateLDAPDirectoryRequest) (*LDAPDirectory, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateLDAPDirectory not implemented")
+}
+func (UnimplementedFederationServiceServer) ListLDAPDirectories(context.Context, *ListLDAPDirectoriesRequest) (*ListLDAPDirectoriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLDAPDirectories not implemented")
+}
+func (UnimplementedFederationServiceServer) DeleteLDAPDirectory(context.Context, *DeleteLDAPDirectoryRequest) (*DeleteLDAPDirectoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteLDAPDirectory not implemented")
+}
+func (UnimplementedFederationServiceServer) mustEmbedUnimplementedFederationServiceServer() {}
+func (UnimplementedFederationServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeFederationServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FederationServiceServer will
+// result in compilation errors.
+type UnsafeFederationServiceServer interface {
+	mustEmbedUnimplementedFederationServiceServer()
+}
+
+func RegisterFederationServiceServer(s grpc.ServiceRegistrar, srv FederationServiceServer) {
+	// If the following call pancis, it indicates UnimplementedFederationServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&FederationService_ServiceDesc, srv)
+}
+
+func _FederationService_CreateOIDCProvider_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOIDCProviderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FederationServiceServer).CreateOIDCProvider(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FederationService_CreateOIDCProvider_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FederationServiceServer).CreateOIDCProvider(ctx, req.(*CreateOIDCProviderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FederationService_ListOIDCProviders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOIDCProvidersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FederationServiceServer).ListOIDCProviders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FederationService_ListOIDCProviders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FederationServiceServer).ListOIDCProviders(ctx, req.(*ListOIDCProvidersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FederationService_DeleteOIDCProvider_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteOIDCProviderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FederationServiceServer).DeleteOIDCProvider(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FederationService_DeleteOIDCProvider_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FederationServiceServer).DeleteOIDCProvider(ctx, req.(*DeleteOIDCProviderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FederationService_BeginOIDCLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BeginOIDCLoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FederationServiceServer).BeginOIDCLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FederationService_BeginOIDCLogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FederationServiceServer).BeginOIDCLogin(ctx, req.(*BeginOIDCLoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FederationService_CompleteOIDCLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteOIDCLoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FederationServiceServer).CompleteOIDCLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FederationService_CompleteOIDCLogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FederationServiceServer).CompleteOIDCLogin(ctx, req.(*CompleteOIDCLoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FederationService_CreateSAMLProvider_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSAMLProviderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FederationServiceServer).CreateSAMLProvider(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FederationService_CreateSAMLProvider_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FederationServiceServer).CreateSAMLProvider(ctx, req.(*CreateSAMLProviderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FederationService_ListSAMLProviders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSAMLProvidersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FederationServiceServer).ListSAMLProviders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FederationService_ListSAMLProviders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FederationServiceServer).ListSAMLProviders(ctx, req.(*ListSAMLProvidersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FederationService_DeleteSAMLProvider_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSAMLProviderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FederationServiceServer).DeleteSAMLProvider(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FederationService_DeleteSAMLProvider_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FederationServiceServer).DeleteSAMLProvider(ctx, req.(*DeleteSAMLProviderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FederationService_BeginSAMLLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BeginSAMLLoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FederationServiceServer).BeginSAMLLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FederationService_BeginSAMLLogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FederationServiceServer).BeginSAMLLogin(ctx, req.(*BeginSAMLLoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FederationService_CompleteSAMLLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteSAMLLoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FederationServiceServer).CompleteSAMLLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FederationService_CompleteSAMLLogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FederationServiceServer).CompleteSAMLLogin(ctx, req.(*CompleteSAMLLoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FederationService_CreateLDAPDirectory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateLDAPDirectoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FederationServiceServer).CreateLDAPDirectory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FederationService_CreateLDAPDirectory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FederationServiceServer).CreateLDAPDirectory(ctx, req.(*CreateLDAPDirectoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FederationService_ListLDAPDirectories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLDAPDirectoriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FederationServiceServer).ListLDAPDirectories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FederationService_ListLDAPDirectories_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FederationServiceServer).ListLDAPDirectories(ctx, req.(*ListLDAPDirectoriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FederationService_DeleteLDAPDirectory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteLDAPDirectoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FederationServiceServer).DeleteLDAPDirectory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FederationService_DeleteLDAPDirectory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FederationServiceServer).DeleteLDAPDirectory(ctx, req.(*DeleteLDAPDirectoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FederationService_ServiceDesc is the grpc.ServiceDesc for FederationService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FederationService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "auth.FederationService",
+	HandlerType: (*FederationServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateOIDCProvider",
+			Handler:    _FederationService_CreateOIDCProvider_Handler,
+		},
+		{
+			MethodName: "ListOIDCProviders",
+			Handler:    _FederationService_ListOIDCProviders_Handler,
+		},
+		{
+			MethodName: "DeleteOIDCProvider",
+			Handler:    _FederationService_DeleteOIDCProvider_Handler,
+		},
+		{
+			MethodName: "BeginOIDCLogin",
+			Handler:    _FederationService_BeginOIDCLogin_Handler,
+		},
+		{
+			MethodName: "CompleteOIDCLogin",
+			Handler:    _FederationService_CompleteOIDCLogin_Handler,
+		},
+		{
+			MethodName: "CreateSAMLProvider",
+			Handler:    _FederationService_CreateSAMLProvider_Handler,
+		},
+		{
+			MethodName: "ListSAMLProviders",
+			Handler:    _FederationService_ListSAMLProviders_Handler,
+		},
+		{
+			MethodName: "DeleteSAMLProvider",
+			Handler:    _FederationService_DeleteSAMLProvider_Handler,
+		},
+		{
+			MethodName: "BeginSAMLLogin",
+			Handler:    _FederationService_BeginSAMLLogin_Handler,
+		},
+		{
+			MethodName: "CompleteSAMLLogin",
+			Handler:    _FederationService_CompleteSAMLLogin_Handler,
+		},
+		{
+			MethodName: "CreateLDAPDirectory",
+			Handler:    _FederationService_CreateLDAPDirectory_Handler,
+		},
+		{
+			MethodName: "ListLDAPDirectories",
+			Handler:    _FederationService_ListLDAPDirectories_Handler,
+		},
+		{
+			MethodName: "DeleteLDAPDirectory",
+			Handler:    _FederationService_DeleteLDAPDirectory_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "FederationService.proto",
+}
@@ -0,0 +1,814 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        v5.29.3
+// source: OrgUnitService.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type OrgUnit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ParentId      string                 `protobuf:"bytes,3,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`           // empty for a root unit
+	RoleIds       []int64                `protobuf:"varint,4,rep,packed,name=role_ids,json=roleIds,proto3" json:"role_ids,omitempty"`      // assigned at this unit, not inherited
+	MemberCount   int32                  `protobuf:"varint,5,opt,name=member_count,json=memberCount,proto3" json:"member_count,omitempty"` // direct members only
+	CreatedAt     int64                  `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`       // unix seconds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrgUnit) Reset() {
+	*x = OrgUnit{}
+	mi := &file_OrgUnitService_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrgUnit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrgUnit) ProtoMessage() {}
+
+func (x *OrgUnit) ProtoReflect() protoreflect.Message {
+	mi := &file_OrgUnitService_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrgUnit.ProtoReflect.Descriptor instead.
+func (*OrgUnit) Descriptor() ([]byte, []int) {
+	return file_OrgUnitService_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *OrgUnit) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *OrgUnit) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *OrgUnit) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *OrgUnit) GetRoleIds() []int64 {
+	if x != nil {
+		return x.RoleIds
+	}
+	return nil
+}
+
+func (x *OrgUnit) GetMemberCount() int32 {
+	if x != nil {
+		return x.MemberCount
+	}
+	return 0
+}
+
+func (x *OrgUnit) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type OrgUnitNode struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Unit          *OrgUnit               `protobuf:"bytes,1,opt,name=unit,proto3" json:"unit,omitempty"`
+	Children      []*OrgUnitNode         `protobuf:"bytes,2,rep,name=children,proto3" json:"children,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrgUnitNode) Reset() {
+	*x = OrgUnitNode{}
+	mi := &file_OrgUnitService_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrgUnitNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrgUnitNode) ProtoMessage() {}
+
+func (x *OrgUnitNode) ProtoReflect() protoreflect.Message {
+	mi := &file_OrgUnitService_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrgUnitNode.ProtoReflect.Descriptor instead.
+func (*OrgUnitNode) Descriptor() ([]byte, []int) {
+	return file_OrgUnitService_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *OrgUnitNode) GetUnit() *OrgUnit {
+	if x != nil {
+		return x.Unit
+	}
+	return nil
+}
+
+func (x *OrgUnitNode) GetChildren() []*OrgUnitNode {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+type CreateOrgUnitRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ParentId      string                 `protobuf:"bytes,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"` // empty for a root unit
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrgUnitRequest) Reset() {
+	*x = CreateOrgUnitRequest{}
+	mi := &file_OrgUnitService_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrgUnitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrgUnitRequest) ProtoMessage() {}
+
+func (x *CreateOrgUnitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_OrgUnitService_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrgUnitRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrgUnitRequest) Descriptor() ([]byte, []int) {
+	return file_OrgUnitService_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateOrgUnitRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateOrgUnitRequest) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+type RenameOrgUnitRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgUnitId     string                 `protobuf:"bytes,1,opt,name=org_unit_id,json=orgUnitId,proto3" json:"org_unit_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenameOrgUnitRequest) Reset() {
+	*x = RenameOrgUnitRequest{}
+	mi := &file_OrgUnitService_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenameOrgUnitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameOrgUnitRequest) ProtoMessage() {}
+
+func (x *RenameOrgUnitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_OrgUnitService_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameOrgUnitRequest.ProtoReflect.Descriptor instead.
+func (*RenameOrgUnitRequest) Descriptor() ([]byte, []int) {
+	return file_OrgUnitService_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RenameOrgUnitRequest) GetOrgUnitId() string {
+	if x != nil {
+		return x.OrgUnitId
+	}
+	return ""
+}
+
+func (x *RenameOrgUnitRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type MoveOrgUnitRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgUnitId     string                 `protobuf:"bytes,1,opt,name=org_unit_id,json=orgUnitId,proto3" json:"org_unit_id,omitempty"`
+	ParentId      string                 `protobuf:"bytes,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"` // empty to make it a root unit
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveOrgUnitRequest) Reset() {
+	*x = MoveOrgUnitRequest{}
+	mi := &file_OrgUnitService_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveOrgUnitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveOrgUnitRequest) ProtoMessage() {}
+
+func (x *MoveOrgUnitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_OrgUnitService_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveOrgUnitRequest.ProtoReflect.Descriptor instead.
+func (*MoveOrgUnitRequest) Descriptor() ([]byte, []int) {
+	return file_OrgUnitService_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *MoveOrgUnitRequest) GetOrgUnitId() string {
+	if x != nil {
+		return x.OrgUnitId
+	}
+	return ""
+}
+
+func (x *MoveOrgUnitRequest) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+type DeleteOrgUnitRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgUnitId     string                 `protobuf:"bytes,1,opt,name=org_unit_id,json=orgUnitId,proto3" json:"org_unit_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteOrgUnitRequest) Reset() {
+	*x = DeleteOrgUnitRequest{}
+	mi := &file_OrgUnitService_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteOrgUnitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteOrgUnitRequest) ProtoMessage() {}
+
+func (x *DeleteOrgUnitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_OrgUnitService_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteOrgUnitRequest.ProtoReflect.Descriptor instead.
+func (*DeleteOrgUnitRequest) Descriptor() ([]byte, []int) {
+	return file_OrgUnitService_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DeleteOrgUnitRequest) GetOrgUnitId() string {
+	if x != nil {
+		return x.OrgUnitId
+	}
+	return ""
+}
+
+type DeleteOrgUnitResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteOrgUnitResponse) Reset() {
+	*x = DeleteOrgUnitResponse{}
+	mi := &file_OrgUnitService_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteOrgUnitResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteOrgUnitResponse) ProtoMessage() {}
+
+func (x *DeleteOrgUnitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_OrgUnitService_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteOrgUnitResponse.ProtoReflect.Descriptor instead.
+func (*DeleteOrgUnitResponse) Descriptor() ([]byte, []int) {
+	return file_OrgUnitService_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeleteOrgUnitResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ListOrgUnitTreeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrgUnitTreeRequest) Reset() {
+	*x = ListOrgUnitTreeRequest{}
+	mi := &file_OrgUnitService_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrgUnitTreeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrgUnitTreeRequest) ProtoMessage() {}
+
+func (x *ListOrgUnitTreeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_OrgUnitService_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrgUnitTreeRequest.ProtoReflect.Descriptor instead.
+func (*ListOrgUnitTreeRequest) Descriptor() ([]byte, []int) {
+	return file_OrgUnitService_proto_rawDescGZIP(), []int{7}
+}
+
+type ListOrgUnitTreeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Roots         []*OrgUnitNode         `protobuf:"bytes,1,rep,name=roots,proto3" json:"roots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrgUnitTreeResponse) Reset() {
+	*x = ListOrgUnitTreeResponse{}
+	mi := &file_OrgUnitService_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrgUnitTreeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrgUnitTreeResponse) ProtoMessage() {}
+
+func (x *ListOrgUnitTreeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_OrgUnitService_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrgUnitTreeResponse.ProtoReflect.Descriptor instead.
+func (*ListOrgUnitTreeResponse) Descriptor() ([]byte, []int) {
+	return file_OrgUnitService_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListOrgUnitTreeResponse) GetRoots() []*OrgUnitNode {
+	if x != nil {
+		return x.Roots
+	}
+	return nil
+}
+
+type AssignOrgUnitRoleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgUnitId     string                 `protobuf:"bytes,1,opt,name=org_unit_id,json=orgUnitId,proto3" json:"org_unit_id,omitempty"`
+	RoleId        int64                  `protobuf:"varint,2,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignOrgUnitRoleRequest) Reset() {
+	*x = AssignOrgUnitRoleRequest{}
+	mi := &file_OrgUnitService_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignOrgUnitRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignOrgUnitRoleRequest) ProtoMessage() {}
+
+func (x *AssignOrgUnitRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_OrgUnitService_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignOrgUnitRoleRequest.ProtoReflect.Descriptor instead.
+func (*AssignOrgUnitRoleRequest) Descriptor() ([]byte, []int) {
+	return file_OrgUnitService_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *AssignOrgUnitRoleRequest) GetOrgUnitId() string {
+	if x != nil {
+		return x.OrgUnitId
+	}
+	return ""
+}
+
+func (x *AssignOrgUnitRoleRequest) GetRoleId() int64 {
+	if x != nil {
+		return x.RoleId
+	}
+	return 0
+}
+
+type UnassignOrgUnitRoleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgUnitId     string                 `protobuf:"bytes,1,opt,name=org_unit_id,json=orgUnitId,proto3" json:"org_unit_id,omitempty"`
+	RoleId        int64                  `protobuf:"varint,2,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnassignOrgUnitRoleRequest) Reset() {
+	*x = UnassignOrgUnitRoleRequest{}
+	mi := &file_OrgUnitService_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnassignOrgUnitRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnassignOrgUnitRoleRequest) ProtoMessage() {}
+
+func (x *UnassignOrgUnitRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_OrgUnitService_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnassignOrgUnitRoleRequest.ProtoReflect.Descriptor instead.
+func (*UnassignOrgUnitRoleRequest) Descriptor() ([]byte, []int) {
+	return file_OrgUnitService_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UnassignOrgUnitRoleRequest) GetOrgUnitId() string {
+	if x != nil {
+		return x.OrgUnitId
+	}
+	return ""
+}
+
+func (x *UnassignOrgUnitRoleRequest) GetRoleId() int64 {
+	if x != nil {
+		return x.RoleId
+	}
+	return 0
+}
+
+type SetUserOrgUnitRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OrgUnitId     string                 `protobuf:"bytes,2,opt,name=org_unit_id,json=orgUnitId,proto3" json:"org_unit_id,omitempty"` // empty to remove the user from any unit
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetUserOrgUnitRequest) Reset() {
+	*x = SetUserOrgUnitRequest{}
+	mi := &file_OrgUnitService_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetUserOrgUnitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUserOrgUnitRequest) ProtoMessage() {}
+
+func (x *SetUserOrgUnitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_OrgUnitService_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUserOrgUnitRequest.ProtoReflect.Descriptor instead.
+func (*SetUserOrgUnitRequest) Descriptor() ([]byte, []int) {
+	return file_OrgUnitService_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SetUserOrgUnitRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetUserOrgUnitRequest) GetOrgUnitId() string {
+	if x != nil {
+		return x.OrgUnitId
+	}
+	return ""
+}
+
+type SetUserOrgUnitResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetUserOrgUnitResponse) Reset() {
+	*x = SetUserOrgUnitResponse{}
+	mi := &file_OrgUnitService_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetUserOrgUnitResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUserOrgUnitResponse) ProtoMessage() {}
+
+func (x *SetUserOrgUnitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_OrgUnitService_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUserOrgUnitResponse.ProtoReflect.Descriptor instead.
+func (*SetUserOrgUnitResponse) Descriptor() ([]byte, []int) {
+	return file_OrgUnitService_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SetUserOrgUnitResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+var File_OrgUnitService_proto protoreflect.FileDescriptor
+
+const file_OrgUnitService_proto_rawDesc = "" +
+	"\n" +
+	"\x14OrgUnitService.proto\x12\x04auth\"\xa7\x01\n" +
+	"\aOrgUnit\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
+	"\tparent_id\x18\x03 \x01(\tR\bparentId\x12\x19\n" +
+	"\brole_ids\x18\x04 \x03(\x03R\aroleIds\x12!\n" +
+	"\fmember_count\x18\x05 \x01(\x05R\vmemberCount\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\x03R\tcreatedAt\"_\n" +
+	"\vOrgUnitNode\x12!\n" +
+	"\x04unit\x18\x01 \x01(\v2\r.auth.OrgUnitR\x04unit\x12-\n" +
+	"\bchildren\x18\x02 \x03(\v2\x11.auth.OrgUnitNodeR\bchildren\"G\n" +
+	"\x14CreateOrgUnitRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1b\n" +
+	"\tparent_id\x18\x02 \x01(\tR\bparentId\"J\n" +
+	"\x14RenameOrgUnitRequest\x12\x1e\n" +
+	"\vorg_unit_id\x18\x01 \x01(\tR\torgUnitId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"Q\n" +
+	"\x12MoveOrgUnitRequest\x12\x1e\n" +
+	"\vorg_unit_id\x18\x01 \x01(\tR\torgUnitId\x12\x1b\n" +
+	"\tparent_id\x18\x02 \x01(\tR\bparentId\"6\n" +
+	"\x14DeleteOrgUnitRequest\x12\x1e\n" +
+	"\vorg_unit_id\x18\x01 \x01(\tR\torgUnitId\"1\n" +
+	"\x15DeleteOrgUnitResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x18\n" +
+	"\x16ListOrgUnitTreeRequest\"B\n" +
+	"\x17ListOrgUnitTreeResponse\x12'\n" +
+	"\x05roots\x18\x01 \x03(\v2\x11.auth.OrgUnitNodeR\x05roots\"S\n" +
+	"\x18AssignOrgUnitRoleRequest\x12\x1e\n" +
+	"\vorg_unit_id\x18\x01 \x01(\tR\torgUnitId\x12\x17\n" +
+	"\arole_id\x18\x02 \x01(\x03R\x06roleId\"U\n" +
+	"\x1aUnassignOrgUnitRoleRequest\x12\x1e\n" +
+	"\vorg_unit_id\x18\x01 \x01(\tR\torgUnitId\x12\x17\n" +
+	"\arole_id\x18\x02 \x01(\x03R\x06roleId\"P\n" +
+	"\x15SetUserOrgUnitRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1e\n" +
+	"\vorg_unit_id\x18\x02 \x01(\tR\torgUnitId\"2\n" +
+	"\x16SetUserOrgUnitResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess2\xb3\x04\n" +
+	"\x0eOrgUnitService\x12:\n" +
+	"\rCreateOrgUnit\x12\x1a.auth.CreateOrgUnitRequest\x1a\r.auth.OrgUnit\x12:\n" +
+	"\rRenameOrgUnit\x12\x1a.auth.RenameOrgUnitRequest\x1a\r.auth.OrgUnit\x126\n" +
+	"\vMoveOrgUnit\x12\x18.auth.MoveOrgUnitRequest\x1a\r.auth.OrgUnit\x12H\n" +
+	"\rDeleteOrgUnit\x12\x1a.auth.DeleteOrgUnitRequest\x1a\x1b.auth.DeleteOrgUnitResponse\x12N\n" +
+	"\x0fListOrgUnitTree\x12\x1c.auth.ListOrgUnitTreeRequest\x1a\x1d.auth.ListOrgUnitTreeResponse\x12B\n" +
+	"\x11AssignOrgUnitRole\x12\x1e.auth.AssignOrgUnitRoleRequest\x1a\r.auth.OrgUnit\x12F\n" +
+	"\x13UnassignOrgUnitRole\x12 .auth.UnassignOrgUnitRoleRequest\x1a\r.auth.OrgUnit\x12K\n" +
+	"\x0eSetUserOrgUnit\x12\x1b.auth.SetUserOrgUnitRequest\x1a\x1c.auth.SetUserOrgUnitResponseB\x16Z\x14auth-haven/pkg/protob\x06proto3"
+
+var (
+	file_OrgUnitService_proto_rawDescOnce sync.Once
+	file_OrgUnitService_proto_rawDescData []byte
+)
+
+func file_OrgUnitService_proto_rawDescGZIP() []byte {
+	file_OrgUnitService_proto_rawDescOnce.Do(func() {
+		file_OrgUnitService_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_OrgUnitService_proto_rawDesc), len(file_OrgUnitService_proto_rawDesc)))
+	})
+	return file_OrgUnitService_proto_rawDescData
+}
+
+var file_OrgUnitService_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_OrgUnitService_proto_goTypes = []any{
+	(*OrgUnit)(nil),                    // 0: auth.OrgUnit
+	(*OrgUnitNode)(nil),                // 1: auth.OrgUnitNode
+	(*CreateOrgUnitRequest)(nil),       // 2: auth.CreateOrgUnitRequest
+	(*RenameOrgUnitRequest)(nil),       // 3: auth.RenameOrgUnitRequest
+	(*MoveOrgUnitRequest)(nil),         // 4: auth.MoveOrgUnitRequest
+	(*DeleteOrgUnitRequest)(nil),       // 5: auth.DeleteOrgUnitRequest
+	(*DeleteOrgUnitResponse)(nil),      // 6: auth.DeleteOrgUnitResponse
+	(*ListOrgUnitTreeRequest)(nil),     // 7: auth.ListOrgUnitTreeRequest
+	(*ListOrgUnitTreeResponse)(nil),    // 8: auth.ListOrgUnitTreeResponse
+	(*AssignOrgUnitRoleRequest)(nil),   // 9: auth.AssignOrgUnitRoleRequest
+	(*UnassignOrgUnitRoleRequest)(nil), // 10: auth.UnassignOrgUnitRoleRequest
+	(*SetUserOrgUnitRequest)(nil),      // 11: auth.SetUserOrgUnitRequest
+	(*SetUserOrgUnitResponse)(nil),     // 12: auth.SetUserOrgUnitResponse
+}
+var file_OrgUnitService_proto_depIdxs = []int32{
+	0,  // 0: auth.OrgUnitNode.unit:type_name -> auth.OrgUnit
+	1,  // 1: auth.OrgUnitNode.children:type_name -> auth.OrgUnitNode
+	1,  // 2: auth.ListOrgUnitTreeResponse.roots:type_name -> auth.OrgUnitNode
+	2,  // 3: auth.OrgUnitService.CreateOrgUnit:input_type -> auth.CreateOrgUnitRequest
+	3,  // 4: auth.OrgUnitService.RenameOrgUnit:input_type -> auth.RenameOrgUnitRequest
+	4,  // 5: auth.OrgUnitService.MoveOrgUnit:input_type -> auth.MoveOrgUnitRequest
+	5,  // 6: auth.OrgUnitService.DeleteOrgUnit:input_type -> auth.DeleteOrgUnitRequest
+	7,  // 7: auth.OrgUnitService.ListOrgUnitTree:input_type -> auth.ListOrgUnitTreeRequest
+	9,  // 8: auth.OrgUnitService.AssignOrgUnitRole:input_type -> auth.AssignOrgUnitRoleRequest
+	10, // 9: auth.OrgUnitService.UnassignOrgUnitRole:input_type -> auth.UnassignOrgUnitRoleRequest
+	11, // 10: auth.OrgUnitService.SetUserOrgUnit:input_type -> auth.SetUserOrgUnitRequest
+	0,  // 11: auth.OrgUnitService.CreateOrgUnit:output_type -> auth.OrgUnit
+	0,  // 12: auth.OrgUnitService.RenameOrgUnit:output_type -> auth.OrgUnit
+	0,  // 13: auth.OrgUnitService.MoveOrgUnit:output_type -> auth.OrgUnit
+	6,  // 14: auth.OrgUnitService.DeleteOrgUnit:output_type -> auth.DeleteOrgUnitResponse
+	8,  // 15: auth.OrgUnitService.ListOrgUnitTree:output_type -> auth.ListOrgUnitTreeResponse
+	0,  // 16: auth.OrgUnitService.AssignOrgUnitRole:output_type -> auth.OrgUnit
+	0,  // 17: auth.OrgUnitService.UnassignOrgUnitRole:output_type -> auth.OrgUnit
+	12, // 18: auth.OrgUnitService.SetUserOrgUnit:output_type -> auth.SetUserOrgUnitResponse
+	11, // [11:19] is the sub-list for method output_type
+	3,  // [3:11] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_OrgUnitService_proto_init() }
+func file_OrgUnitService_proto_init() {
+	if File_OrgUnitService_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_OrgUnitService_proto_rawDesc), len(file_OrgUnitService_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_OrgUnitService_proto_goTypes,
+		DependencyIndexes: file_OrgUnitService_proto_depIdxs,
+		MessageInfos:      file_OrgUnitService_proto_msgTypes,
+	}.Build()
+	File_OrgUnitService_proto = out.File
+	file_OrgUnitService_proto_goTypes = nil
+	file_OrgUnitService_proto_depIdxs = nil
+}
@@ -0,0 +1,399 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: OrgUnitService.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	OrgUnitService_CreateOrgUnit_FullMethodName       = "/auth.OrgUnitService/CreateOrgUnit"
+	OrgUnitService_RenameOrgUnit_FullMethodName       = "/auth.OrgUnitService/RenameOrgUnit"
+	OrgUnitService_MoveOrgUnit_FullMethodName         = "/auth.OrgUnitService/MoveOrgUnit"
+	OrgUnitService_DeleteOrgUnit_FullMethodName       = "/auth.OrgUnitService/DeleteOrgUnit"
+	OrgUnitService_ListOrgUnitTree_FullMethodName     = "/auth.OrgUnitService/ListOrgUnitTree"
+	OrgUnitService_AssignOrgUnitRole_FullMethodName   = "/auth.OrgUnitService/AssignOrgUnitRole"
+	OrgUnitService_UnassignOrgUnitRole_FullMethodName = "/auth.OrgUnitService/UnassignOrgUnitRole"
+	OrgUnitService_SetUserOrgUnit_FullMethodName      = "/auth.OrgUnitService/SetUserOrgUnit"
+)
+
+// OrgUnitServiceClient is the client API for OrgUnitService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// The caller tenant's organisation tree. Reading needs orgunits.read, changes
+// need orgunits.manage. Roles assigned at a unit are inherited by its members
+// and by the members of every unit below it.
+type OrgUnitServiceClient interface {
+	CreateOrgUnit(ctx context.Context, in *CreateOrgUnitRequest, opts ...grpc.CallOption) (*OrgUnit, error)
+	RenameOrgUnit(ctx context.Context, in *RenameOrgUnitRequest, opts ...grpc.CallOption) (*OrgUnit, error)
+	// Fails when the new parent is the unit itself or one of its descendants
+	MoveOrgUnit(ctx context.Context, in *MoveOrgUnitRequest, opts ...grpc.CallOption) (*OrgUnit, error)
+	// Sub-units and members move up to the deleted unit's parent
+	DeleteOrgUnit(ctx context.Context, in *DeleteOrgUnitRequest, opts ...grpc.CallOption) (*DeleteOrgUnitResponse, error)
+	ListOrgUnitTree(ctx context.Context, in *ListOrgUnitTreeRequest, opts ...grpc.CallOption) (*ListOrgUnitTreeResponse, error)
+	AssignOrgUnitRole(ctx context.Context, in *AssignOrgUnitRoleRequest, opts ...grpc.CallOption) (*OrgUnit, error)
+	UnassignOrgUnitRole(ctx context.Context, in *UnassignOrgUnitRoleRequest, opts ...grpc.CallOption) (*OrgUnit, error)
+	SetUserOrgUnit(ctx context.Context, in *SetUserOrgUnitRequest, opts ...grpc.CallOption) (*SetUserOrgUnitResponse, error)
+}
+
+type orgUnitServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOrgUnitServiceClient(cc grpc.ClientConnInterface) OrgUnitServiceClient {
+	return &orgUnitServiceClient{cc}
+}
+
+func (c *orgUnitServiceClient) CreateOrgUnit(ctx context.Context, in *CreateOrgUnitRequest, opts ...grpc.CallOption) (*OrgUnit, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrgUnit)
+	err := c.cc.Invoke(ctx, OrgUnitService_CreateOrgUnit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orgUnitServiceClient) RenameOrgUnit(ctx context.Context, in *RenameOrgUnitRequest, opts ...grpc.CallOption) (*OrgUnit, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrgUnit)
+	err := c.cc.Invoke(ctx, OrgUnitService_RenameOrgUnit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orgUnitServiceClient) MoveOrgUnit(ctx context.Context, in *MoveOrgUnitRequest, opts ...grpc.CallOption) (*OrgUnit, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrgUnit)
+	err := c.cc.Invoke(ctx, OrgUnitService_MoveOrgUnit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orgUnitServiceClient) DeleteOrgUnit(ctx context.Context, in *DeleteOrgUnitRequest, opts ...grpc.CallOption) (*DeleteOrgUnitResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteOrgUnitResponse)
+	err := c.cc.Invoke(ctx, OrgUnitService_DeleteOrgUnit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orgUnitServiceClient) ListOrgUnitTree(ctx context.Context, in *ListOrgUnitTreeRequest, opts ...grpc.CallOption) (*ListOrgUnitTreeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOrgUnitTreeResponse)
+	err := c.cc.Invoke(ctx, OrgUnitService_ListOrgUnitTree_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orgUnitServiceClient) AssignOrgUnitRole(ctx context.Context, in *AssignOrgUnitRoleRequest, opts ...grpc.CallOption) (*OrgUnit, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrgUnit)
+	err := c.cc.Invoke(ctx, OrgUnitService_AssignOrgUnitRole_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orgUnitServiceClient) UnassignOrgUnitRole(ctx context.Context, in *UnassignOrgUnitRoleRequest, opts ...grpc.CallOption) (*OrgUnit, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrgUnit)
+	err := c.cc.Invoke(ctx, OrgUnitService_UnassignOrgUnitRole_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orgUnitServiceClient) SetUserOrgUnit(ctx context.Context, in *SetUserOrgUnitRequest, opts ...grpc.CallOption) (*SetUserOrgUnitResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetUserOrgUnitResponse)
+	err := c.cc.Invoke(ctx, OrgUnitService_SetUserOrgUnit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OrgUnitServiceServer is the server API for OrgUnitService service.
+// All implementations must embed UnimplementedOrgUnitServiceServer
+// for forward compatibility.
+//
+// The caller tenant's organisation tree. Reading needs orgunits.read, changes
+// need orgunits.manage. Roles assigned at a unit are inherited by its members
+// and by the members of every unit below it.
+type OrgUnitServiceServer interface {
+	CreateOrgUnit(context.Context, *CreateOrgUnitRequest) (*OrgUnit, error)
+	RenameOrgUnit(context.Context, *RenameOrgUnitRequest) (*OrgUnit, error)
+	// Fails when the new parent is the unit itself or one of its descendants
+	MoveOrgUnit(context.Context, *MoveOrgUnitRequest) (*OrgUnit, error)
+	// Sub-units and members move up to the deleted unit's parent
+	DeleteOrgUnit(context.Context, *DeleteOrgUnitRequest) (*DeleteOrgUnitResponse, error)
+	ListOrgUnitTree(context.Context, *ListOrgUnitTreeRequest) (*ListOrgUnitTreeResponse, error)
+	AssignOrgUnitRole(context.Context, *AssignOrgUnitRoleRequest) (*OrgUnit, error)
+	UnassignOrgUnitRole(context.Context, *UnassignOrgUnitRoleRequest) (*OrgUnit, error)
+	SetUserOrgUnit(context.Context, *SetUserOrgUnitRequest) (*SetUserOrgUnitResponse, error)
+	mustEmbedUnimplementedOrgUnitServiceServer()
+}
+
+// UnimplementedOrgUnitServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedOrgUnitServiceServer struct{}
+
+func (UnimplementedOrgUnitServiceServer) CreateOrgUnit(context.Context, *CreateOrgUnitRequest) (*OrgUnit, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOrgUnit not implemented")
+}
+func (UnimplementedOrgUnitServiceServer) RenameOrgUnit(context.Context, *RenameOrgUnitRequest) (*OrgUnit, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameOrgUnit not implemented")
+}
+func (UnimplementedOrgUnitServiceServer) MoveOrgUnit(context.Context, *MoveOrgUnitRequest) (*OrgUnit, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveOrgUnit not implemented")
+}
+func (UnimplementedOrgUnitServiceServer) DeleteOrgUnit(context.Context, *DeleteOrgUnitRequest) (*DeleteOrgUnitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteOrgUnit not implemented")
+}
+func (UnimplementedOrgUnitServiceServer) ListOrgUnitTree(context.Context, *ListOrgUnitTreeRequest) (*ListOrgUnitTreeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOrgUnitTree not implemented")
+}
+func (UnimplementedOrgUnitServiceServer) AssignOrgUnitRole(context.Context, *AssignOrgUnitRoleRequest) (*OrgUnit, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AssignOrgUnitRole not implemented")
+}
+func (UnimplementedOrgUnitServiceServer) UnassignOrgUnitRole(context.Context, *UnassignOrgUnitRoleRequest) (*OrgUnit, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnassignOrgUnitRole not implemented")
+}
+func (UnimplementedOrgUnitServiceServer) SetUserOrgUnit(context.Context, *SetUserOrgUnitRequest) (*SetUserOrgUnitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetUserOrgUnit not implemented")
+}
+func (UnimplementedOrgUnitServiceServer) mustEmbedUnimplementedOrgUnitServiceServer() {}
+func (UnimplementedOrgUnitServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeOrgUnitServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OrgUnitServiceServer will
+// result in compilation errors.
+type UnsafeOrgUnitServiceServer interface {
+	mustEmbedUnimplementedOrgUnitServiceServer()
+}
+
+func RegisterOrgUnitServiceServer(s grpc.ServiceRegistrar, srv OrgUnitServiceServer) {
+	// If the following call pancis, it indicates UnimplementedOrgUnitServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&OrgUnitService_ServiceDesc, srv)
+}
+
+func _OrgUnitService_CreateOrgUnit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOrgUnitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrgUnitServiceServer).CreateOrgUnit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrgUnitService_CreateOrgUnit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrgUnitServiceServer).CreateOrgUnit(ctx, req.(*CreateOrgUnitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrgUnitService_RenameOrgUnit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameOrgUnitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrgUnitServiceServer).RenameOrgUnit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrgUnitService_RenameOrgUnit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrgUnitServiceServer).RenameOrgUnit(ctx, req.(*RenameOrgUnitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrgUnitService_MoveOrgUnit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveOrgUnitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrgUnitServiceServer).MoveOrgUnit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrgUnitService_MoveOrgUnit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrgUnitServiceServer).MoveOrgUnit(ctx, req.(*MoveOrgUnitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrgUnitService_DeleteOrgUnit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteOrgUnitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrgUnitServiceServer).DeleteOrgUnit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrgUnitService_DeleteOrgUnit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrgUnitServiceServer).DeleteOrgUnit(ctx, req.(*DeleteOrgUnitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrgUnitService_ListOrgUnitTree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOrgUnitTreeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrgUnitServiceServer).ListOrgUnitTree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrgUnitService_ListOrgUnitTree_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrgUnitServiceServer).ListOrgUnitTree(ctx, req.(*ListOrgUnitTreeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrgUnitService_AssignOrgUnitRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssignOrgUnitRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrgUnitServiceServer).AssignOrgUnitRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrgUnitService_AssignOrgUnitRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrgUnitServiceServer).AssignOrgUnitRole(ctx, req.(*AssignOrgUnitRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrgUnitService_UnassignOrgUnitRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnassignOrgUnitRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrgUnitServiceServer).UnassignOrgUnitRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrgUnitService_UnassignOrgUnitRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrgUnitServiceServer).UnassignOrgUnitRole(ctx, req.(*UnassignOrgUnitRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrgUnitService_SetUserOrgUnit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetUserOrgUnitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrgUnitServiceServer).SetUserOrgUnit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrgUnitService_SetUserOrgUnit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrgUnitServiceServer).SetUserOrgUnit(ctx, req.(*SetUserOrgUnitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// OrgUnitService_ServiceDesc is the grpc.ServiceDesc for OrgUnitService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var OrgUnitService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "auth.OrgUnitService",
+	HandlerType: (*OrgUnitServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateOrgUnit",
+			Handler:    _OrgUnitService_CreateOrgUnit_Handler,
+		},
+		{
+			MethodName: "RenameOrgUnit",
+			Handler:    _OrgUnitService_RenameOrgUnit_Handler,
+		},
+		{
+			MethodName: "MoveOrgUnit",
+			Handler:    _OrgUnitService_MoveOrgUnit_Handler,
+		},
+		{
+			MethodName: "DeleteOrgUnit",
+			Handler:    _OrgUnitService_DeleteOrgUnit_Handler,
+		},
+		{
+			MethodName: "ListOrgUnitTree",
+			Handler:    _OrgUnitService_ListOrgUnitTree_Handler,
+		},
+		{
+			MethodName: "AssignOrgUnitRole",
+			Handler:    _OrgUnitService_AssignOrgUnitRole_Handler,
+		},
+		{
+			MethodName: "UnassignOrgUnitRole",
+			Handler:    _OrgUnitService_UnassignOrgUnitRole_Handler,
+		},
+		{
+			MethodName: "SetUserOrgUnit",
+			Handler:    _OrgUnitService_SetUserOrgUnit_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "OrgUnitService.proto",
+}
@@ -0,0 +1,908 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        v5.29.3
+// source: ProvisioningService.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SCIMToken struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Revoked       bool                   `protobuf:"varint,3,opt,name=revoked,proto3" json:"revoked,omitempty"`
+	LastUsedAt    int64                  `protobuf:"varint,4,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"` // unix seconds, 0 if never used
+	CreatedAt     int64                  `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SCIMToken) Reset() {
+	*x = SCIMToken{}
+	mi := &file_ProvisioningService_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SCIMToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SCIMToken) ProtoMessage() {}
+
+func (x *SCIMToken) ProtoReflect() protoreflect.Message {
+	mi := &file_ProvisioningService_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SCIMToken.ProtoReflect.Descriptor instead.
+func (*SCIMToken) Descriptor() ([]byte, []int) {
+	return file_ProvisioningService_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SCIMToken) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SCIMToken) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *SCIMToken) GetRevoked() bool {
+	if x != nil {
+		return x.Revoked
+	}
+	return false
+}
+
+func (x *SCIMToken) GetLastUsedAt() int64 {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return 0
+}
+
+func (x *SCIMToken) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type CreateSCIMTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Description   string                 `protobuf:"bytes,1,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSCIMTokenRequest) Reset() {
+	*x = CreateSCIMTokenRequest{}
+	mi := &file_ProvisioningService_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSCIMTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSCIMTokenRequest) ProtoMessage() {}
+
+func (x *CreateSCIMTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ProvisioningService_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSCIMTokenRequest.ProtoReflect.Descriptor instead.
+func (*CreateSCIMTokenRequest) Descriptor() ([]byte, []int) {
+	return file_ProvisioningService_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateSCIMTokenRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type CreateSCIMTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         *SCIMToken             `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Secret        string                 `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"` // shown once; only a hash is stored
+	BaseUrl       string                 `protobuf:"bytes,3,opt,name=base_url,json=baseUrl,proto3" json:"base_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSCIMTokenResponse) Reset() {
+	*x = CreateSCIMTokenResponse{}
+	mi := &file_ProvisioningService_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSCIMTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSCIMTokenResponse) ProtoMessage() {}
+
+func (x *CreateSCIMTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ProvisioningService_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSCIMTokenResponse.ProtoReflect.Descriptor instead.
+func (*CreateSCIMTokenResponse) Descriptor() ([]byte, []int) {
+	return file_ProvisioningService_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateSCIMTokenResponse) GetToken() *SCIMToken {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
+func (x *CreateSCIMTokenResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *CreateSCIMTokenResponse) GetBaseUrl() string {
+	if x != nil {
+		return x.BaseUrl
+	}
+	return ""
+}
+
+type ListSCIMTokensRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSCIMTokensRequest) Reset() {
+	*x = ListSCIMTokensRequest{}
+	mi := &file_ProvisioningService_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSCIMTokensRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSCIMTokensRequest) ProtoMessage() {}
+
+func (x *ListSCIMTokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ProvisioningService_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSCIMTokensRequest.ProtoReflect.Descriptor instead.
+func (*ListSCIMTokensRequest) Descriptor() ([]byte, []int) {
+	return file_ProvisioningService_proto_rawDescGZIP(), []int{3}
+}
+
+type ListSCIMTokensResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tokens        []*SCIMToken           `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSCIMTokensResponse) Reset() {
+	*x = ListSCIMTokensResponse{}
+	mi := &file_ProvisioningService_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSCIMTokensResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSCIMTokensResponse) ProtoMessage() {}
+
+func (x *ListSCIMTokensResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ProvisioningService_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSCIMTokensResponse.ProtoReflect.Descriptor instead.
+func (*ListSCIMTokensResponse) Descriptor() ([]byte, []int) {
+	return file_ProvisioningService_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListSCIMTokensResponse) GetTokens() []*SCIMToken {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+type RevokeSCIMTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TokenId       string                 `protobuf:"bytes,1,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeSCIMTokenRequest) Reset() {
+	*x = RevokeSCIMTokenRequest{}
+	mi := &file_ProvisioningService_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeSCIMTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeSCIMTokenRequest) ProtoMessage() {}
+
+func (x *RevokeSCIMTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ProvisioningService_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeSCIMTokenRequest.ProtoReflect.Descriptor instead.
+func (*RevokeSCIMTokenRequest) Descriptor() ([]byte, []int) {
+	return file_ProvisioningService_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RevokeSCIMTokenRequest) GetTokenId() string {
+	if x != nil {
+		return x.TokenId
+	}
+	return ""
+}
+
+type RevokeSCIMTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeSCIMTokenResponse) Reset() {
+	*x = RevokeSCIMTokenResponse{}
+	mi := &file_ProvisioningService_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeSCIMTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeSCIMTokenResponse) ProtoMessage() {}
+
+func (x *RevokeSCIMTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ProvisioningService_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeSCIMTokenResponse.ProtoReflect.Descriptor instead.
+func (*RevokeSCIMTokenResponse) Descriptor() ([]byte, []int) {
+	return file_ProvisioningService_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RevokeSCIMTokenResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type JITCondition struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Claim         string                 `protobuf:"bytes,1,opt,name=claim,proto3" json:"claim,omitempty"` // dotted path, e.g. "groups" or "org.department"
+	Op            string                 `protobuf:"bytes,2,opt,name=op,proto3" json:"op,omitempty"`       // equals, contains, matches, exists
+	Values        []string               `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty"`
+	Negate        bool                   `protobuf:"varint,4,opt,name=negate,proto3" json:"negate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JITCondition) Reset() {
+	*x = JITCondition{}
+	mi := &file_ProvisioningService_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JITCondition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JITCondition) ProtoMessage() {}
+
+func (x *JITCondition) ProtoReflect() protoreflect.Message {
+	mi := &file_ProvisioningService_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JITCondition.ProtoReflect.Descriptor instead.
+func (*JITCondition) Descriptor() ([]byte, []int) {
+	return file_ProvisioningService_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *JITCondition) GetClaim() string {
+	if x != nil {
+		return x.Claim
+	}
+	return ""
+}
+
+func (x *JITCondition) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *JITCondition) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *JITCondition) GetNegate() bool {
+	if x != nil {
+		return x.Negate
+	}
+	return false
+}
+
+type JITRule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Effect        string                 `protobuf:"bytes,2,opt,name=effect,proto3" json:"effect,omitempty"`         // grant or deny
+	Conditions    []*JITCondition        `protobuf:"bytes,3,rep,name=conditions,proto3" json:"conditions,omitempty"` // all must hold
+	Roles         []string               `protobuf:"bytes,4,rep,name=roles,proto3" json:"roles,omitempty"`
+	Final         bool                   `protobuf:"varint,5,opt,name=final,proto3" json:"final,omitempty"` // stop evaluating after this rule matches
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JITRule) Reset() {
+	*x = JITRule{}
+	mi := &file_ProvisioningService_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JITRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JITRule) ProtoMessage() {}
+
+func (x *JITRule) ProtoReflect() protoreflect.Message {
+	mi := &file_ProvisioningService_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JITRule.ProtoReflect.Descriptor instead.
+func (*JITRule) Descriptor() ([]byte, []int) {
+	return file_ProvisioningService_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *JITRule) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *JITRule) GetEffect() string {
+	if x != nil {
+		return x.Effect
+	}
+	return ""
+}
+
+func (x *JITRule) GetConditions() []*JITCondition {
+	if x != nil {
+		return x.Conditions
+	}
+	return nil
+}
+
+func (x *JITRule) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *JITRule) GetFinal() bool {
+	if x != nil {
+		return x.Final
+	}
+	return false
+}
+
+type JITPolicy struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AutoCreate    bool                   `protobuf:"varint,1,opt,name=auto_create,json=autoCreate,proto3" json:"auto_create,omitempty"`
+	DefaultRole   string                 `protobuf:"bytes,2,opt,name=default_role,json=defaultRole,proto3" json:"default_role,omitempty"` // granted when no grant rule matches
+	Rules         []*JITRule             `protobuf:"bytes,3,rep,name=rules,proto3" json:"rules,omitempty"`
+	UpdatedAt     int64                  `protobuf:"varint,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JITPolicy) Reset() {
+	*x = JITPolicy{}
+	mi := &file_ProvisioningService_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JITPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JITPolicy) ProtoMessage() {}
+
+func (x *JITPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_ProvisioningService_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JITPolicy.ProtoReflect.Descriptor instead.
+func (*JITPolicy) Descriptor() ([]byte, []int) {
+	return file_ProvisioningService_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *JITPolicy) GetAutoCreate() bool {
+	if x != nil {
+		return x.AutoCreate
+	}
+	return false
+}
+
+func (x *JITPolicy) GetDefaultRole() string {
+	if x != nil {
+		return x.DefaultRole
+	}
+	return ""
+}
+
+func (x *JITPolicy) GetRules() []*JITRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *JITPolicy) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+type GetJITPolicyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJITPolicyRequest) Reset() {
+	*x = GetJITPolicyRequest{}
+	mi := &file_ProvisioningService_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJITPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJITPolicyRequest) ProtoMessage() {}
+
+func (x *GetJITPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ProvisioningService_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJITPolicyRequest.ProtoReflect.Descriptor instead.
+func (*GetJITPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_ProvisioningService_proto_rawDescGZIP(), []int{10}
+}
+
+type SetJITPolicyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Policy        *JITPolicy             `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetJITPolicyRequest) Reset() {
+	*x = SetJITPolicyRequest{}
+	mi := &file_ProvisioningService_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetJITPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetJITPolicyRequest) ProtoMessage() {}
+
+func (x *SetJITPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ProvisioningService_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetJITPolicyRequest.ProtoReflect.Descriptor instead.
+func (*SetJITPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_ProvisioningService_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SetJITPolicyRequest) GetPolicy() *JITPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+type EvaluateJITPolicyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClaimsJson    string                 `protobuf:"bytes,1,opt,name=claims_json,json=claimsJson,proto3" json:"claims_json,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvaluateJITPolicyRequest) Reset() {
+	*x = EvaluateJITPolicyRequest{}
+	mi := &file_ProvisioningService_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvaluateJITPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluateJITPolicyRequest) ProtoMessage() {}
+
+func (x *EvaluateJITPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ProvisioningService_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluateJITPolicyRequest.ProtoReflect.Descriptor instead.
+func (*EvaluateJITPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_ProvisioningService_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *EvaluateJITPolicyRequest) GetClaimsJson() string {
+	if x != nil {
+		return x.ClaimsJson
+	}
+	return ""
+}
+
+type EvaluateJITPolicyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Denied        bool                   `protobuf:"varint,1,opt,name=denied,proto3" json:"denied,omitempty"`
+	DenyRule      string                 `protobuf:"bytes,2,opt,name=deny_rule,json=denyRule,proto3" json:"deny_rule,omitempty"`
+	Roles         []string               `protobuf:"bytes,3,rep,name=roles,proto3" json:"roles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvaluateJITPolicyResponse) Reset() {
+	*x = EvaluateJITPolicyResponse{}
+	mi := &file_ProvisioningService_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvaluateJITPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluateJITPolicyResponse) ProtoMessage() {}
+
+func (x *EvaluateJITPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ProvisioningService_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluateJITPolicyResponse.ProtoReflect.Descriptor instead.
+func (*EvaluateJITPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_ProvisioningService_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *EvaluateJITPolicyResponse) GetDenied() bool {
+	if x != nil {
+		return x.Denied
+	}
+	return false
+}
+
+func (x *EvaluateJITPolicyResponse) GetDenyRule() string {
+	if x != nil {
+		return x.DenyRule
+	}
+	return ""
+}
+
+func (x *EvaluateJITPolicyResponse) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+var File_ProvisioningService_proto protoreflect.FileDescriptor
+
+const file_ProvisioningService_proto_rawDesc = "" +
+	"\n" +
+	"\x19ProvisioningService.proto\x12\x04auth\"\x98\x01\n" +
+	"\tSCIMToken\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x18\n" +
+	"\arevoked\x18\x03 \x01(\bR\arevoked\x12 \n" +
+	"\flast_used_at\x18\x04 \x01(\x03R\n" +
+	"lastUsedAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\x03R\tcreatedAt\":\n" +
+	"\x16CreateSCIMTokenRequest\x12 \n" +
+	"\vdescription\x18\x01 \x01(\tR\vdescription\"s\n" +
+	"\x17CreateSCIMTokenResponse\x12%\n" +
+	"\x05token\x18\x01 \x01(\v2\x0f.auth.SCIMTokenR\x05token\x12\x16\n" +
+	"\x06secret\x18\x02 \x01(\tR\x06secret\x12\x19\n" +
+	"\bbase_url\x18\x03 \x01(\tR\abaseUrl\"\x17\n" +
+	"\x15ListSCIMTokensRequest\"A\n" +
+	"\x16ListSCIMTokensResponse\x12'\n" +
+	"\x06tokens\x18\x01 \x03(\v2\x0f.auth.SCIMTokenR\x06tokens\"3\n" +
+	"\x16RevokeSCIMTokenRequest\x12\x19\n" +
+	"\btoken_id\x18\x01 \x01(\tR\atokenId\"3\n" +
+	"\x17RevokeSCIMTokenResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"d\n" +
+	"\fJITCondition\x12\x14\n" +
+	"\x05claim\x18\x01 \x01(\tR\x05claim\x12\x0e\n" +
+	"\x02op\x18\x02 \x01(\tR\x02op\x12\x16\n" +
+	"\x06values\x18\x03 \x03(\tR\x06values\x12\x16\n" +
+	"\x06negate\x18\x04 \x01(\bR\x06negate\"\x95\x01\n" +
+	"\aJITRule\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06effect\x18\x02 \x01(\tR\x06effect\x122\n" +
+	"\n" +
+	"conditions\x18\x03 \x03(\v2\x12.auth.JITConditionR\n" +
+	"conditions\x12\x14\n" +
+	"\x05roles\x18\x04 \x03(\tR\x05roles\x12\x14\n" +
+	"\x05final\x18\x05 \x01(\bR\x05final\"\x93\x01\n" +
+	"\tJITPolicy\x12\x1f\n" +
+	"\vauto_create\x18\x01 \x01(\bR\n" +
+	"autoCreate\x12!\n" +
+	"\fdefault_role\x18\x02 \x01(\tR\vdefaultRole\x12#\n" +
+	"\x05rules\x18\x03 \x03(\v2\r.auth.JITRuleR\x05rules\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\x03R\tupdatedAt\"\x15\n" +
+	"\x13GetJITPolicyRequest\">\n" +
+	"\x13SetJITPolicyRequest\x12'\n" +
+	"\x06policy\x18\x01 \x01(\v2\x0f.auth.JITPolicyR\x06policy\";\n" +
+	"\x18EvaluateJITPolicyRequest\x12\x1f\n" +
+	"\vclaims_json\x18\x01 \x01(\tR\n" +
+	"claimsJson\"f\n" +
+	"\x19EvaluateJITPolicyResponse\x12\x16\n" +
+	"\x06denied\x18\x01 \x01(\bR\x06denied\x12\x1b\n" +
+	"\tdeny_rule\x18\x02 \x01(\tR\bdenyRule\x12\x14\n" +
+	"\x05roles\x18\x03 \x03(\tR\x05roles2\xd0\x03\n" +
+	"\x13ProvisioningService\x12N\n" +
+	"\x0fCreateSCIMToken\x12\x1c.auth.CreateSCIMTokenRequest\x1a\x1d.auth.CreateSCIMTokenResponse\x12K\n" +
+	"\x0eListSCIMTokens\x12\x1b.auth.ListSCIMTokensRequest\x1a\x1c.auth.ListSCIMTokensResponse\x12N\n" +
+	"\x0fRevokeSCIMToken\x12\x1c.auth.RevokeSCIMTokenRequest\x1a\x1d.auth.RevokeSCIMTokenResponse\x12:\n" +
+	"\fGetJITPolicy\x12\x19.auth.GetJITPolicyRequest\x1a\x0f.auth.JITPolicy\x12:\n" +
+	"\fSetJITPolicy\x12\x19.auth.SetJITPolicyRequest\x1a\x0f.auth.JITPolicy\x12T\n" +
+	"\x11EvaluateJITPolicy\x12\x1e.auth.EvaluateJITPolicyRequest\x1a\x1f.auth.EvaluateJITPolicyResponseB\x16Z\x14auth-haven/pkg/protob\x06proto3"
+
+var (
+	file_ProvisioningService_proto_rawDescOnce sync.Once
+	file_ProvisioningService_proto_rawDescData []byte
+)
+
+func file_ProvisioningService_proto_rawDescGZIP() []byte {
+	file_ProvisioningService_proto_rawDescOnce.Do(func() {
+		file_ProvisioningService_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_ProvisioningService_proto_rawDesc), len(file_ProvisioningService_proto_rawDesc)))
+	})
+	return file_ProvisioningService_proto_rawDescData
+}
+
+var file_ProvisioningService_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_ProvisioningService_proto_goTypes = []any{
+	(*SCIMToken)(nil),                 // 0: auth.SCIMToken
+	(*CreateSCIMTokenRequest)(nil),    // 1: auth.CreateSCIMTokenRequest
+	(*CreateSCIMTokenResponse)(nil),   // 2: auth.CreateSCIMTokenResponse
+	(*ListSCIMTokensRequest)(nil),     // 3: auth.ListSCIMTokensRequest
+	(*ListSCIMTokensResponse)(nil),    // 4: auth.ListSCIMTokensResponse
+	(*RevokeSCIMTokenRequest)(nil),    // 5: auth.RevokeSCIMTokenRequest
+	(*RevokeSCIMTokenResponse)(nil),   // 6: auth.RevokeSCIMTokenResponse
+	(*JITCondition)(nil),              // 7: auth.JITCondition
+	(*JITRule)(nil),                   // 8: auth.JITRule
+	(*JITPolicy)(nil),                 // 9: auth.JITPolicy
+	(*GetJITPolicyRequest)(nil),       // 10: auth.GetJITPolicyRequest
+	(*SetJITPolicyRequest)(nil),       // 11: auth.SetJITPolicyRequest
+	(*EvaluateJITPolicyRequest)(nil),  // 12: auth.EvaluateJITPolicyRequest
+	(*EvaluateJITPolicyResponse)(nil), // 13: auth.EvaluateJITPolicyResponse
+}
+var file_ProvisioningService_proto_depIdxs = []int32{
+	0,  // 0: auth.CreateSCIMTokenResponse.token:type_name -> auth.SCIMToken
+	0,  // 1: auth.ListSCIMTokensResponse.tokens:type_name -> auth.SCIMToken
+	7,  // 2: auth.JITRule.conditions:type_name -> auth.JITCondition
+	8,  // 3: auth.JITPolicy.rules:type_name -> auth.JITRule
+	9,  // 4: auth.SetJITPolicyRequest.policy:type_name -> auth.JITPolicy
+	1,  // 5: auth.ProvisioningService.CreateSCIMToken:input_type -> auth.CreateSCIMTokenRequest
+	3,  // 6: auth.ProvisioningService.ListSCIMTokens:input_type -> auth.ListSCIMTokensRequest
+	5,  // 7: auth.ProvisioningService.RevokeSCIMToken:input_type -> auth.RevokeSCIMTokenRequest
+	10, // 8: auth.ProvisioningService.GetJITPolicy:input_type -> auth.GetJITPolicyRequest
+	11, // 9: auth.ProvisioningService.SetJITPolicy:input_type -> auth.SetJITPolicyRequest
+	12, // 10: auth.ProvisioningService.EvaluateJITPolicy:input_type -> auth.EvaluateJITPolicyRequest
+	2,  // 11: auth.ProvisioningService.CreateSCIMToken:output_type -> auth.CreateSCIMTokenResponse
+	4,  // 12: auth.ProvisioningService.ListSCIMTokens:output_type -> auth.ListSCIMTokensResponse
+	6,  // 13: auth.ProvisioningService.RevokeSCIMToken:output_type -> auth.RevokeSCIMTokenResponse
+	9,  // 14: auth.ProvisioningService.GetJITPolicy:output_type -> auth.JITPolicy
+	9,  // 15: auth.ProvisioningService.SetJITPolicy:output_type -> auth.JITPolicy
+	13, // 16: auth.ProvisioningService.EvaluateJITPolicy:output_type -> auth.EvaluateJITPolicyResponse
+	11, // [11:17] is the sub-list for method output_type
+	5,  // [5:11] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_ProvisioningService_proto_init() }
+func file_ProvisioningService_proto_init() {
+	if File_ProvisioningService_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ProvisioningService_proto_rawDesc), len(file_ProvisioningService_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   14,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_ProvisioningService_proto_goTypes,
+		DependencyIndexes: file_ProvisioningService_proto_depIdxs,
+		MessageInfos:      file_ProvisioningService_proto_msgTypes,
+	}.Build()
+	File_ProvisioningService_proto = out.File
+	file_ProvisioningService_proto_goTypes = nil
+	file_ProvisioningService_proto_depIdxs = nil
+}
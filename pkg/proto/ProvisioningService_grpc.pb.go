@@ -0,0 +1,323 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: ProvisioningService.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ProvisioningService_CreateSCIMToken_FullMethodName   = "/auth.ProvisioningService/CreateSCIMToken"
+	ProvisioningService_ListSCIMTokens_FullMethodName    = "/auth.ProvisioningService/ListSCIMTokens"
+	ProvisioningService_RevokeSCIMToken_FullMethodName   = "/auth.ProvisioningService/RevokeSCIMToken"
+	ProvisioningService_GetJITPolicy_FullMethodName      = "/auth.ProvisioningService/GetJITPolicy"
+	ProvisioningService_SetJITPolicy_FullMethodName      = "/auth.ProvisioningService/SetJITPolicy"
+	ProvisioningService_EvaluateJITPolicy_FullMethodName = "/auth.ProvisioningService/EvaluateJITPolicy"
+)
+
+// ProvisioningServiceClient is the client API for ProvisioningService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Account provisioning from external identity sources
+type ProvisioningServiceClient interface {
+	// Bearer tokens identity providers use to call the SCIM 2.0 endpoints
+	// served over HTTP at /scim/v2
+	CreateSCIMToken(ctx context.Context, in *CreateSCIMTokenRequest, opts ...grpc.CallOption) (*CreateSCIMTokenResponse, error)
+	ListSCIMTokens(ctx context.Context, in *ListSCIMTokensRequest, opts ...grpc.CallOption) (*ListSCIMTokensResponse, error)
+	RevokeSCIMToken(ctx context.Context, in *RevokeSCIMTokenRequest, opts ...grpc.CallOption) (*RevokeSCIMTokenResponse, error)
+	// Just-in-time provisioning rules applied to federated sign-ins
+	GetJITPolicy(ctx context.Context, in *GetJITPolicyRequest, opts ...grpc.CallOption) (*JITPolicy, error)
+	SetJITPolicy(ctx context.Context, in *SetJITPolicyRequest, opts ...grpc.CallOption) (*JITPolicy, error)
+	// Dry-runs the stored rules against a claim set
+	EvaluateJITPolicy(ctx context.Context, in *EvaluateJITPolicyRequest, opts ...grpc.CallOption) (*EvaluateJITPolicyResponse, error)
+}
+
+type provisioningServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewProvisioningServiceClient(cc grpc.ClientConnInterface) ProvisioningServiceClient {
+	return &provisioningServiceClient{cc}
+}
+
+func (c *provisioningServiceClient) CreateSCIMToken(ctx context.Context, in *CreateSCIMTokenRequest, opts ...grpc.CallOption) (*CreateSCIMTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateSCIMTokenResponse)
+	err := c.cc.Invoke(ctx, ProvisioningService_CreateSCIMToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *provisioningServiceClient) ListSCIMTokens(ctx context.Context, in *ListSCIMTokensRequest, opts ...grpc.CallOption) (*ListSCIMTokensResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSCIMTokensResponse)
+	err := c.cc.Invoke(ctx, ProvisioningService_ListSCIMTokens_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *provisioningServiceClient) RevokeSCIMToken(ctx context.Context, in *RevokeSCIMTokenRequest, opts ...grpc.CallOption) (*RevokeSCIMTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeSCIMTokenResponse)
+	err := c.cc.Invoke(ctx, ProvisioningService_RevokeSCIMToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *provisioningServiceClient) GetJITPolicy(ctx context.Context, in *GetJITPolicyRequest, opts ...grpc.CallOption) (*JITPolicy, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(JITPolicy)
+	err := c.cc.Invoke(ctx, ProvisioningService_GetJITPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *provisioningServiceClient) SetJITPolicy(ctx context.Context, in *SetJITPolicyRequest, opts ...grpc.CallOption) (*JITPolicy, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(JITPolicy)
+	err := c.cc.Invoke(ctx, ProvisioningService_SetJITPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *provisioningServiceClient) EvaluateJITPolicy(ctx context.Context, in *EvaluateJITPolicyRequest, opts ...grpc.CallOption) (*EvaluateJITPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EvaluateJITPolicyResponse)
+	err := c.cc.Invoke(ctx, ProvisioningService_EvaluateJITPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ProvisioningServiceServer is the server API for ProvisioningService service.
+// All implementations must embed UnimplementedProvisioningServiceServer
+// for forward compatibility.
+//
+// Account provisioning from external identity sources
+type ProvisioningServiceServer interface {
+	// Bearer tokens identity providers use to call the SCIM 2.0 endpoints
+	// served over HTTP at /scim/v2
+	CreateSCIMToken(context.Context, *CreateSCIMTokenRequest) (*CreateSCIMTokenResponse, error)
+	ListSCIMTokens(context.Context, *ListSCIMTokensRequest) (*ListSCIMTokensResponse, error)
+	RevokeSCIMToken(context.Context, *RevokeSCIMTokenRequest) (*RevokeSCIMTokenResponse, error)
+	// Just-in-time provisioning rules applied to federated sign-ins
+	GetJITPolicy(context.Context, *GetJITPolicyRequest) (*JITPolicy, error)
+	SetJITPolicy(context.Context, *SetJITPolicyRequest) (*JITPolicy, error)
+	// Dry-runs the stored rules against a claim set
+	EvaluateJITPolicy(context.Context, *EvaluateJITPolicyRequest) (*EvaluateJITPolicyResponse, error)
+	mustEmbedUnimplementedProvisioningServiceServer()
+}
+
+// UnimplementedProvisioningServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedProvisioningServiceServer struct{}
+
+func (UnimplementedProvisioningServiceServer) CreateSCIMToken(context.Context, *CreateSCIMTokenRequest) (*CreateSCIMTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSCIMToken not implemented")
+}
+func (UnimplementedProvisioningServiceServer) ListSCIMTokens(context.Context, *ListSCIMTokensRequest) (*ListSCIMTokensResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSCIMTokens not implemented")
+}
+func (UnimplementedProvisioningServiceServer) RevokeSCIMToken(context.Context, *RevokeSCIMTokenRequest) (*RevokeSCIMTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeSCIMToken not implemented")
+}
+func (UnimplementedProvisioningServiceServer) GetJITPolicy(context.Context, *GetJITPolicyRequest) (*JITPolicy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJITPolicy not implemented")
+}
+func (UnimplementedProvisioningServiceServer) SetJITPolicy(context.Context, *SetJITPolicyRequest) (*JITPolicy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetJITPolicy not implemented")
+}
+func (UnimplementedProvisioningServiceServer) EvaluateJITPolicy(context.Context, *EvaluateJITPolicyRequest) (*EvaluateJITPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EvaluateJITPolicy not implemented")
+}
+func (UnimplementedProvisioningServiceServer) mustEmbedUnimplementedProvisioningServiceServer() {}
+func (UnimplementedProvisioningServiceServer) testEmbeddedByValue()                             {}
+
+// UnsafeProvisioningServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ProvisioningServiceServer will
+// result in compilation errors.
+type UnsafeProvisioningServiceServer interface {
+	mustEmbedUnimplementedProvisioningServiceServer()
+}
+
+func RegisterProvisioningServiceServer(s grpc.ServiceRegistrar, srv ProvisioningServiceServer) {
+	// If the following call pancis, it indicates UnimplementedProvisioningServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ProvisioningService_ServiceDesc, srv)
+}
+
+func _ProvisioningService_CreateSCIMToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSCIMTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProvisioningServiceServer).CreateSCIMToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProvisioningService_CreateSCIMToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProvisioningServiceServer).CreateSCIMToken(ctx, req.(*CreateSCIMTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProvisioningService_ListSCIMTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSCIMTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProvisioningServiceServer).ListSCIMTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProvisioningService_ListSCIMTokens_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProvisioningServiceServer).ListSCIMTokens(ctx, req.(*ListSCIMTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProvisioningService_RevokeSCIMToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeSCIMTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProvisioningServiceServer).RevokeSCIMToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProvisioningService_RevokeSCIMToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProvisioningServiceServer).RevokeSCIMToken(ctx, req.(*RevokeSCIMTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProvisioningService_GetJITPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJITPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProvisioningServiceServer).GetJITPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProvisioningService_GetJITPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProvisioningServiceServer).GetJITPolicy(ctx, req.(*GetJITPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProvisioningService_SetJITPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetJITPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProvisioningServiceServer).SetJITPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProvisioningService_SetJITPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProvisioningServiceServer).SetJITPolicy(ctx, req.(*SetJITPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProvisioningService_EvaluateJITPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EvaluateJITPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProvisioningServiceServer).EvaluateJITPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProvisioningService_EvaluateJITPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProvisioningServiceServer).EvaluateJITPolicy(ctx, req.(*EvaluateJITPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ProvisioningService_ServiceDesc is the grpc.ServiceDesc for ProvisioningService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ProvisioningService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "auth.ProvisioningService",
+	HandlerType: (*ProvisioningServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateSCIMToken",
+			Handler:    _ProvisioningService_CreateSCIMToken_Handler,
+		},
+		{
+			MethodName: "ListSCIMTokens",
+			Handler:    _ProvisioningService_ListSCIMTokens_Handler,
+		},
+		{
+			MethodName: "RevokeSCIMToken",
+			Handler:    _ProvisioningService_RevokeSCIMToken_Handler,
+		},
+		{
+			MethodName: "GetJITPolicy",
+			Handler:    _ProvisioningService_GetJITPolicy_Handler,
+		},
+		{
+			MethodName: "SetJITPolicy",
+			Handler:    _ProvisioningService_SetJITPolicy_Handler,
+		},
+		{
+			MethodName: "EvaluateJITPolicy",
+			Handler:    _ProvisioningService_EvaluateJITPolicy_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "ProvisioningService.proto",
+}
@@ -0,0 +1,1333 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        v5.29.3
+// source: RelationshipService.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ObjectRef struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ObjectRef) Reset() {
+	*x = ObjectRef{}
+	mi := &file_RelationshipService_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ObjectRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ObjectRef) ProtoMessage() {}
+
+func (x *ObjectRef) ProtoReflect() protoreflect.Message {
+	mi := &file_RelationshipService_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ObjectRef.ProtoReflect.Descriptor instead.
+func (*ObjectRef) Descriptor() ([]byte, []int) {
+	return file_RelationshipService_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ObjectRef) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ObjectRef) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// A subject with a relation is a userset: everyone holding that relation to
+// the object
+type SubjectRef struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Object        *ObjectRef             `protobuf:"bytes,1,opt,name=object,proto3" json:"object,omitempty"`
+	Relation      string                 `protobuf:"bytes,2,opt,name=relation,proto3" json:"relation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubjectRef) Reset() {
+	*x = SubjectRef{}
+	mi := &file_RelationshipService_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubjectRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubjectRef) ProtoMessage() {}
+
+func (x *SubjectRef) ProtoReflect() protoreflect.Message {
+	mi := &file_RelationshipService_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubjectRef.ProtoReflect.Descriptor instead.
+func (*SubjectRef) Descriptor() ([]byte, []int) {
+	return file_RelationshipService_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SubjectRef) GetObject() *ObjectRef {
+	if x != nil {
+		return x.Object
+	}
+	return nil
+}
+
+func (x *SubjectRef) GetRelation() string {
+	if x != nil {
+		return x.Relation
+	}
+	return ""
+}
+
+type Relationship struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Object        *ObjectRef             `protobuf:"bytes,1,opt,name=object,proto3" json:"object,omitempty"`
+	Relation      string                 `protobuf:"bytes,2,opt,name=relation,proto3" json:"relation,omitempty"`
+	Subject       *SubjectRef            `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Relationship) Reset() {
+	*x = Relationship{}
+	mi := &file_RelationshipService_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Relationship) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Relationship) ProtoMessage() {}
+
+func (x *Relationship) ProtoReflect() protoreflect.Message {
+	mi := &file_RelationshipService_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Relationship.ProtoReflect.Descriptor instead.
+func (*Relationship) Descriptor() ([]byte, []int) {
+	return file_RelationshipService_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Relationship) GetObject() *ObjectRef {
+	if x != nil {
+		return x.Object
+	}
+	return nil
+}
+
+func (x *Relationship) GetRelation() string {
+	if x != nil {
+		return x.Relation
+	}
+	return ""
+}
+
+func (x *Relationship) GetSubject() *SubjectRef {
+	if x != nil {
+		return x.Subject
+	}
+	return nil
+}
+
+// How fresh the data a read is evaluated against must be. Unset means
+// minimize_latency.
+type Consistency struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Requirement:
+	//
+	//	*Consistency_MinimizeLatency
+	//	*Consistency_FullyConsistent
+	//	*Consistency_AtLeastAsFresh
+	//	*Consistency_AtExactSnapshot
+	Requirement   isConsistency_Requirement `protobuf_oneof:"requirement"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Consistency) Reset() {
+	*x = Consistency{}
+	mi := &file_RelationshipService_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Consistency) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Consistency) ProtoMessage() {}
+
+func (x *Consistency) ProtoReflect() protoreflect.Message {
+	mi := &file_RelationshipService_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Consistency.ProtoReflect.Descriptor instead.
+func (*Consistency) Descriptor() ([]byte, []int) {
+	return file_RelationshipService_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Consistency) GetRequirement() isConsistency_Requirement {
+	if x != nil {
+		return x.Requirement
+	}
+	return nil
+}
+
+func (x *Consistency) GetMinimizeLatency() bool {
+	if x != nil {
+		if x, ok := x.Requirement.(*Consistency_MinimizeLatency); ok {
+			return x.MinimizeLatency
+		}
+	}
+	return false
+}
+
+func (x *Consistency) GetFullyConsistent() bool {
+	if x != nil {
+		if x, ok := x.Requirement.(*Consistency_FullyConsistent); ok {
+			return x.FullyConsistent
+		}
+	}
+	return false
+}
+
+func (x *Consistency) GetAtLeastAsFresh() string {
+	if x != nil {
+		if x, ok := x.Requirement.(*Consistency_AtLeastAsFresh); ok {
+			return x.AtLeastAsFresh
+		}
+	}
+	return ""
+}
+
+func (x *Consistency) GetAtExactSnapshot() string {
+	if x != nil {
+		if x, ok := x.Requirement.(*Consistency_AtExactSnapshot); ok {
+			return x.AtExactSnapshot
+		}
+	}
+	return ""
+}
+
+type isConsistency_Requirement interface {
+	isConsistency_Requirement()
+}
+
+type Consistency_MinimizeLatency struct {
+	// Cached results may be used
+	MinimizeLatency bool `protobuf:"varint,1,opt,name=minimize_latency,json=minimizeLatency,proto3,oneof"`
+}
+
+type Consistency_FullyConsistent struct {
+	// Evaluated at the latest revision, bypassing caches
+	FullyConsistent bool `protobuf:"varint,2,opt,name=fully_consistent,json=fullyConsistent,proto3,oneof"`
+}
+
+type Consistency_AtLeastAsFresh struct {
+	// Evaluated at the token's revision or a later one
+	AtLeastAsFresh string `protobuf:"bytes,3,opt,name=at_least_as_fresh,json=atLeastAsFresh,proto3,oneof"`
+}
+
+type Consistency_AtExactSnapshot struct {
+	// Evaluated at exactly the token's revision
+	AtExactSnapshot string `protobuf:"bytes,4,opt,name=at_exact_snapshot,json=atExactSnapshot,proto3,oneof"`
+}
+
+func (*Consistency_MinimizeLatency) isConsistency_Requirement() {}
+
+func (*Consistency_FullyConsistent) isConsistency_Requirement() {}
+
+func (*Consistency_AtLeastAsFresh) isConsistency_Requirement() {}
+
+func (*Consistency_AtExactSnapshot) isConsistency_Requirement() {}
+
+type RelationshipSchema struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schema        string                 `protobuf:"bytes,1,opt,name=schema,proto3" json:"schema,omitempty"`
+	Revision      int64                  `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	UpdatedAt     int64                  `protobuf:"varint,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"` // unix seconds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RelationshipSchema) Reset() {
+	*x = RelationshipSchema{}
+	mi := &file_RelationshipService_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelationshipSchema) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelationshipSchema) ProtoMessage() {}
+
+func (x *RelationshipSchema) ProtoReflect() protoreflect.Message {
+	mi := &file_RelationshipService_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelationshipSchema.ProtoReflect.Descriptor instead.
+func (*RelationshipSchema) Descriptor() ([]byte, []int) {
+	return file_RelationshipService_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RelationshipSchema) GetSchema() string {
+	if x != nil {
+		return x.Schema
+	}
+	return ""
+}
+
+func (x *RelationshipSchema) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *RelationshipSchema) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+type WriteSchemaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schema        string                 `protobuf:"bytes,1,opt,name=schema,proto3" json:"schema,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WriteSchemaRequest) Reset() {
+	*x = WriteSchemaRequest{}
+	mi := &file_RelationshipService_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WriteSchemaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteSchemaRequest) ProtoMessage() {}
+
+func (x *WriteSchemaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_RelationshipService_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteSchemaRequest.ProtoReflect.Descriptor instead.
+func (*WriteSchemaRequest) Descriptor() ([]byte, []int) {
+	return file_RelationshipService_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *WriteSchemaRequest) GetSchema() string {
+	if x != nil {
+		return x.Schema
+	}
+	return ""
+}
+
+type ReadSchemaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadSchemaRequest) Reset() {
+	*x = ReadSchemaRequest{}
+	mi := &file_RelationshipService_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadSchemaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadSchemaRequest) ProtoMessage() {}
+
+func (x *ReadSchemaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_RelationshipService_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadSchemaRequest.ProtoReflect.Descriptor instead.
+func (*ReadSchemaRequest) Descriptor() ([]byte, []int) {
+	return file_RelationshipService_proto_rawDescGZIP(), []int{6}
+}
+
+type WriteRelationshipsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Writes        []*Relationship        `protobuf:"bytes,1,rep,name=writes,proto3" json:"writes,omitempty"`
+	Deletes       []*Relationship        `protobuf:"bytes,2,rep,name=deletes,proto3" json:"deletes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WriteRelationshipsRequest) Reset() {
+	*x = WriteRelationshipsRequest{}
+	mi := &file_RelationshipService_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WriteRelationshipsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteRelationshipsRequest) ProtoMessage() {}
+
+func (x *WriteRelationshipsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_RelationshipService_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteRelationshipsRequest.ProtoReflect.Descriptor instead.
+func (*WriteRelationshipsRequest) Descriptor() ([]byte, []int) {
+	return file_RelationshipService_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *WriteRelationshipsRequest) GetWrites() []*Relationship {
+	if x != nil {
+		return x.Writes
+	}
+	return nil
+}
+
+func (x *WriteRelationshipsRequest) GetDeletes() []*Relationship {
+	if x != nil {
+		return x.Deletes
+	}
+	return nil
+}
+
+type WriteRelationshipsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WrittenAt     string                 `protobuf:"bytes,1,opt,name=written_at,json=writtenAt,proto3" json:"written_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WriteRelationshipsResponse) Reset() {
+	*x = WriteRelationshipsResponse{}
+	mi := &file_RelationshipService_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WriteRelationshipsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteRelationshipsResponse) ProtoMessage() {}
+
+func (x *WriteRelationshipsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_RelationshipService_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteRelationshipsResponse.ProtoReflect.Descriptor instead.
+func (*WriteRelationshipsResponse) Descriptor() ([]byte, []int) {
+	return file_RelationshipService_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *WriteRelationshipsResponse) GetWrittenAt() string {
+	if x != nil {
+		return x.WrittenAt
+	}
+	return ""
+}
+
+// Empty filter fields match everything
+type ReadRelationshipsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ObjectType    string                 `protobuf:"bytes,1,opt,name=object_type,json=objectType,proto3" json:"object_type,omitempty"`
+	ObjectId      string                 `protobuf:"bytes,2,opt,name=object_id,json=objectId,proto3" json:"object_id,omitempty"`
+	Relation      string                 `protobuf:"bytes,3,opt,name=relation,proto3" json:"relation,omitempty"`
+	SubjectType   string                 `protobuf:"bytes,4,opt,name=subject_type,json=subjectType,proto3" json:"subject_type,omitempty"`
+	SubjectId     string                 `protobuf:"bytes,5,opt,name=subject_id,json=subjectId,proto3" json:"subject_id,omitempty"`
+	Consistency   *Consistency           `protobuf:"bytes,6,opt,name=consistency,proto3" json:"consistency,omitempty"`
+	PageSize      int32                  `protobuf:"varint,7,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // default 50, max 200
+	PageToken     string                 `protobuf:"bytes,8,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // pages are only stable when read at read_at
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadRelationshipsRequest) Reset() {
+	*x = ReadRelationshipsRequest{}
+	mi := &file_RelationshipService_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadRelationshipsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadRelationshipsRequest) ProtoMessage() {}
+
+func (x *ReadRelationshipsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_RelationshipService_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadRelationshipsRequest.ProtoReflect.Descriptor instead.
+func (*ReadRelationshipsRequest) Descriptor() ([]byte, []int) {
+	return file_RelationshipService_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ReadRelationshipsRequest) GetObjectType() string {
+	if x != nil {
+		return x.ObjectType
+	}
+	return ""
+}
+
+func (x *ReadRelationshipsRequest) GetObjectId() string {
+	if x != nil {
+		return x.ObjectId
+	}
+	return ""
+}
+
+func (x *ReadRelationshipsRequest) GetRelation() string {
+	if x != nil {
+		return x.Relation
+	}
+	return ""
+}
+
+func (x *ReadRelationshipsRequest) GetSubjectType() string {
+	if x != nil {
+		return x.SubjectType
+	}
+	return ""
+}
+
+func (x *ReadRelationshipsRequest) GetSubjectId() string {
+	if x != nil {
+		return x.SubjectId
+	}
+	return ""
+}
+
+func (x *ReadRelationshipsRequest) GetConsistency() *Consistency {
+	if x != nil {
+		return x.Consistency
+	}
+	return nil
+}
+
+func (x *ReadRelationshipsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ReadRelationshipsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ReadRelationshipsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Relationships []*Relationship        `protobuf:"bytes,1,rep,name=relationships,proto3" json:"relationships,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	ReadAt        string                 `protobuf:"bytes,3,opt,name=read_at,json=readAt,proto3" json:"read_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadRelationshipsResponse) Reset() {
+	*x = ReadRelationshipsResponse{}
+	mi := &file_RelationshipService_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadRelationshipsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadRelationshipsResponse) ProtoMessage() {}
+
+func (x *ReadRelationshipsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_RelationshipService_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadRelationshipsResponse.ProtoReflect.Descriptor instead.
+func (*ReadRelationshipsResponse) Descriptor() ([]byte, []int) {
+	return file_RelationshipService_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ReadRelationshipsResponse) GetRelationships() []*Relationship {
+	if x != nil {
+		return x.Relationships
+	}
+	return nil
+}
+
+func (x *ReadRelationshipsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ReadRelationshipsResponse) GetReadAt() string {
+	if x != nil {
+		return x.ReadAt
+	}
+	return ""
+}
+
+type CheckRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Object        *ObjectRef             `protobuf:"bytes,1,opt,name=object,proto3" json:"object,omitempty"`
+	Relation      string                 `protobuf:"bytes,2,opt,name=relation,proto3" json:"relation,omitempty"`
+	Subject       *SubjectRef            `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject,omitempty"`
+	Consistency   *Consistency           `protobuf:"bytes,4,opt,name=consistency,proto3" json:"consistency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckRequest) Reset() {
+	*x = CheckRequest{}
+	mi := &file_RelationshipService_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckRequest) ProtoMessage() {}
+
+func (x *CheckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_RelationshipService_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckRequest.ProtoReflect.Descriptor instead.
+func (*CheckRequest) Descriptor() ([]byte, []int) {
+	return file_RelationshipService_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CheckRequest) GetObject() *ObjectRef {
+	if x != nil {
+		return x.Object
+	}
+	return nil
+}
+
+func (x *CheckRequest) GetRelation() string {
+	if x != nil {
+		return x.Relation
+	}
+	return ""
+}
+
+func (x *CheckRequest) GetSubject() *SubjectRef {
+	if x != nil {
+		return x.Subject
+	}
+	return nil
+}
+
+func (x *CheckRequest) GetConsistency() *Consistency {
+	if x != nil {
+		return x.Consistency
+	}
+	return nil
+}
+
+type CheckResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Allowed       bool                   `protobuf:"varint,1,opt,name=allowed,proto3" json:"allowed,omitempty"`
+	CheckedAt     string                 `protobuf:"bytes,2,opt,name=checked_at,json=checkedAt,proto3" json:"checked_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckResponse) Reset() {
+	*x = CheckResponse{}
+	mi := &file_RelationshipService_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckResponse) ProtoMessage() {}
+
+func (x *CheckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_RelationshipService_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckResponse.ProtoReflect.Descriptor instead.
+func (*CheckResponse) Descriptor() ([]byte, []int) {
+	return file_RelationshipService_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CheckResponse) GetAllowed() bool {
+	if x != nil {
+		return x.Allowed
+	}
+	return false
+}
+
+func (x *CheckResponse) GetCheckedAt() string {
+	if x != nil {
+		return x.CheckedAt
+	}
+	return ""
+}
+
+type ExpandRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Object        *ObjectRef             `protobuf:"bytes,1,opt,name=object,proto3" json:"object,omitempty"`
+	Relation      string                 `protobuf:"bytes,2,opt,name=relation,proto3" json:"relation,omitempty"`
+	Consistency   *Consistency           `protobuf:"bytes,3,opt,name=consistency,proto3" json:"consistency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExpandRequest) Reset() {
+	*x = ExpandRequest{}
+	mi := &file_RelationshipService_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpandRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpandRequest) ProtoMessage() {}
+
+func (x *ExpandRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_RelationshipService_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpandRequest.ProtoReflect.Descriptor instead.
+func (*ExpandRequest) Descriptor() ([]byte, []int) {
+	return file_RelationshipService_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ExpandRequest) GetObject() *ObjectRef {
+	if x != nil {
+		return x.Object
+	}
+	return nil
+}
+
+func (x *ExpandRequest) GetRelation() string {
+	if x != nil {
+		return x.Relation
+	}
+	return ""
+}
+
+func (x *ExpandRequest) GetConsistency() *Consistency {
+	if x != nil {
+		return x.Consistency
+	}
+	return nil
+}
+
+// A leaf lists the subjects stored for a relation; other nodes combine their
+// children with operation
+type UsersetTree struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Object        *ObjectRef             `protobuf:"bytes,1,opt,name=object,proto3" json:"object,omitempty"`
+	Relation      string                 `protobuf:"bytes,2,opt,name=relation,proto3" json:"relation,omitempty"`
+	Operation     string                 `protobuf:"bytes,3,opt,name=operation,proto3" json:"operation,omitempty"` // union, intersection, exclusion or empty for a leaf
+	Subjects      []*SubjectRef          `protobuf:"bytes,4,rep,name=subjects,proto3" json:"subjects,omitempty"`
+	Children      []*UsersetTree         `protobuf:"bytes,5,rep,name=children,proto3" json:"children,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UsersetTree) Reset() {
+	*x = UsersetTree{}
+	mi := &file_RelationshipService_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UsersetTree) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsersetTree) ProtoMessage() {}
+
+func (x *UsersetTree) ProtoReflect() protoreflect.Message {
+	mi := &file_RelationshipService_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UsersetTree.ProtoReflect.Descriptor instead.
+func (*UsersetTree) Descriptor() ([]byte, []int) {
+	return file_RelationshipService_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UsersetTree) GetObject() *ObjectRef {
+	if x != nil {
+		return x.Object
+	}
+	return nil
+}
+
+func (x *UsersetTree) GetRelation() string {
+	if x != nil {
+		return x.Relation
+	}
+	return ""
+}
+
+func (x *UsersetTree) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *UsersetTree) GetSubjects() []*SubjectRef {
+	if x != nil {
+		return x.Subjects
+	}
+	return nil
+}
+
+func (x *UsersetTree) GetChildren() []*UsersetTree {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+type ExpandResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tree          *UsersetTree           `protobuf:"bytes,1,opt,name=tree,proto3" json:"tree,omitempty"`
+	ExpandedAt    string                 `protobuf:"bytes,2,opt,name=expanded_at,json=expandedAt,proto3" json:"expanded_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExpandResponse) Reset() {
+	*x = ExpandResponse{}
+	mi := &file_RelationshipService_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpandResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpandResponse) ProtoMessage() {}
+
+func (x *ExpandResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_RelationshipService_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpandResponse.ProtoReflect.Descriptor instead.
+func (*ExpandResponse) Descriptor() ([]byte, []int) {
+	return file_RelationshipService_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ExpandResponse) GetTree() *UsersetTree {
+	if x != nil {
+		return x.Tree
+	}
+	return nil
+}
+
+func (x *ExpandResponse) GetExpandedAt() string {
+	if x != nil {
+		return x.ExpandedAt
+	}
+	return ""
+}
+
+type ListObjectsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ObjectType    string                 `protobuf:"bytes,1,opt,name=object_type,json=objectType,proto3" json:"object_type,omitempty"`
+	Relation      string                 `protobuf:"bytes,2,opt,name=relation,proto3" json:"relation,omitempty"`
+	Subject       *SubjectRef            `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject,omitempty"`
+	Consistency   *Consistency           `protobuf:"bytes,4,opt,name=consistency,proto3" json:"consistency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListObjectsRequest) Reset() {
+	*x = ListObjectsRequest{}
+	mi := &file_RelationshipService_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListObjectsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListObjectsRequest) ProtoMessage() {}
+
+func (x *ListObjectsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_RelationshipService_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListObjectsRequest.ProtoReflect.Descriptor instead.
+func (*ListObjectsRequest) Descriptor() ([]byte, []int) {
+	return file_RelationshipService_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListObjectsRequest) GetObjectType() string {
+	if x != nil {
+		return x.ObjectType
+	}
+	return ""
+}
+
+func (x *ListObjectsRequest) GetRelation() string {
+	if x != nil {
+		return x.Relation
+	}
+	return ""
+}
+
+func (x *ListObjectsRequest) GetSubject() *SubjectRef {
+	if x != nil {
+		return x.Subject
+	}
+	return nil
+}
+
+func (x *ListObjectsRequest) GetConsistency() *Consistency {
+	if x != nil {
+		return x.Consistency
+	}
+	return nil
+}
+
+type ListObjectsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ObjectIds     []string               `protobuf:"bytes,1,rep,name=object_ids,json=objectIds,proto3" json:"object_ids,omitempty"`
+	ListedAt      string                 `protobuf:"bytes,2,opt,name=listed_at,json=listedAt,proto3" json:"listed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListObjectsResponse) Reset() {
+	*x = ListObjectsResponse{}
+	mi := &file_RelationshipService_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListObjectsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListObjectsResponse) ProtoMessage() {}
+
+func (x *ListObjectsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_RelationshipService_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListObjectsResponse.ProtoReflect.Descriptor instead.
+func (*ListObjectsResponse) Descriptor() ([]byte, []int) {
+	return file_RelationshipService_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListObjectsResponse) GetObjectIds() []string {
+	if x != nil {
+		return x.ObjectIds
+	}
+	return nil
+}
+
+func (x *ListObjectsResponse) GetListedAt() string {
+	if x != nil {
+		return x.ListedAt
+	}
+	return ""
+}
+
+var File_RelationshipService_proto protoreflect.FileDescriptor
+
+const file_RelationshipService_proto_rawDesc = "" +
+	"\n" +
+	"\x19RelationshipService.proto\x12\x04auth\"/\n" +
+	"\tObjectRef\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\"Q\n" +
+	"\n" +
+	"SubjectRef\x12'\n" +
+	"\x06object\x18\x01 \x01(\v2\x0f.auth.ObjectRefR\x06object\x12\x1a\n" +
+	"\brelation\x18\x02 \x01(\tR\brelation\"\x7f\n" +
+	"\fRelationship\x12'\n" +
+	"\x06object\x18\x01 \x01(\v2\x0f.auth.ObjectRefR\x06object\x12\x1a\n" +
+	"\brelation\x18\x02 \x01(\tR\brelation\x12*\n" +
+	"\asubject\x18\x03 \x01(\v2\x10.auth.SubjectRefR\asubject\"\xd1\x01\n" +
+	"\vConsistency\x12+\n" +
+	"\x10minimize_latency\x18\x01 \x01(\bH\x00R\x0fminimizeLatency\x12+\n" +
+	"\x10fully_consistent\x18\x02 \x01(\bH\x00R\x0ffullyConsistent\x12+\n" +
+	"\x11at_least_as_fresh\x18\x03 \x01(\tH\x00R\x0eatLeastAsFresh\x12,\n" +
+	"\x11at_exact_snapshot\x18\x04 \x01(\tH\x00R\x0fatExactSnapshotB\r\n" +
+	"\vrequirement\"g\n" +
+	"\x12RelationshipSchema\x12\x16\n" +
+	"\x06schema\x18\x01 \x01(\tR\x06schema\x12\x1a\n" +
+	"\brevision\x18\x02 \x01(\x03R\brevision\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x03 \x01(\x03R\tupdatedAt\",\n" +
+	"\x12WriteSchemaRequest\x12\x16\n" +
+	"\x06schema\x18\x01 \x01(\tR\x06schema\"\x13\n" +
+	"\x11ReadSchemaRequest\"u\n" +
+	"\x19WriteRelationshipsRequest\x12*\n" +
+	"\x06writes\x18\x01 \x03(\v2\x12.auth.RelationshipR\x06writes\x12,\n" +
+	"\adeletes\x18\x02 \x03(\v2\x12.auth.RelationshipR\adeletes\";\n" +
+	"\x1aWriteRelationshipsResponse\x12\x1d\n" +
+	"\n" +
+	"written_at\x18\x01 \x01(\tR\twrittenAt\"\xa7\x02\n" +
+	"\x18ReadRelationshipsRequest\x12\x1f\n" +
+	"\vobject_type\x18\x01 \x01(\tR\n" +
+	"objectType\x12\x1b\n" +
+	"\tobject_id\x18\x02 \x01(\tR\bobjectId\x12\x1a\n" +
+	"\brelation\x18\x03 \x01(\tR\brelation\x12!\n" +
+	"\fsubject_type\x18\x04 \x01(\tR\vsubjectType\x12\x1d\n" +
+	"\n" +
+	"subject_id\x18\x05 \x01(\tR\tsubjectId\x123\n" +
+	"\vconsistency\x18\x06 \x01(\v2\x11.auth.ConsistencyR\vconsistency\x12\x1b\n" +
+	"\tpage_size\x18\a \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\b \x01(\tR\tpageToken\"\x96\x01\n" +
+	"\x19ReadRelationshipsResponse\x128\n" +
+	"\rrelationships\x18\x01 \x03(\v2\x12.auth.RelationshipR\rrelationships\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x17\n" +
+	"\aread_at\x18\x03 \x01(\tR\x06readAt\"\xb4\x01\n" +
+	"\fCheckRequest\x12'\n" +
+	"\x06object\x18\x01 \x01(\v2\x0f.auth.ObjectRefR\x06object\x12\x1a\n" +
+	"\brelation\x18\x02 \x01(\tR\brelation\x12*\n" +
+	"\asubject\x18\x03 \x01(\v2\x10.auth.SubjectRefR\asubject\x123\n" +
+	"\vconsistency\x18\x04 \x01(\v2\x11.auth.ConsistencyR\vconsistency\"H\n" +
+	"\rCheckResponse\x12\x18\n" +
+	"\aallowed\x18\x01 \x01(\bR\aallowed\x12\x1d\n" +
+	"\n" +
+	"checked_at\x18\x02 \x01(\tR\tcheckedAt\"\x89\x01\n" +
+	"\rExpandRequest\x12'\n" +
+	"\x06object\x18\x01 \x01(\v2\x0f.auth.ObjectRefR\x06object\x12\x1a\n" +
+	"\brelation\x18\x02 \x01(\tR\brelation\x123\n" +
+	"\vconsistency\x18\x03 \x01(\v2\x11.auth.ConsistencyR\vconsistency\"\xcd\x01\n" +
+	"\vUsersetTree\x12'\n" +
+	"\x06object\x18\x01 \x01(\v2\x0f.auth.ObjectRefR\x06object\x12\x1a\n" +
+	"\brelation\x18\x02 \x01(\tR\brelation\x12\x1c\n" +
+	"\toperation\x18\x03 \x01(\tR\toperation\x12,\n" +
+	"\bsubjects\x18\x04 \x03(\v2\x10.auth.SubjectRefR\bsubjects\x12-\n" +
+	"\bchildren\x18\x05 \x03(\v2\x11.auth.UsersetTreeR\bchildren\"X\n" +
+	"\x0eExpandResponse\x12%\n" +
+	"\x04tree\x18\x01 \x01(\v2\x11.auth.UsersetTreeR\x04tree\x12\x1f\n" +
+	"\vexpanded_at\x18\x02 \x01(\tR\n" +
+	"expandedAt\"\xb2\x01\n" +
+	"\x12ListObjectsRequest\x12\x1f\n" +
+	"\vobject_type\x18\x01 \x01(\tR\n" +
+	"objectType\x12\x1a\n" +
+	"\brelation\x18\x02 \x01(\tR\brelation\x12*\n" +
+	"\asubject\x18\x03 \x01(\v2\x10.auth.SubjectRefR\asubject\x123\n" +
+	"\vconsistency\x18\x04 \x01(\v2\x11.auth.ConsistencyR\vconsistency\"Q\n" +
+	"\x13ListObjectsResponse\x12\x1d\n" +
+	"\n" +
+	"object_ids\x18\x01 \x03(\tR\tobjectIds\x12\x1b\n" +
+	"\tlisted_at\x18\x02 \x01(\tR\blistedAt2\xf3\x03\n" +
+	"\x13RelationshipService\x12A\n" +
+	"\vWriteSchema\x12\x18.auth.WriteSchemaRequest\x1a\x18.auth.RelationshipSchema\x12?\n" +
+	"\n" +
+	"ReadSchema\x12\x17.auth.ReadSchemaRequest\x1a\x18.auth.RelationshipSchema\x12W\n" +
+	"\x12WriteRelationships\x12\x1f.auth.WriteRelationshipsRequest\x1a .auth.WriteRelationshipsResponse\x12T\n" +
+	"\x11ReadRelationships\x12\x1e.auth.ReadRelationshipsRequest\x1a\x1f.auth.ReadRelationshipsResponse\x120\n" +
+	"\x05Check\x12\x12.auth.CheckRequest\x1a\x13.auth.CheckResponse\x123\n" +
+	"\x06Expand\x12\x13.auth.ExpandRequest\x1a\x14.auth.ExpandResponse\x12B\n" +
+	"\vListObjects\x12\x18.auth.ListObjectsRequest\x1a\x19.auth.ListObjectsResponseB\x16Z\x14auth-haven/pkg/protob\x06proto3"
+
+var (
+	file_RelationshipService_proto_rawDescOnce sync.Once
+	file_RelationshipService_proto_rawDescData []byte
+)
+
+func file_RelationshipService_proto_rawDescGZIP() []byte {
+	file_RelationshipService_proto_rawDescOnce.Do(func() {
+		file_RelationshipService_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_RelationshipService_proto_rawDesc), len(file_RelationshipService_proto_rawDesc)))
+	})
+	return file_RelationshipService_proto_rawDescData
+}
+
+var file_RelationshipService_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_RelationshipService_proto_goTypes = []any{
+	(*ObjectRef)(nil),                  // 0: auth.ObjectRef
+	(*SubjectRef)(nil),                 // 1: auth.SubjectRef
+	(*Relationship)(nil),               // 2: auth.Relationship
+	(*Consistency)(nil),                // 3: auth.Consistency
+	(*RelationshipSchema)(nil),         // 4: auth.RelationshipSchema
+	(*WriteSchemaRequest)(nil),         // 5: auth.WriteSchemaRequest
+	(*ReadSchemaRequest)(nil),          // 6: auth.ReadSchemaRequest
+	(*WriteRelationshipsRequest)(nil),  // 7: auth.WriteRelationshipsRequest
+	(*WriteRelationshipsResponse)(nil), // 8: auth.WriteRelationshipsResponse
+	(*ReadRelationshipsRequest)(nil),   // 9: auth.ReadRelationshipsRequest
+	(*ReadRelationshipsResponse)(nil),  // 10: auth.ReadRelationshipsResponse
+	(*CheckRequest)(nil),               // 11: auth.CheckRequest
+	(*CheckResponse)(nil),              // 12: auth.CheckResponse
+	(*ExpandRequest)(nil),              // 13: auth.ExpandRequest
+	(*UsersetTree)(nil),                // 14: auth.UsersetTree
+	(*ExpandResponse)(nil),             // 15: auth.ExpandResponse
+	(*ListObjectsRequest)(nil),         // 16: auth.ListObjectsRequest
+	(*ListObjectsResponse)(nil),        // 17: auth.ListObjectsResponse
+}
+var file_RelationshipService_proto_depIdxs = []int32{
+	0,  // 0: auth.SubjectRef.object:type_name -> auth.ObjectRef
+	0,  // 1: auth.Relationship.object:type_name -> auth.ObjectRef
+	1,  // 2: auth.Relationship.subject:type_name -> auth.SubjectRef
+	2,  // 3: auth.WriteRelationshipsRequest.writes:type_name -> auth.Relationship
+	2,  // 4: auth.WriteRelationshipsRequest.deletes:type_name -> auth.Relationship
+	3,  // 5: auth.ReadRelationshipsRequest.consistency:type_name -> auth.Consistency
+	2,  // 6: auth.ReadRelationshipsResponse.relationships:type_name -> auth.Relationship
+	0,  // 7: auth.CheckRequest.object:type_name -> auth.ObjectRef
+	1,  // 8: auth.CheckRequest.subject:type_name -> auth.SubjectRef
+	3,  // 9: auth.CheckRequest.consistency:type_name -> auth.Consistency
+	0,  // 10: auth.ExpandRequest.object:type_name -> auth.ObjectRef
+	3,  // 11: auth.ExpandRequest.consistency:type_name -> auth.Consistency
+	0,  // 12: auth.UsersetTree.object:type_name -> auth.ObjectRef
+	1,  // 13: auth.UsersetTree.subjects:type_name -> auth.SubjectRef
+	14, // 14: auth.UsersetTree.children:type_name -> auth.UsersetTree
+	14, // 15: auth.ExpandResponse.tree:type_name -> auth.UsersetTree
+	1,  // 16: auth.ListObjectsRequest.subject:type_name -> auth.SubjectRef
+	3,  // 17: auth.ListObjectsRequest.consistency:type_name -> auth.Consistency
+	5,  // 18: auth.RelationshipService.WriteSchema:input_type -> auth.WriteSchemaRequest
+	6,  // 19: auth.RelationshipService.ReadSchema:input_type -> auth.ReadSchemaRequest
+	7,  // 20: auth.RelationshipService.WriteRelationships:input_type -> auth.WriteRelationshipsRequest
+	9,  // 21: auth.RelationshipService.ReadRelationships:input_type -> auth.ReadRelationshipsRequest
+	11, // 22: auth.RelationshipService.Check:input_type -> auth.CheckRequest
+	13, // 23: auth.RelationshipService.Expand:input_type -> auth.ExpandRequest
+	16, // 24: auth.RelationshipService.ListObjects:input_type -> auth.ListObjectsRequest
+	4,  // 25: auth.RelationshipService.WriteSchema:output_type -> auth.RelationshipSchema
+	4,  // 26: auth.RelationshipService.ReadSchema:output_type -> auth.RelationshipSchema
+	8,  // 27: auth.RelationshipService.WriteRelationships:output_type -> auth.WriteRelationshipsResponse
+	10, // 28: auth.RelationshipService.ReadRelationships:output_type -> auth.ReadRelationshipsResponse
+	12, // 29: auth.RelationshipService.Check:output_type -> auth.CheckResponse
+	15, // 30: auth.RelationshipService.Expand:output_type -> auth.ExpandResponse
+	17, // 31: auth.RelationshipService.ListObjects:output_type -> auth.ListObjectsResponse
+	25, // [25:32] is the sub-list for method output_type
+	18, // [18:25] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
+}
+
+func init() { file_RelationshipService_proto_init() }
+func file_RelationshipService_proto_init() {
+	if File_RelationshipService_proto != nil {
+		return
+	}
+	file_RelationshipService_proto_msgTypes[3].OneofWrappers = []any{
+		(*Consistency_MinimizeLatency)(nil),
+		(*Consistency_FullyConsistent)(nil),
+		(*Consistency_AtLeastAsFresh)(nil),
+		(*Consistency_AtExactSnapshot)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_RelationshipService_proto_rawDesc), len(file_RelationshipService_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   18,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_RelationshipService_proto_goTypes,
+		DependencyIndexes: file_RelationshipService_proto_depIdxs,
+		MessageInfos:      file_RelationshipService_proto_msgTypes,
+	}.Build()
+	File_RelationshipService_proto = out.File
+	file_RelationshipService_proto_goTypes = nil
+	file_RelationshipService_proto_depIdxs = nil
+}
@@ -0,0 +1,395 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: RelationshipService.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	RelationshipService_WriteSchema_FullMethodName        = "/auth.RelationshipService/WriteSchema"
+	RelationshipService_ReadSchema_FullMethodName         = "/auth.RelationshipService/ReadSchema"
+	RelationshipService_WriteRelationships_FullMethodName = "/auth.RelationshipService/WriteRelationships"
+	RelationshipService_ReadRelationships_FullMethodName  = "/auth.RelationshipService/ReadRelationships"
+	RelationshipService_Check_FullMethodName              = "/auth.RelationshipService/Check"
+	RelationshipService_Expand_FullMethodName             = "/auth.RelationshipService/Expand"
+	RelationshipService_ListObjects_FullMethodName        = "/auth.RelationshipService/ListObjects"
+)
+
+// RelationshipServiceClient is the client API for RelationshipService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Relationship-based access control for the caller's tenant. Applications
+// store relationships as tuples, object#relation@subject, and ask whether a
+// subject holds a relation to an object. Each tenant's namespace
+// configuration declares the object types and how their relations derive
+// from one another:
+//
+//	namespace document {
+//	  relation parent
+//	  relation owner
+//	  relation editor = this | owner
+//	  relation viewer = this | editor | parent->viewer
+//	}
+//
+// `this` is the tuples stored for the relation itself, a bare name is another
+// relation of the same object (a computed userset), and `tupleset->relation`
+// follows the objects stored under tupleset and takes their relation. `|`,
+// `&` and `-` are union, intersection and exclusion.
+//
+// Reading needs relationships.read; writing tuples or the schema needs
+// relationships.write.
+type RelationshipServiceClient interface {
+	WriteSchema(ctx context.Context, in *WriteSchemaRequest, opts ...grpc.CallOption) (*RelationshipSchema, error)
+	ReadSchema(ctx context.Context, in *ReadSchemaRequest, opts ...grpc.CallOption) (*RelationshipSchema, error)
+	// Applies all writes and deletes at one new revision and returns its
+	// token. Pass the token as at_least_as_fresh to read your own writes.
+	WriteRelationships(ctx context.Context, in *WriteRelationshipsRequest, opts ...grpc.CallOption) (*WriteRelationshipsResponse, error)
+	ReadRelationships(ctx context.Context, in *ReadRelationshipsRequest, opts ...grpc.CallOption) (*ReadRelationshipsResponse, error)
+	Check(ctx context.Context, in *CheckRequest, opts ...grpc.CallOption) (*CheckResponse, error)
+	Expand(ctx context.Context, in *ExpandRequest, opts ...grpc.CallOption) (*ExpandResponse, error)
+	ListObjects(ctx context.Context, in *ListObjectsRequest, opts ...grpc.CallOption) (*ListObjectsResponse, error)
+}
+
+type relationshipServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRelationshipServiceClient(cc grpc.ClientConnInterface) RelationshipServiceClient {
+	return &relationshipServiceClient{cc}
+}
+
+func (c *relationshipServiceClient) WriteSchema(ctx context.Context, in *WriteSchemaRequest, opts ...grpc.CallOption) (*RelationshipSchema, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RelationshipSchema)
+	err := c.cc.Invoke(ctx, RelationshipService_WriteSchema_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *relationshipServiceClient) ReadSchema(ctx context.Context, in *ReadSchemaRequest, opts ...grpc.CallOption) (*RelationshipSchema, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RelationshipSchema)
+	err := c.cc.Invoke(ctx, RelationshipService_ReadSchema_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *relationshipServiceClient) WriteRelationships(ctx context.Context, in *WriteRelationshipsRequest, opts ...grpc.CallOption) (*WriteRelationshipsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WriteRelationshipsResponse)
+	err := c.cc.Invoke(ctx, RelationshipService_WriteRelationships_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *relationshipServiceClient) ReadRelationships(ctx context.Context, in *ReadRelationshipsRequest, opts ...grpc.CallOption) (*ReadRelationshipsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReadRelationshipsResponse)
+	err := c.cc.Invoke(ctx, RelationshipService_ReadRelationships_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *relationshipServiceClient) Check(ctx context.Context, in *CheckRequest, opts ...grpc.CallOption) (*CheckResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckResponse)
+	err := c.cc.Invoke(ctx, RelationshipService_Check_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *relationshipServiceClient) Expand(ctx context.Context, in *ExpandRequest, opts ...grpc.CallOption) (*ExpandResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExpandResponse)
+	err := c.cc.Invoke(ctx, RelationshipService_Expand_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *relationshipServiceClient) ListObjects(ctx context.Context, in *ListObjectsRequest, opts ...grpc.CallOption) (*ListObjectsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListObjectsResponse)
+	err := c.cc.Invoke(ctx, RelationshipService_ListObjects_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RelationshipServiceServer is the server API for RelationshipService service.
+// All implementations must embed UnimplementedRelationshipServiceServer
+// for forward compatibility.
+//
+// Relationship-based access control for the caller's tenant. Applications
+// store relationships as tuples, object#relation@subject, and ask whether a
+// subject holds a relation to an object. Each tenant's namespace
+// configuration declares the object types and how their relations derive
+// from one another:
+//
+//	namespace document {
+//	  relation parent
+//	  relation owner
+//	  relation editor = this | owner
+//	  relation viewer = this | editor | parent->viewer
+//	}
+//
+// `this` is the tuples stored for the relation itself, a bare name is another
+// relation of the same object (a computed userset), and `tupleset->relation`
+// follows the objects stored under tupleset and takes their relation. `|`,
+// `&` and `-` are union, intersection and exclusion.
+//
+// Reading needs relationships.read; writing tuples or the schema needs
+// relationships.write.
+type RelationshipServiceServer interface {
+	WriteSchema(context.Context, *WriteSchemaRequest) (*RelationshipSchema, error)
+	ReadSchema(context.Context, *ReadSchemaRequest) (*RelationshipSchema, error)
+	// Applies all writes and deletes at one new revision and returns its
+	// token. Pass the token as at_least_as_fresh to read your own writes.
+	WriteRelationships(context.Context, *WriteRelationshipsRequest) (*WriteRelationshipsResponse, error)
+	ReadRelationships(context.Context, *ReadRelationshipsRequest) (*ReadRelationshipsResponse, error)
+	Check(context.Context, *CheckRequest) (*CheckResponse, error)
+	Expand(context.Context, *ExpandRequest) (*ExpandResponse, error)
+	ListObjects(context.Context, *ListObjectsRequest) (*ListObjectsResponse, error)
+	mustEmbedUnimplementedRelationshipServiceServer()
+}
+
+// UnimplementedRelationshipServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRelationshipServiceServer struct{}
+
+func (UnimplementedRelationshipServiceServer) WriteSchema(context.Context, *WriteSchemaRequest) (*RelationshipSchema, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WriteSchema not implemented")
+}
+func (UnimplementedRelationshipServiceServer) ReadSchema(context.Context, *ReadSchemaRequest) (*RelationshipSchema, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadSchema not implemented")
+}
+func (UnimplementedRelationshipServiceServer) WriteRelationships(context.Context, *WriteRelationshipsRequest) (*WriteRelationshipsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WriteRelationships not implemented")
+}
+func (UnimplementedRelationshipServiceServer) ReadRelationships(context.Context, *ReadRelationshipsRequest) (*ReadRelationshipsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadRelationships not implemented")
+}
+func (UnimplementedRelationshipServiceServer) Check(context.Context, *CheckRequest) (*CheckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Check not implemented")
+}
+func (UnimplementedRelationshipServiceServer) Expand(context.Context, *ExpandRequest) (*ExpandResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Expand not implemented")
+}
+func (UnimplementedRelationshipServiceServer) ListObjects(context.Context, *ListObjectsRequest) (*ListObjectsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListObjects not implemented")
+}
+func (UnimplementedRelationshipServiceServer) mustEmbedUnimplementedRelationshipServiceServer() {}
+func (UnimplementedRelationshipServiceServer) testEmbeddedByValue()                             {}
+
+// UnsafeRelationshipServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RelationshipServiceServer will
+// result in compilation errors.
+type UnsafeRelationshipServiceServer interface {
+	mustEmbedUnimplementedRelationshipServiceServer()
+}
+
+func RegisterRelationshipServiceServer(s grpc.ServiceRegistrar, srv RelationshipServiceServer) {
+	// If the following call pancis, it indicates UnimplementedRelationshipServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RelationshipService_ServiceDesc, srv)
+}
+
+func _RelationshipService_WriteSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WriteSchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RelationshipServiceServer).WriteSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RelationshipService_WriteSchema_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RelationshipServiceServer).WriteSchema(ctx, req.(*WriteSchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RelationshipService_ReadSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadSchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RelationshipServiceServer).ReadSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RelationshipService_ReadSchema_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RelationshipServiceServer).ReadSchema(ctx, req.(*ReadSchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RelationshipService_WriteRelationships_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WriteRelationshipsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RelationshipServiceServer).WriteRelationships(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RelationshipService_WriteRelationships_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RelationshipServiceServer).WriteRelationships(ctx, req.(*WriteRelationshipsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RelationshipService_ReadRelationships_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadRelationshipsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RelationshipServiceServer).ReadRelationships(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RelationshipService_ReadRelationships_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RelationshipServiceServer).ReadRelationships(ctx, req.(*ReadRelationshipsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RelationshipService_Check_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RelationshipServiceServer).Check(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RelationshipService_Check_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RelationshipServiceServer).Check(ctx, req.(*CheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RelationshipService_Expand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExpandRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RelationshipServiceServer).Expand(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RelationshipService_Expand_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RelationshipServiceServer).Expand(ctx, req.(*ExpandRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RelationshipService_ListObjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListObjectsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RelationshipServiceServer).ListObjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RelationshipService_ListObjects_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RelationshipServiceServer).ListObjects(ctx, req.(*ListObjectsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RelationshipService_ServiceDesc is the grpc.ServiceDesc for RelationshipService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RelationshipService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "auth.RelationshipService",
+	HandlerType: (*RelationshipServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "WriteSchema",
+			Handler:    _RelationshipService_WriteSchema_Handler,
+		},
+		{
+			MethodName: "ReadSchema",
+			Handler:    _RelationshipService_ReadSchema_Handler,
+		},
+		{
+			MethodName: "WriteRelationships",
+			Handler:    _RelationshipService_WriteRelationships_Handler,
+		},
+		{
+			MethodName: "ReadRelationships",
+			Handler:    _RelationshipService_ReadRelationships_Handler,
+		},
+		{
+			MethodName: "Check",
+			Handler:    _RelationshipService_Check_Handler,
+		},
+		{
+			MethodName: "Expand",
+			Handler:    _RelationshipService_Expand_Handler,
+		},
+		{
+			MethodName: "ListObjects",
+			Handler:    _RelationshipService_ListObjects_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "RelationshipService.proto",
+}
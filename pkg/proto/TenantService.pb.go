@@ -0,0 +1,819 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        v5.29.3
+// source: TenantService.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Tenant struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Id                  string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Domain              string                 `protobuf:"bytes,3,opt,name=domain,proto3" json:"domain,omitempty"`
+	DomainVerified      bool                   `protobuf:"varint,4,opt,name=domain_verified,json=domainVerified,proto3" json:"domain_verified,omitempty"`
+	Status              string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"` // ACTIVE or SUSPENDED
+	SuspendedReason     string                 `protobuf:"bytes,6,opt,name=suspended_reason,json=suspendedReason,proto3" json:"suspended_reason,omitempty"`
+	AllowImpersonation  bool                   `protobuf:"varint,7,opt,name=allow_impersonation,json=allowImpersonation,proto3" json:"allow_impersonation,omitempty"`
+	DeletionScheduledAt int64                  `protobuf:"varint,8,opt,name=deletion_scheduled_at,json=deletionScheduledAt,proto3" json:"deletion_scheduled_at,omitempty"` // unix seconds, 0 if not scheduled
+	CreatedAt           int64                  `protobuf:"varint,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt           int64                  `protobuf:"varint,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *Tenant) Reset() {
+	*x = Tenant{}
+	mi := &file_TenantService_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tenant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tenant) ProtoMessage() {}
+
+func (x *Tenant) ProtoReflect() protoreflect.Message {
+	mi := &file_TenantService_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tenant.ProtoReflect.Descriptor instead.
+func (*Tenant) Descriptor() ([]byte, []int) {
+	return file_TenantService_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Tenant) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Tenant) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Tenant) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *Tenant) GetDomainVerified() bool {
+	if x != nil {
+		return x.DomainVerified
+	}
+	return false
+}
+
+func (x *Tenant) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Tenant) GetSuspendedReason() string {
+	if x != nil {
+		return x.SuspendedReason
+	}
+	return ""
+}
+
+func (x *Tenant) GetAllowImpersonation() bool {
+	if x != nil {
+		return x.AllowImpersonation
+	}
+	return false
+}
+
+func (x *Tenant) GetDeletionScheduledAt() int64 {
+	if x != nil {
+		return x.DeletionScheduledAt
+	}
+	return 0
+}
+
+func (x *Tenant) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Tenant) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+type GetTenantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"` // empty for the caller's tenant
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTenantRequest) Reset() {
+	*x = GetTenantRequest{}
+	mi := &file_TenantService_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTenantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTenantRequest) ProtoMessage() {}
+
+func (x *GetTenantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_TenantService_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTenantRequest.ProtoReflect.Descriptor instead.
+func (*GetTenantRequest) Descriptor() ([]byte, []int) {
+	return file_TenantService_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetTenantRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+type UpdateTenantRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	TenantId           string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"` // empty for the caller's tenant
+	Name               *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Domain             *string                `protobuf:"bytes,3,opt,name=domain,proto3,oneof" json:"domain,omitempty"` // a new domain has to be verified again
+	AllowImpersonation *bool                  `protobuf:"varint,4,opt,name=allow_impersonation,json=allowImpersonation,proto3,oneof" json:"allow_impersonation,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *UpdateTenantRequest) Reset() {
+	*x = UpdateTenantRequest{}
+	mi := &file_TenantService_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTenantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTenantRequest) ProtoMessage() {}
+
+func (x *UpdateTenantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_TenantService_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTenantRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTenantRequest) Descriptor() ([]byte, []int) {
+	return file_TenantService_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *UpdateTenantRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *UpdateTenantRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *UpdateTenantRequest) GetDomain() string {
+	if x != nil && x.Domain != nil {
+		return *x.Domain
+	}
+	return ""
+}
+
+func (x *UpdateTenantRequest) GetAllowImpersonation() bool {
+	if x != nil && x.AllowImpersonation != nil {
+		return *x.AllowImpersonation
+	}
+	return false
+}
+
+type GetDomainChallengeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"` // empty for the caller's tenant
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDomainChallengeRequest) Reset() {
+	*x = GetDomainChallengeRequest{}
+	mi := &file_TenantService_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDomainChallengeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDomainChallengeRequest) ProtoMessage() {}
+
+func (x *GetDomainChallengeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_TenantService_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDomainChallengeRequest.ProtoReflect.Descriptor instead.
+func (*GetDomainChallengeRequest) Descriptor() ([]byte, []int) {
+	return file_TenantService_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetDomainChallengeRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+type DomainChallenge struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Domain        string                 `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	RecordName    string                 `protobuf:"bytes,2,opt,name=record_name,json=recordName,proto3" json:"record_name,omitempty"`    // where to publish the TXT record
+	RecordValue   string                 `protobuf:"bytes,3,opt,name=record_value,json=recordValue,proto3" json:"record_value,omitempty"` // changes whenever the domain does
+	Verified      bool                   `protobuf:"varint,4,opt,name=verified,proto3" json:"verified,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DomainChallenge) Reset() {
+	*x = DomainChallenge{}
+	mi := &file_TenantService_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DomainChallenge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DomainChallenge) ProtoMessage() {}
+
+func (x *DomainChallenge) ProtoReflect() protoreflect.Message {
+	mi := &file_TenantService_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DomainChallenge.ProtoReflect.Descriptor instead.
+func (*DomainChallenge) Descriptor() ([]byte, []int) {
+	return file_TenantService_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DomainChallenge) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *DomainChallenge) GetRecordName() string {
+	if x != nil {
+		return x.RecordName
+	}
+	return ""
+}
+
+func (x *DomainChallenge) GetRecordValue() string {
+	if x != nil {
+		return x.RecordValue
+	}
+	return ""
+}
+
+func (x *DomainChallenge) GetVerified() bool {
+	if x != nil {
+		return x.Verified
+	}
+	return false
+}
+
+type VerifyDomainRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"` // empty for the caller's tenant
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyDomainRequest) Reset() {
+	*x = VerifyDomainRequest{}
+	mi := &file_TenantService_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyDomainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyDomainRequest) ProtoMessage() {}
+
+func (x *VerifyDomainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_TenantService_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyDomainRequest.ProtoReflect.Descriptor instead.
+func (*VerifyDomainRequest) Descriptor() ([]byte, []int) {
+	return file_TenantService_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *VerifyDomainRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+type SuspendTenantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuspendTenantRequest) Reset() {
+	*x = SuspendTenantRequest{}
+	mi := &file_TenantService_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuspendTenantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuspendTenantRequest) ProtoMessage() {}
+
+func (x *SuspendTenantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_TenantService_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuspendTenantRequest.ProtoReflect.Descriptor instead.
+func (*SuspendTenantRequest) Descriptor() ([]byte, []int) {
+	return file_TenantService_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SuspendTenantRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *SuspendTenantRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ReactivateTenantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReactivateTenantRequest) Reset() {
+	*x = ReactivateTenantRequest{}
+	mi := &file_TenantService_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReactivateTenantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactivateTenantRequest) ProtoMessage() {}
+
+func (x *ReactivateTenantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_TenantService_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactivateTenantRequest.ProtoReflect.Descriptor instead.
+func (*ReactivateTenantRequest) Descriptor() ([]byte, []int) {
+	return file_TenantService_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ReactivateTenantRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+type ScheduleTenantDeletionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"` // empty for the caller's tenant
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduleTenantDeletionRequest) Reset() {
+	*x = ScheduleTenantDeletionRequest{}
+	mi := &file_TenantService_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleTenantDeletionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleTenantDeletionRequest) ProtoMessage() {}
+
+func (x *ScheduleTenantDeletionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_TenantService_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleTenantDeletionRequest.ProtoReflect.Descriptor instead.
+func (*ScheduleTenantDeletionRequest) Descriptor() ([]byte, []int) {
+	return file_TenantService_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ScheduleTenantDeletionRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+type CancelTenantDeletionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"` // empty for the caller's tenant
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelTenantDeletionRequest) Reset() {
+	*x = CancelTenantDeletionRequest{}
+	mi := &file_TenantService_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelTenantDeletionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelTenantDeletionRequest) ProtoMessage() {}
+
+func (x *CancelTenantDeletionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_TenantService_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelTenantDeletionRequest.ProtoReflect.Descriptor instead.
+func (*CancelTenantDeletionRequest) Descriptor() ([]byte, []int) {
+	return file_TenantService_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CancelTenantDeletionRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+type DeleteTenantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"` // empty for the caller's tenant
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTenantRequest) Reset() {
+	*x = DeleteTenantRequest{}
+	mi := &file_TenantService_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTenantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTenantRequest) ProtoMessage() {}
+
+func (x *DeleteTenantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_TenantService_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTenantRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTenantRequest) Descriptor() ([]byte, []int) {
+	return file_TenantService_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeleteTenantRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+type DeleteTenantResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTenantResponse) Reset() {
+	*x = DeleteTenantResponse{}
+	mi := &file_TenantService_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTenantResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTenantResponse) ProtoMessage() {}
+
+func (x *DeleteTenantResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_TenantService_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTenantResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTenantResponse) Descriptor() ([]byte, []int) {
+	return file_TenantService_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DeleteTenantResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+var File_TenantService_proto protoreflect.FileDescriptor
+
+const file_TenantService_proto_rawDesc = "" +
+	"\n" +
+	"\x13TenantService.proto\x12\x04auth\"\xd3\x02\n" +
+	"\x06Tenant\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06domain\x18\x03 \x01(\tR\x06domain\x12'\n" +
+	"\x0fdomain_verified\x18\x04 \x01(\bR\x0edomainVerified\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12)\n" +
+	"\x10suspended_reason\x18\x06 \x01(\tR\x0fsuspendedReason\x12/\n" +
+	"\x13allow_impersonation\x18\a \x01(\bR\x12allowImpersonation\x122\n" +
+	"\x15deletion_scheduled_at\x18\b \x01(\x03R\x13deletionScheduledAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\x03R\tupdatedAt\"/\n" +
+	"\x10GetTenantRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\"\xca\x01\n" +
+	"\x13UpdateTenantRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12\x1b\n" +
+	"\x06domain\x18\x03 \x01(\tH\x01R\x06domain\x88\x01\x01\x124\n" +
+	"\x13allow_impersonation\x18\x04 \x01(\bH\x02R\x12allowImpersonation\x88\x01\x01B\a\n" +
+	"\x05_nameB\t\n" +
+	"\a_domainB\x16\n" +
+	"\x14_allow_impersonation\"8\n" +
+	"\x19GetDomainChallengeRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\"\x89\x01\n" +
+	"\x0fDomainChallenge\x12\x16\n" +
+	"\x06domain\x18\x01 \x01(\tR\x06domain\x12\x1f\n" +
+	"\vrecord_name\x18\x02 \x01(\tR\n" +
+	"recordName\x12!\n" +
+	"\frecord_value\x18\x03 \x01(\tR\vrecordValue\x12\x1a\n" +
+	"\bverified\x18\x04 \x01(\bR\bverified\"2\n" +
+	"\x13VerifyDomainRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\"K\n" +
+	"\x14SuspendTenantRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"6\n" +
+	"\x17ReactivateTenantRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\"<\n" +
+	"\x1dScheduleTenantDeletionRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\":\n" +
+	"\x1bCancelTenantDeletionRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\"2\n" +
+	"\x13DeleteTenantRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\"0\n" +
+	"\x14DeleteTenantResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess2\xdb\x04\n" +
+	"\rTenantService\x121\n" +
+	"\tGetTenant\x12\x16.auth.GetTenantRequest\x1a\f.auth.Tenant\x127\n" +
+	"\fUpdateTenant\x12\x19.auth.UpdateTenantRequest\x1a\f.auth.Tenant\x12L\n" +
+	"\x12GetDomainChallenge\x12\x1f.auth.GetDomainChallengeRequest\x1a\x15.auth.DomainChallenge\x127\n" +
+	"\fVerifyDomain\x12\x19.auth.VerifyDomainRequest\x1a\f.auth.Tenant\x129\n" +
+	"\rSuspendTenant\x12\x1a.auth.SuspendTenantRequest\x1a\f.auth.Tenant\x12?\n" +
+	"\x10ReactivateTenant\x12\x1d.auth.ReactivateTenantRequest\x1a\f.auth.Tenant\x12K\n" +
+	"\x16ScheduleTenantDeletion\x12#.auth.ScheduleTenantDeletionRequest\x1a\f.auth.Tenant\x12G\n" +
+	"\x14CancelTenantDeletion\x12!.auth.CancelTenantDeletionRequest\x1a\f.auth.Tenant\x12E\n" +
+	"\fDeleteTenant\x12\x19.auth.DeleteTenantRequest\x1a\x1a.auth.DeleteTenantResponseB\x16Z\x14auth-haven/pkg/protob\x06proto3"
+
+var (
+	file_TenantService_proto_rawDescOnce sync.Once
+	file_TenantService_proto_rawDescData []byte
+)
+
+func file_TenantService_proto_rawDescGZIP() []byte {
+	file_TenantService_proto_rawDescOnce.Do(func() {
+		file_TenantService_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_TenantService_proto_rawDesc), len(file_TenantService_proto_rawDesc)))
+	})
+	return file_TenantService_proto_rawDescData
+}
+
+var file_TenantService_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_TenantService_proto_goTypes = []any{
+	(*Tenant)(nil),                        // 0: auth.Tenant
+	(*GetTenantRequest)(nil),              // 1: auth.GetTenantRequest
+	(*UpdateTenantRequest)(nil),           // 2: auth.UpdateTenantRequest
+	(*GetDomainChallengeRequest)(nil),     // 3: auth.GetDomainChallengeRequest
+	(*DomainChallenge)(nil),               // 4: auth.DomainChallenge
+	(*VerifyDomainRequest)(nil),           // 5: auth.VerifyDomainRequest
+	(*SuspendTenantRequest)(nil),          // 6: auth.SuspendTenantRequest
+	(*ReactivateTenantRequest)(nil),       // 7: auth.ReactivateTenantRequest
+	(*ScheduleTenantDeletionRequest)(nil), // 8: auth.ScheduleTenantDeletionRequest
+	(*CancelTenantDeletionRequest)(nil),   // 9: auth.CancelTenantDeletionRequest
+	(*DeleteTenantRequest)(nil),           // 10: auth.DeleteTenantRequest
+	(*DeleteTenantResponse)(nil),          // 11: auth.DeleteTenantResponse
+}
+var file_TenantService_proto_depIdxs = []int32{
+	1,  // 0: auth.TenantService.GetTenant:input_type -> auth.GetTenantRequest
+	2,  // 1: auth.TenantService.UpdateTenant:input_type -> auth.UpdateTenantRequest
+	3,  // 2: auth.TenantService.GetDomainChallenge:input_type -> auth.GetDomainChallengeRequest
+	5,  // 3: auth.TenantService.VerifyDomain:input_type -> auth.VerifyDomainRequest
+	6,  // 4: auth.TenantService.SuspendTenant:input_type -> auth.SuspendTenantRequest
+	7,  // 5: auth.TenantService.ReactivateTenant:input_type -> auth.ReactivateTenantRequest
+	8,  // 6: auth.TenantService.ScheduleTenantDeletion:input_type -> auth.ScheduleTenantDeletionRequest
+	9,  // 7: auth.TenantService.CancelTenantDeletion:input_type -> auth.CancelTenantDeletionRequest
+	10, // 8: auth.TenantService.DeleteTenant:input_type -> auth.DeleteTenantRequest
+	0,  // 9: auth.TenantService.GetTenant:output_type -> auth.Tenant
+	0,  // 10: auth.TenantService.UpdateTenant:output_type -> auth.Tenant
+	4,  // 11: auth.TenantService.GetDomainChallenge:output_type -> auth.DomainChallenge
+	0,  // 12: auth.TenantService.VerifyDomain:output_type -> auth.Tenant
+	0,  // 13: auth.TenantService.SuspendTenant:output_type -> auth.Tenant
+	0,  // 14: auth.TenantService.ReactivateTenant:output_type -> auth.Tenant
+	0,  // 15: auth.TenantService.ScheduleTenantDeletion:output_type -> auth.Tenant
+	0,  // 16: auth.TenantService.CancelTenantDeletion:output_type -> auth.Tenant
+	11, // 17: auth.TenantService.DeleteTenant:output_type -> auth.DeleteTenantResponse
+	9,  // [9:18] is the sub-list for method output_type
+	0,  // [0:9] is the sub-list for method input_type
+	0,  // [0:0] is the sub-list for extension type_name
+	0,  // [0:0] is the sub-list for extension extendee
+	0,  // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_TenantService_proto_init() }
+func file_TenantService_proto_init() {
+	if File_TenantService_proto != nil {
+		return
+	}
+	file_TenantService_proto_msgTypes[2].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_TenantService_proto_rawDesc), len(file_TenantService_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_TenantService_proto_goTypes,
+		DependencyIndexes: file_TenantService_proto_depIdxs,
+		MessageInfos:      file_TenantService_proto_msgTypes,
+	}.Build()
+	File_TenantService_proto = out.File
+	file_TenantService_proto_goTypes = nil
+	file_TenantService_proto_depIdxs = nil
+}